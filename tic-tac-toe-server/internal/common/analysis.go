@@ -0,0 +1,64 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+// AnalysisPosition представляет фишку на анализируемом поле.
+// Поля с валидацией:
+//   - ID: клетка в формате "i-j" (обязательное)
+//   - Symbol: знак фишки (обязательное, X или O)
+type AnalysisPosition struct {
+	ID     string `json:"id" validate:"required,max=5"`
+	Symbol string `json:"symbol" validate:"required,oneof=X O"`
+}
+
+// AnalysisRequest представляет запрос на анализ позиции.
+// Поля с валидацией:
+//   - Position: позиция в нотации позиции (необязательное, до 512 символов); если задана,
+//     поле, длина линии, фишки, ходящий и вариант берутся из неё
+//   - BorderSize: размер квадратного поля (обязательное без Position, 3-15)
+//   - WinLength: длина выигрышной линии (необязательное, 3-15, по умолчанию во всю длину поля)
+//   - Positions: фишки на поле (необязательное)
+//   - Turn: знак игрока, который ходит (обязательное без Position, X или O)
+//   - Variant: правила подсчёта линии (classic или misere, по умолчанию classic)
+//   - TimeBudget: время на поиск в миллисекундах (необязательное, 1-5000, по умолчанию 1000)
+type AnalysisRequest struct {
+	Position   string             `json:"position" validate:"omitempty,max=512"`
+	BorderSize uint8              `json:"border_size" validate:"required_without=Position,omitempty,min=3,max=15"`
+	WinLength  uint8              `json:"win_length" validate:"omitempty,min=3,max=15,ltefield=BorderSize"`
+	Positions  []AnalysisPosition `json:"positions" validate:"max=225,dive"`
+	Turn       string             `json:"turn" validate:"required_without=Position,omitempty,oneof=X O"`
+	Variant    string             `json:"variant" validate:"omitempty,oneof=classic misere"`
+	TimeBudget uint16             `json:"time_budget" validate:"omitempty,min=1,max=5000"`
+}
+
+// MoveEvaluation представляет оценку одного хода.
+// Поля:
+//   - PositionID: клетка хода в формате "i-j"
+//   - Score: оценка для ходящего (чем больше, тем лучше; выигрыш и проигрыш — по модулю не меньше WinScore-ходы)
+//   - Outcome: исход после хода для ходящего (win/loss/draw/unknown)
+type MoveEvaluation struct {
+	PositionID string `json:"position_id"`
+	Score      int    `json:"score"`
+	Outcome    string `json:"outcome"`
+}
+
+// AnalysisResponse представляет результат анализа позиции.
+// Поля:
+//   - Position: анализируемая позиция в нотации позиции
+//   - Outcome: теоретический исход для ходящего при лучшей игре (win/loss/draw/unknown)
+//   - Exact: исход доказан перебором до конца партии (иначе — оценка на глубину Depth)
+//   - Depth: глубина последней полностью завершённой итерации поиска в полуходах
+//   - Score: оценка лучшего хода
+//   - BestMoves: ходы с лучшей оценкой
+//   - Moves: оценки всех ходов, лучшие первыми
+//   - Nodes: число просмотренных позиций
+type AnalysisResponse struct {
+	Position  string            `json:"position"`
+	Outcome   string            `json:"outcome"`
+	Exact     bool              `json:"exact"`
+	Depth     int               `json:"depth"`
+	Score     int               `json:"score"`
+	BestMoves []string          `json:"best_moves"`
+	Moves     []*MoveEvaluation `json:"moves"`
+	Nodes     uint64            `json:"nodes"`
+}
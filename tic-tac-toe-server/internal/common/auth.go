@@ -40,17 +40,21 @@ type AuthSignUpRequest struct {
 //   - Name: имя пользователя
 //   - Email: электронная почта
 //   - Password: хэш пароля (не возвращается в JSON)
+//   - IsAdmin: пользователь является администратором
+//   - PuzzleRating: рейтинг решения задач
 //   - CreatedAt: дата создания
 //   - UpdatedAt: дата обновления (не возвращается в JSON)
 //   - DeletedAt: дата удаления (soft delete, не возвращается в JSON)
 type User struct {
-	ID        uuid.UUID  `json:"-"`
-	Name      string     `json:"name"`
-	Email     string     `json:"email"`
-	Password  string     `json:"-"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"-"`
-	DeletedAt *time.Time `json:"-"`
+	ID           uuid.UUID  `json:"-"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Password     string     `json:"-"`
+	IsAdmin      bool       `json:"is_admin"`
+	PuzzleRating int        `json:"puzzle_rating"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"-"`
+	DeletedAt    *time.Time `json:"-"`
 }
 
 // UserResponse представляет структуру ответа с данными пользователя.
@@ -60,13 +64,23 @@ type User struct {
 //   - Name: имя пользователя
 //   - Email: электронная почта (может быть опущена)
 //   - WonScore: количество побед (может быть опущено)
+//   - WonScoreByVariant: количество побед в разрезе вариантов правил (может быть опущено)
 //   - Symbol: символ игрока (X/O, может быть опущен)
+//   - IsAdmin: пользователь является администратором (может быть опущен)
+//   - IsBot: игрок комнаты — бот (может быть опущен)
+//   - PuzzleRating: рейтинг решения задач (может быть опущен)
+//   - Ratings: рейтинги Glicko-2 по пулам рейтинговых партий (может быть опущен)
 //   - CreatedAt: дата создания аккаунта (может быть опущена)
 type UserResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Email     string     `json:"email,omitempty"`
-	WonScore  *uint      `json:"current_won_score,omitempty"`
-	Symbol    string     `json:"symbol,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ID                uuid.UUID       `json:"id"`
+	Name              string          `json:"name"`
+	Email             string          `json:"email,omitempty"`
+	WonScore          *uint           `json:"current_won_score,omitempty"`
+	WonScoreByVariant map[string]uint `json:"won_score_by_variant,omitempty"`
+	Symbol            string          `json:"symbol,omitempty"`
+	IsAdmin           bool            `json:"is_admin,omitempty"`
+	IsBot             bool            `json:"is_bot,omitempty"`
+	PuzzleRating      *int            `json:"puzzle_rating,omitempty"`
+	Ratings           []*Rating       `json:"ratings,omitempty"`
+	CreatedAt         *time.Time      `json:"created_at,omitempty"`
 }
@@ -35,10 +35,12 @@ type JWTConfig struct {
 //   - BcryptPower: сложность хеширования паролей (4-31)
 //   - DbConfig: конфигурация базы данных
 //   - JWTConfig: конфигурация JWT аутентификации
+//   - DailyTimezone: часовой пояс, в полночь которого меняется ежедневная задача (например "Europe/Moscow")
 type ServerConfig struct {
-	Port        string
-	LogLevel    int8
-	BcryptPower int
-	DbConfig    DBConfig
-	JWTConfig   JWTConfig
+	Port          string
+	LogLevel      int8
+	BcryptPower   int
+	DbConfig      DBConfig
+	JWTConfig     JWTConfig
+	DailyTimezone string
 }
@@ -0,0 +1,76 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailyChallenge представляет модель ежедневной задачи в базе данных.
+// Поля:
+//   - ID: уникальный идентификатор
+//   - Day: день в часовом поясе DAILY_TIMEZONE в формате "2006-01-02"
+//   - PuzzleID: задача дня
+//   - ChosenBy: администратор, выбравший задачу (nil — выбрана автоматически)
+//   - CreatedAt: дата создания
+type DailyChallenge struct {
+	ID        uint64     `json:"id"`
+	Day       string     `json:"day"`
+	PuzzleID  uint64     `json:"puzzle_id"`
+	ChosenBy  *uuid.UUID `json:"-"`
+	CreatedAt time.Time  `json:"-"`
+}
+
+// DailyAttempt представляет попытку пользователя решить ежедневную задачу.
+// Поля:
+//   - ID: уникальный идентификатор
+//   - DailyID: ежедневная задача
+//   - UserID: пользователь
+//   - Solved: задача решена (nil — попытка ещё не завершена)
+//   - SolveTime: время от первого открытия задачи до завершения попытки в миллисекундах
+//   - StartedAt: время первого открытия задачи
+//   - FinishedAt: время завершения попытки
+type DailyAttempt struct {
+	ID         uint64     `json:"-"`
+	DailyID    uint64     `json:"-"`
+	UserID     uuid.UUID  `json:"-"`
+	Solved     *bool      `json:"solved"`
+	SolveTime  *int64     `json:"solve_time"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// DailyLeaderboardEntry представляет строку таблицы быстрейших решений.
+// Поля:
+//   - Place: место в таблице
+//   - Name: имя пользователя
+//   - SolveTime: время решения в миллисекундах
+type DailyLeaderboardEntry struct {
+	Place     int    `json:"place"`
+	Name      string `json:"name"`
+	SolveTime int64  `json:"solve_time"`
+}
+
+// DailyResponse представляет ежедневную задачу для пользователя.
+// Поля:
+//   - Day: день задачи
+//   - Puzzle: задача без решений
+//   - Attempt: попытка текущего пользователя
+//   - Leaderboard: быстрейшие решения дня
+type DailyResponse struct {
+	Day         string                   `json:"day"`
+	Puzzle      *Puzzle                  `json:"puzzle"`
+	Attempt     *DailyAttempt            `json:"attempt"`
+	Leaderboard []*DailyLeaderboardEntry `json:"leaderboard"`
+}
+
+// DailyChooseRequest представляет выбор ежедневной задачи администратором.
+// Поля с валидацией:
+//   - PuzzleID: задача (обязательное)
+//   - Day: день в формате "2006-01-02" (необязательное, по умолчанию сегодня)
+type DailyChooseRequest struct {
+	PuzzleID uint64 `json:"puzzle_id" validate:"required"`
+	Day      string `json:"day" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -2,6 +2,7 @@
 package dependency
 
 import (
+	"context"
 	"log/slog"
 
 	http_handler "github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/handler/http"
@@ -27,11 +28,19 @@ type GlobalRepositories struct {
 //   - Внедрения зависимостей между слоями
 //   - Предоставления единой точки доступа к сервисам
 type AppDependencies struct {
-	RoomHandler  http_handler.RoomHandler
-	ScoreHandler http_handler.ScoreHandler
-	UserHandler  http_handler.UserHandler
-	AuthHandler  http_handler.AuthHandler
-	WSServer     *service.WSServer
+	RoomHandler        http_handler.RoomHandler
+	ScoreHandler       http_handler.ScoreHandler
+	UserHandler        http_handler.UserHandler
+	AuthHandler        http_handler.AuthHandler
+	VariantHandler     http_handler.VariantHandler
+	AnalysisHandler    http_handler.AnalysisHandler
+	GameHandler        http_handler.GameHandler
+	PuzzleHandler      http_handler.PuzzleHandler
+	DailyHandler       http_handler.DailyHandler
+	LeaderboardHandler http_handler.LeaderboardHandler
+	MatchmakingHandler http_handler.MatchmakingHandler
+	TournamentHandler  http_handler.TournamentHandler
+	WSServer           *service.WSServer
 	GlobalRepositories
 }
 
@@ -40,9 +49,10 @@ type AppDependencies struct {
 // Выполняет:
 //  1. Подключение к базе данных
 //  2. Инициализацию репозиториев
-//  3. Создание сервисов
+//  3. Создание сервисов и регистрацию пользовательских вариантов правил
 //  4. Инициализацию обработчиков
-//  5. Настройку WebSocket сервера
+//  5. Настройку WebSocket сервера, запуск фонового разбора партий, планировщика ежедневной задачи
+//     подбора соперников и расписания турниров; турниры продвигаются по итогам партий WebSocket сервера
 //
 // Возвращает:
 // - *AppDependencies: указатель на инициализированные зависимости
@@ -63,25 +73,67 @@ func NewAppDependencies() *AppDependencies {
 	roomRepo := repository.NewRoomRepository(db)
 	scoreRepo := repository.NewScoreRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	variantRepo := repository.NewVariantRepository(db)
+	gameRepo := repository.NewGameRepository(db)
+	puzzleRepo := repository.NewPuzzleRepository(db)
+	dailyRepo := repository.NewDailyRepository(db)
+	ratingRepo := repository.NewRatingRepository(db)
+	leaderboardRepo := repository.NewLeaderboardRepository(db)
+	tournamentRepo := repository.NewTournamentRepository(db)
 	// Инициализация сервисов
 	roomService := service.NewRoomService(roomRepo)
-	scoreService := service.NewScoreService(scoreRepo, userRepo)
-	userService := service.NewUserService(userRepo, scoreRepo)
+	scoreService := service.NewScoreService(scoreRepo, userRepo, ratingRepo)
+	userService := service.NewUserService(userRepo, scoreRepo, ratingRepo)
 	authService := service.NewAuthService(userRepo)
+	variantService := service.NewVariantService(variantRepo)
+	analysisService := service.NewAnalysisService()
+	puzzleService := service.NewPuzzleService(puzzleRepo, dailyRepo, analysisService)
+	gameService := service.NewGameService(gameRepo, analysisService, puzzleService)
+	go gameService.RunAnalysisWorker(context.Background())
+	dailyService := service.NewDailyService(dailyRepo, puzzleService)
+	go dailyService.RunScheduler(context.Background())
+	leaderboardService := service.NewLeaderboardService(leaderboardRepo)
+	matchmakingService := service.NewMatchmakingService(roomService, ratingRepo)
+	go matchmakingService.RunMatcher(context.Background())
+	tournamentService := service.NewTournamentService(tournamentRepo, roomService, scoreService)
+	go tournamentService.RunScheduler(context.Background())
+	if err := variantService.LoadCustomVariants(context.Background()); err != nil {
+		slog.Error("failed to load custom variants", slog.String("error", err.Error()))
+	}
 	// Создание обработчиков
 	roomHandler := http_handler.NewRoomHandler(*roomService)
 	scoreHandler := http_handler.NewScoreHandler(*scoreService)
 	userHandler := http_handler.NewUserHandler(*userService)
 	authHandler := http_handler.NewAuthHandler(*authService)
+	variantHandler := http_handler.NewVariantHandler(*variantService)
+	analysisHandler := http_handler.NewAnalysisHandler(*analysisService)
+	gameHandler := http_handler.NewGameHandler(*gameService)
+	puzzleHandler := http_handler.NewPuzzleHandler(*puzzleService)
+	dailyHandler := http_handler.NewDailyHandler(*dailyService)
+	leaderboardHandler := http_handler.NewLeaderboardHandler(*leaderboardService)
+	matchmakingHandler := http_handler.NewMatchmakingHandler(*matchmakingService)
+	tournamentHandler := http_handler.NewTournamentHandler(*tournamentService)
+	wsServer := service.NewWsServer(
+		service.NewScoreService(scoreRepo, userRepo, ratingRepo),
+		gameService,
+		tournamentService,
+	)
+	go wsServer.RunBotScheduler(context.Background())
 
 	return &AppDependencies{
-		RoomHandler:  *roomHandler,
-		ScoreHandler: *scoreHandler,
-		UserHandler:  *userHandler,
-		AuthHandler:  *authHandler,
-		WSServer: service.NewWsServer(
-			service.NewScoreService(scoreRepo, userRepo),
-		),
+		RoomHandler:        *roomHandler,
+		ScoreHandler:       *scoreHandler,
+		UserHandler:        *userHandler,
+		AuthHandler:        *authHandler,
+		VariantHandler:     *variantHandler,
+		AnalysisHandler:    *analysisHandler,
+		GameHandler:        *gameHandler,
+		PuzzleHandler:      *puzzleHandler,
+		DailyHandler:       *dailyHandler,
+		LeaderboardHandler: *leaderboardHandler,
+		MatchmakingHandler: *matchmakingHandler,
+		TournamentHandler:  *tournamentHandler,
+		WSServer:           wsServer,
 		GlobalRepositories: GlobalRepositories{
 			UserRepository:  userRepo,
 			ScoreRepository: scoreRepo,
@@ -0,0 +1,122 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GamePlayer описывает участника сохранённой партии.
+// Поля:
+//   - UserID: идентификатор пользователя
+//   - Name: имя пользователя на момент партии
+//   - Symbol: символ игрока в партии
+type GamePlayer struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Symbol string    `json:"symbol"`
+}
+
+// GameMove описывает ход сохранённой партии.
+// Поля:
+//   - Move: порядковый номер хода (начиная с 1)
+//   - PositionID: клетка хода (формат зависит от варианта, например "i-j" или "l-i-j")
+//   - Symbol: поставленный знак
+//   - UserID: игрок, сделавший ход
+type GameMove struct {
+	Move       uint64    `json:"move"`
+	PositionID string    `json:"position_id"`
+	Symbol     string    `json:"symbol"`
+	UserID     uuid.UUID `json:"user_id"`
+}
+
+// MoveAnalysis представляет разбор одного хода партии.
+// Поля:
+//   - Move: порядковый номер хода
+//   - PositionID: сделанный ход
+//   - Symbol: поставленный знак
+//   - Label: оценка хода (best/good/inaccuracy/blunder/missed_win)
+//   - Score: оценка сделанного хода для ходившего
+//   - BestScore: оценка лучшего хода
+//   - BestMoves: лучшие ходы в позиции
+//   - Outcome: исход позиции до хода для ходившего при лучшей игре (win/loss/draw/unknown)
+type MoveAnalysis struct {
+	Move       uint64   `json:"move"`
+	PositionID string   `json:"position_id"`
+	Symbol     string   `json:"symbol"`
+	Label      string   `json:"label"`
+	Score      int      `json:"score"`
+	BestScore  int      `json:"best_score"`
+	BestMoves  []string `json:"best_moves"`
+	Outcome    string   `json:"outcome"`
+}
+
+// GameAnalysis представляет разбор партии.
+// Поля:
+//   - Moves: разбор каждого хода
+//   - Accuracy: точность игроков в процентах по их символам
+//   - Exact: все позиции решены перебором до конца партии
+type GameAnalysis struct {
+	Moves    []*MoveAnalysis    `json:"moves"`
+	Accuracy map[string]float64 `json:"accuracy"`
+	Exact    bool               `json:"exact"`
+}
+
+// Game представляет модель завершённой партии в базе данных.
+// Поля:
+//   - ID: уникальный идентификатор партии
+//   - RoomID: комната, в которой сыграна партия
+//   - Variant: вариант правил
+//   - Width, Height: размеры поля
+//   - WinLength: длина выигрышной линии
+//   - Gravity: режим "гравитации"
+//   - PieceLimit: лимит фишек игрока на поле (0 — без ограничения)
+//   - BlockedCells: заблокированные клетки "i-j"
+//   - StartPosition: начальная позиция в нотации позиции (пустая строка — пустое поле)
+//   - Players: участники партии
+//   - Moves: ходы в порядке их совершения
+//   - WinnerSymbol: символ победителя (пустой при ничьей)
+//   - AnalysisStatus: состояние разбора (pending/done/unsupported/failed)
+//   - Analysis: разбор партии (nil, пока разбор не готов)
+//   - CreatedAt: дата окончания партии
+//   - AnalyzedAt: дата разбора
+type Game struct {
+	ID             uint64        `json:"id"`
+	RoomID         uint64        `json:"room_id"`
+	Variant        string        `json:"variant"`
+	Width          uint8         `json:"width"`
+	Height         uint8         `json:"height"`
+	WinLength      uint8         `json:"win_length"`
+	Gravity        bool          `json:"gravity"`
+	PieceLimit     uint8         `json:"piece_limit"`
+	BlockedCells   []string      `json:"blocked_cells"`
+	StartPosition  string        `json:"start_position"`
+	Players        []GamePlayer  `json:"players"`
+	Moves          []GameMove    `json:"moves"`
+	WinnerSymbol   string        `json:"winner_symbol"`
+	AnalysisStatus string        `json:"analysis_status"`
+	Analysis       *GameAnalysis `json:"analysis"`
+	CreatedAt      time.Time     `json:"created_at"`
+	AnalyzedAt     *time.Time    `json:"analyzed_at"`
+}
+
+// GameAnalysisResponse представляет ответ с разбором партии.
+// Поля:
+//   - GameID: идентификатор партии
+//   - Variant: вариант правил
+//   - StartPosition: начальная позиция в нотации позиции (может быть опущена)
+//   - Players: участники партии
+//   - WinnerSymbol: символ победителя (пустой при ничьей)
+//   - Status: состояние разбора (pending/done/unsupported/failed)
+//   - Analysis: разбор партии (может быть опущен, пока разбор не готов)
+type GameAnalysisResponse struct {
+	GameID        uint64        `json:"game_id"`
+	Variant       string        `json:"variant"`
+	StartPosition string        `json:"start_position,omitempty"`
+	Players       []GamePlayer  `json:"players"`
+	WinnerSymbol  string        `json:"winner_symbol"`
+	Status        string        `json:"status"`
+	Analysis      *GameAnalysis `json:"analysis,omitempty"`
+}
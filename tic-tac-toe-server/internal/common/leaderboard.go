@@ -0,0 +1,56 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"github.com/google/uuid"
+)
+
+// LeaderboardRequest представляет параметры запроса таблицы лидеров (передаются в строке запроса).
+// Поля с валидацией:
+//   - Variant: вариант правил (необязательное, по умолчанию все варианты)
+//   - BoardSize: размер поля "<ширина>x<высота>" (необязательное, по умолчанию все размеры)
+//   - Period: период (all/month/week, по умолчанию all — за всё время)
+//   - Sort: по чему строится таблица (rating/wins, по умолчанию rating)
+//   - Page: номер страницы (необязательное, с 1)
+//   - PerPage: строк на странице (необязательное, 1-100, по умолчанию 20)
+type LeaderboardRequest struct {
+	Variant   string `validate:"omitempty,max=64"`
+	BoardSize string `validate:"omitempty,max=5"`
+	Period    string `validate:"omitempty,oneof=all month week"`
+	Sort      string `validate:"omitempty,oneof=rating wins"`
+	Page      int    `validate:"omitempty,min=1"`
+	PerPage   int    `validate:"omitempty,min=1,max=100"`
+}
+
+// LeaderboardEntry представляет строку таблицы лидеров.
+// Поля:
+//   - Place: место (у игроков с одинаковым значением одно место)
+//   - UserID: идентификатор пользователя
+//   - Name: имя пользователя
+//   - Rating: рейтинг (только в таблице по рейтингу)
+//   - Wins: число побед (только в таблице по победам)
+//   - Games: число партий (в таблице по рейтингу — рейтинговых партий в пуле)
+type LeaderboardEntry struct {
+	Place  int       `json:"place"`
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Rating *float64  `json:"rating,omitempty"`
+	Wins   *uint     `json:"wins,omitempty"`
+	Games  uint      `json:"games"`
+}
+
+// LeaderboardResponse представляет страницу таблицы лидеров.
+// Поля:
+//   - Entries: строки страницы
+//   - Page, PerPage: номер страницы и число строк на странице
+//   - Total: число игроков в таблице
+//   - Current: строка текущего пользователя, даже если она не на странице
+//     (опускается, если пользователя нет в таблице)
+type LeaderboardResponse struct {
+	Entries []*LeaderboardEntry `json:"entries"`
+	Page    int                 `json:"page"`
+	PerPage int                 `json:"per_page"`
+	Total   int                 `json:"total"`
+	Current *LeaderboardEntry   `json:"current,omitempty"`
+}
@@ -0,0 +1,48 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+)
+
+// MatchmakingRequest представляет запрос на постановку в очередь поиска соперника.
+// Поля с валидацией:
+//   - Variant: вариант правил (необязательное, по умолчанию любой вариант)
+//   - BoardSize: размер поля "<ширина>x<высота>" (необязательное, по умолчанию любой размер)
+//   - TimeControl: контроль времени "<минуты>+<добавка в секундах>", например "5+3"
+//     (необязательное, по умолчанию любой)
+//   - IsRated: рейтинговая партия (необязательное, по умолчанию товарищеская)
+//
+// Контроль времени только разводит игроков по очередям: часов в комнатах на сервере
+// пока нет, поэтому созданная комната время на ходы не ограничивает.
+type MatchmakingRequest struct {
+	Variant     string `validate:"omitempty,max=64" json:"variant"`
+	BoardSize   string `validate:"omitempty,max=5" json:"board_size"`
+	TimeControl string `validate:"omitempty,max=7" json:"time_control"`
+	IsRated     *bool  `validate:"omitempty,boolean" json:"is_rated"`
+}
+
+// MatchmakingResponse представляет состояние игрока в очереди поиска соперника.
+// Поля:
+//   - Status: waiting — соперник ищется, matched — комната создана
+//   - Variant, BoardSize, TimeControl, IsRated: выбранные игроком условия партии
+//   - Rating: рейтинг игрока, по которому подбирается соперник
+//   - RatingWindow: на сколько рейтинг соперника может отличаться от рейтинга игрока
+//   - JoinedAt: время постановки в очередь
+//   - RoomID: созданная для пары комната (только для matched)
+//   - Password: пароль комнаты, который нужно передать при подключении (только для matched)
+//   - Opponent: найденный соперник (только для matched)
+type MatchmakingResponse struct {
+	Status       string        `json:"status"`
+	Variant      string        `json:"variant"`
+	BoardSize    string        `json:"board_size"`
+	TimeControl  string        `json:"time_control"`
+	IsRated      bool          `json:"is_rated"`
+	Rating       float64       `json:"rating"`
+	RatingWindow float64       `json:"rating_window"`
+	JoinedAt     time.Time     `json:"joined_at"`
+	RoomID       *uint64       `json:"room_id,omitempty"`
+	Password     string        `json:"password,omitempty"`
+	Opponent     *UserResponse `json:"opponent,omitempty"`
+}
@@ -0,0 +1,21 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+// BoardPosition представляет позицию на двумерном поле, записываемую в нотации позиции.
+// Поля:
+//   - Width, Height: ширина и высота поля
+//   - WinLength: длина выигрышной линии
+//   - Positions: фишки на поле (знаки X, O, triangle, square)
+//   - BlockedCells: заблокированные клетки в формате "i-j"
+//   - Turn: символ игрока, который ходит
+//   - Variant: вариант правил
+type BoardPosition struct {
+	Width        uint8
+	Height       uint8
+	WinLength    uint8
+	Positions    []AnalysisPosition
+	BlockedCells []string
+	Turn         string
+	Variant      string
+}
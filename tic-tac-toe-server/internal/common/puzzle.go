@@ -0,0 +1,59 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+)
+
+// Puzzle представляет модель задачи "выигрыш за N ходов" в базе данных.
+// Поля:
+//   - ID: уникальный идентификатор задачи
+//   - GameID: партия, из которой взята позиция
+//   - Key: ключ позиции для исключения повторов
+//   - Variant: вариант правил (classic или misere)
+//   - BorderSize: размер квадратного поля
+//   - WinLength: длина выигрышной линии
+//   - Positions: фишки на поле
+//   - Turn: знак игрока, который ходит и должен выиграть
+//   - WinIn: за сколько своих ходов достигается выигрыш
+//   - Solutions: первые ходы, ведущие к выигрышу за WinIn ходов
+//   - Rating: сложность задачи
+//   - CreatedAt: дата создания
+type Puzzle struct {
+	ID         uint64             `json:"id"`
+	GameID     uint64             `json:"game_id"`
+	Key        string             `json:"-"`
+	Variant    string             `json:"variant"`
+	BorderSize uint8              `json:"border_size"`
+	WinLength  uint8              `json:"win_length"`
+	Positions  []AnalysisPosition `json:"positions"`
+	Turn       string             `json:"turn"`
+	WinIn      uint8              `json:"win_in"`
+	Solutions  []string           `json:"-"`
+	Rating     int                `json:"rating"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// PuzzleSolveRequest представляет ходы решающего задачу.
+// Поля с валидацией:
+//   - Moves: свои ходы решающего по порядку в формате "i-j" (обязательное, 1-8);
+//     ответы соперника между ними выбирает сервер и возвращает в PuzzleSolveResponse
+type PuzzleSolveRequest struct {
+	Moves []string `json:"moves" validate:"required,min=1,max=8,dive,required,max=5"`
+}
+
+// PuzzleSolveResponse представляет результат проверки ходов задачи.
+// Поля:
+//   - Status: solved — задача решена, continue — ход верный, нужен следующий, failed — ход неверный
+//   - Reply: ответ соперника на последний ход (для continue)
+//   - Rated: попытка изменила рейтинг решения задач (засчитывается только первая попытка)
+//   - Rating: рейтинг решения задач пользователя
+//   - SolveTime: время решения ежедневной задачи в миллисекундах (только для ежедневной задачи)
+type PuzzleSolveResponse struct {
+	Status    string `json:"status"`
+	Reply     string `json:"reply,omitempty"`
+	Rated     bool   `json:"rated"`
+	Rating    int    `json:"rating"`
+	SolveTime *int64 `json:"solve_time,omitempty"`
+}
@@ -0,0 +1,58 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rating представляет рейтинг Glicko-2 пользователя в одном пуле.
+// Пул задаётся вариантом правил и размером поля; пустые вариант и размер — общий
+// рейтинг по всем рейтинговым партиям.
+// Поля:
+//   - UserID: идентификатор пользователя
+//   - Variant: вариант правил пула (пустая строка — общий рейтинг)
+//   - BoardSize: размер поля пула в формате "<ширина>x<высота>" (пустая строка — общий рейтинг)
+//   - Rating: рейтинг
+//   - RD: отклонение рейтинга (чем меньше, тем надёжнее рейтинг)
+//   - Volatility: волатильность рейтинга
+//   - Games: число рейтинговых партий в пуле
+//   - UpdatedAt: дата последнего изменения рейтинга
+type Rating struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Variant    string    `json:"variant"`
+	BoardSize  string    `json:"board_size"`
+	Rating     float64   `json:"rating"`
+	RD         float64   `json:"rd"`
+	Volatility float64   `json:"volatility"`
+	Games      uint      `json:"games"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RatingChange представляет запись истории рейтинга: изменение рейтинга
+// пользователя в пуле после одной рейтинговой партии.
+// Поля:
+//   - ID: идентификатор записи
+//   - UserID: идентификатор пользователя
+//   - ScoreID: идентификатор результата партии, изменившего рейтинг
+//   - Variant, BoardSize: пул рейтинга (пустые — общий рейтинг)
+//   - RatingBefore, RatingAfter: рейтинг до и после партии
+//   - RDBefore, RDAfter: отклонение рейтинга до и после партии
+//   - VolatilityBefore, VolatilityAfter: волатильность до и после партии
+//   - CreatedAt: дата изменения
+type RatingChange struct {
+	ID               uint64    `json:"id"`
+	UserID           uuid.UUID `json:"user_id"`
+	ScoreID          uint64    `json:"score_id"`
+	Variant          string    `json:"variant"`
+	BoardSize        string    `json:"board_size"`
+	RatingBefore     float64   `json:"rating_before"`
+	RatingAfter      float64   `json:"rating_after"`
+	RDBefore         float64   `json:"rd_before"`
+	RDAfter          float64   `json:"rd_after"`
+	VolatilityBefore float64   `json:"volatility_before"`
+	VolatilityAfter  float64   `json:"volatility_after"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -3,6 +3,7 @@
 package common
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,19 +17,47 @@ import (
 //   - CreatorID: ID создателя комнаты (uuid)
 //   - Password: пароль для приватной комнаты (не возвращается в JSON)
 //   - Capacity: максимальное количество игроков
+//   - FirstMovePolicy: правило выбора игрока, который ходит первым
+//   - Variant: идентификатор варианта правил (classic/misere/ultimate/qubic/rolling/wild
+//     или другой зарегистрированный набор правил)
+//   - VariantOptions: настройки варианта в формате JSON (проверяются набором правил)
+//   - Gravity: режим "гравитации" — фишка падает в нижнюю свободную клетку столбца
+//   - WinLength: длина выигрышной линии (0 — во всю длину поля)
+//   - PieceLimit: сколько фишек одного игрока может быть на поле (0 — без ограничения)
+//   - Width, Height: ширина и высота поля (0 — квадратное поле по умолчанию)
+//   - BlockedCells: заблокированные клетки в формате "i-j"
+//   - BlockedSeed: зерно, из которого сгенерированы заблокированные клетки (nil — заданы вручную)
+//   - StartPosition: начальная позиция в нотации позиции (пустая строка — пустое поле)
+//   - IsRated: рейтинговая комната — результаты партий меняют рейтинг игроков
+//   - BotFill: подсадка бота к одинокому игроку (off — нет, offer — предложить, auto — автоматически)
+//   - BotAfter: через сколько минут ожидания соперника подсаживается бот (0 — бот выключен)
 //   - CreatedAt: дата создания комнаты
 //   - UpdatedAt: дата обновления (не возвращается в JSON)
 //   - DeletedAt: дата удаления (soft delete, не возвращается в JSON)
 type Room struct {
-	ID        uint64     `json:"id"`
-	Name      string     `json:"name"`
-	IsPrivate bool       `json:"is_private"`
-	CreatorID uuid.UUID  `json:"creator_id"`
-	Password  string     `json:"-"`
-	Capacity  uint8      `json:"capacity"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"-"`
-	DeletedAt *time.Time `json:"-"`
+	ID              uint64          `json:"id"`
+	Name            string          `json:"name"`
+	IsPrivate       bool            `json:"is_private"`
+	CreatorID       uuid.UUID       `json:"creator_id"`
+	Password        string          `json:"-"`
+	Capacity        uint8           `json:"capacity"`
+	FirstMovePolicy string          `json:"first_move_policy"`
+	Variant         string          `json:"variant"`
+	VariantOptions  json.RawMessage `json:"variant_options"`
+	Gravity         bool            `json:"gravity"`
+	WinLength       uint8           `json:"win_length"`
+	PieceLimit      uint8           `json:"piece_limit"`
+	Width           uint8           `json:"width"`
+	Height          uint8           `json:"height"`
+	BlockedCells    []string        `json:"blocked_cells"`
+	BlockedSeed     *int64          `json:"blocked_seed"`
+	StartPosition   string          `json:"start_position"`
+	IsRated         bool            `json:"is_rated"`
+	BotFill         string          `json:"bot_fill"`
+	BotAfter        uint8           `json:"bot_after"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"-"`
+	DeletedAt       *time.Time      `json:"-"`
 }
 
 // RoomRequest представляет структуру запроса для создания/обновления комнаты.
@@ -37,11 +66,45 @@ type Room struct {
 //   - Name: название комнаты (обязательное, 4-255 символов)
 //   - IsPrivate: флаг приватности (обязательное boolean значение)
 //   - Password: пароль (обязательное если IsPrivate=true, максимум 255 символов)
+//   - Capacity: число игроков (необязательное, 2-4, по умолчанию 2; больше двух — только classic и rolling)
+//   - FirstMovePolicy: правило первого хода (creator/random/alternate/loser, по умолчанию creator)
+//   - Variant: идентификатор зарегистрированного варианта правил (по умолчанию classic)
+//   - VariantOptions: настройки варианта (необязательное, JSON-объект)
+//   - Gravity: режим "гравитации" (необязательное, только для двумерных вариантов)
+//   - WinLength: длина выигрышной линии (необязательное, 3-15)
+//   - PieceLimit: лимит фишек игрока для варианта rolling (необязательное, 1-40, по умолчанию 3)
+//   - Width, Height: ширина и высота поля (необязательные, 3-15, задаются вместе)
+//   - BlockedCells: заблокированные клетки "i-j" (необязательное, не больше трети поля)
+//   - BlockedCount: сколько клеток заблокировать случайно (необязательное, вместо BlockedCells)
+//   - BlockedSeed: зерно для случайной раскладки (необязательное, по умолчанию случайное)
+//   - StartPosition: начальная позиция в нотации позиции (необязательное, до 512 символов);
+//     размеры поля, длина линии и заблокированные клетки берутся из неё
+//   - IsRated: рейтинговая комната (необязательное, по умолчанию товарищеская;
+//     несовместимо с начальной позицией)
+//   - BotFill: подсадка бота к одинокому игроку (off/offer/auto, по умолчанию off;
+//     только для комнат на двух игроков)
+//   - BotAfter: минуты ожидания соперника до подсадки бота (необязательное, 1-60, по умолчанию 3)
 type RoomRequest struct {
-	CreatorID uuid.UUID `json:"creator_id"`
-	Name      string    `validate:"required,min=4,max=255" json:"name"`
-	IsPrivate *bool     `validate:"required,boolean" json:"is_private"`
-	Password  *string   `validate:"required_if=IsPrivate true,max=255" json:"password"`
+	CreatorID       uuid.UUID       `json:"creator_id"`
+	Name            string          `validate:"required,min=4,max=255" json:"name"`
+	IsPrivate       *bool           `validate:"required,boolean" json:"is_private"`
+	Password        *string         `validate:"required_if=IsPrivate true,max=255" json:"password"`
+	Capacity        uint8           `validate:"omitempty,min=2,max=4" json:"capacity"`
+	FirstMovePolicy string          `validate:"omitempty,oneof=creator random alternate loser" json:"first_move_policy"`
+	Variant         string          `validate:"omitempty,max=64" json:"variant"`
+	VariantOptions  json.RawMessage `json:"variant_options"`
+	Gravity         *bool           `validate:"omitempty,boolean" json:"gravity"`
+	WinLength       uint8           `validate:"omitempty,min=3,max=15" json:"win_length"`
+	PieceLimit      uint8           `validate:"omitempty,min=1,max=40" json:"piece_limit"`
+	Width           uint8           `validate:"required_with=Height,omitempty,min=3,max=15" json:"width"`
+	Height          uint8           `validate:"required_with=Width,omitempty,min=3,max=15" json:"height"`
+	BlockedCells    []string        `validate:"omitempty,max=75" json:"blocked_cells"`
+	BlockedCount    uint8           `validate:"excluded_with=BlockedCells,omitempty,min=1,max=75" json:"blocked_count"`
+	BlockedSeed     *int64          `json:"blocked_seed"`
+	StartPosition   string          `validate:"omitempty,max=512" json:"start_position"`
+	IsRated         *bool           `validate:"omitempty,boolean" json:"is_rated"`
+	BotFill         string          `validate:"omitempty,oneof=off offer auto" json:"bot_fill"`
+	BotAfter        uint8           `validate:"omitempty,min=1,max=60" json:"bot_after"`
 }
 
 // RoomResponse представляет упрощенную структуру комнаты для API ответов.
@@ -51,12 +114,36 @@ type RoomRequest struct {
 //   - IsPrivate: флаг приватности
 //   - Capacity: вместимость комнаты
 //   - PlayerIn: текущее количество игроков в комнате
+//   - FirstMovePolicy: правило выбора игрока, который ходит первым
+//   - Variant: вариант правил игры
+//   - VariantOptions: настройки варианта
+//   - Gravity: режим "гравитации"
+//   - WinLength: длина выигрышной линии (0 — во всю длину поля)
+//   - PieceLimit: лимит фишек игрока на поле (0 — без ограничения)
+//   - Width, Height: ширина и высота поля (0 — квадратное поле по умолчанию)
+//   - BlockedCells: заблокированные клетки
+//   - StartPosition: начальная позиция в нотации позиции
+//   - IsRated: рейтинговая комната
+//   - BotFill, BotAfter: подсадка бота к одинокому игроку и минуты ожидания до неё
 type RoomResponse struct {
-	ID        uint64 `json:"id"`
-	Name      string `json:"name"`
-	IsPrivate *bool  `json:"is_private"`
-	Capacity  uint8  `json:"capacity"`
-	PlayerIn  int    `json:"player_in"`
+	ID              uint64          `json:"id"`
+	Name            string          `json:"name"`
+	IsPrivate       *bool           `json:"is_private"`
+	Capacity        uint8           `json:"capacity"`
+	PlayerIn        int             `json:"player_in"`
+	FirstMovePolicy string          `json:"first_move_policy"`
+	Variant         string          `json:"variant"`
+	VariantOptions  json.RawMessage `json:"variant_options,omitempty"`
+	Gravity         bool            `json:"gravity"`
+	WinLength       uint8           `json:"win_length"`
+	PieceLimit      uint8           `json:"piece_limit"`
+	Width           uint8           `json:"width"`
+	Height          uint8           `json:"height"`
+	BlockedCells    []string        `json:"blocked_cells"`
+	StartPosition   string          `json:"start_position,omitempty"`
+	IsRated         bool            `json:"is_rated"`
+	BotFill         string          `json:"bot_fill"`
+	BotAfter        uint8           `json:"bot_after"`
 }
 
 // RoomSessionResponse представляет полную информацию о комнате для игровой сессии.
@@ -67,13 +154,37 @@ type RoomResponse struct {
 //   - Password: пароль комнаты (не возвращается в JSON)
 //   - IsPrivate: флаг приватности (может быть опущен)
 //   - Capacity: вместимость комнаты
+//   - FirstMovePolicy: правило выбора игрока, который ходит первым
+//   - Variant: вариант правил игры
+//   - VariantOptions: настройки варианта
+//   - Gravity: режим "гравитации"
+//   - WinLength: длина выигрышной линии (0 — во всю длину поля)
+//   - PieceLimit: лимит фишек игрока на поле (0 — без ограничения)
+//   - Width, Height: ширина и высота поля (0 — квадратное поле по умолчанию)
+//   - BlockedCells: заблокированные клетки
+//   - StartPosition: начальная позиция в нотации позиции
+//   - IsRated: рейтинговая комната
+//   - BotFill, BotAfter: подсадка бота к одинокому игроку и минуты ожидания до неё
 //   - Users: список пользователей в комнате (сокращенная информация)
 type RoomSessionResponse struct {
-	ID        uint64          `json:"id"`
-	Name      string          `json:"name"`
-	CreatorID uuid.UUID       `json:"creator_id"`
-	Password  string          `json:"-"`
-	IsPrivate *bool           `json:"is_private,omitempty"`
-	Capacity  uint8           `json:"capacity"`
-	Users     []*UserResponse `json:"users"`
+	ID              uint64          `json:"id"`
+	Name            string          `json:"name"`
+	CreatorID       uuid.UUID       `json:"creator_id"`
+	Password        string          `json:"-"`
+	IsPrivate       *bool           `json:"is_private,omitempty"`
+	Capacity        uint8           `json:"capacity"`
+	FirstMovePolicy string          `json:"first_move_policy"`
+	Variant         string          `json:"variant"`
+	VariantOptions  json.RawMessage `json:"variant_options,omitempty"`
+	Gravity         bool            `json:"gravity"`
+	WinLength       uint8           `json:"win_length"`
+	PieceLimit      uint8           `json:"piece_limit"`
+	Width           uint8           `json:"width"`
+	Height          uint8           `json:"height"`
+	BlockedCells    []string        `json:"blocked_cells"`
+	StartPosition   string          `json:"start_position,omitempty"`
+	IsRated         bool            `json:"is_rated"`
+	BotFill         string          `json:"bot_fill"`
+	BotAfter        uint8           `json:"bot_after"`
+	Users           []*UserResponse `json:"users"`
 }
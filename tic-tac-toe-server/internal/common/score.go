@@ -4,6 +4,8 @@ package common
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Score представляет модель игрового результата (счета) пользователя.
@@ -11,17 +13,42 @@ import (
 // Поля:
 //   - ID: уникальный идентификатор записи (не возвращается в JSON)
 //   - UserID: идентификатор пользователя в формате UUID (обязательное поле, не возвращается в JSON)
-//   - IsWon: флаг победы (1 - победа, 0 - поражение, обязательное поле)
+//   - IsWon: флаг победы (1 - победа, 0 - поражение, -1 - ничья), выводится из Result
+//   - Result: результат игрока (win, loss, draw, forfeit, timeout, abandoned)
+//   - Termination: причина завершения партии (line, board_full, exit, timeout,
+//     room_closed, reset; пустая, если причина неизвестна)
+//   - RoomID: комната, в которой сыграна партия (опускается у старых результатов)
+//   - GameID: сохранённая партия (опускается, если партия не была доиграна)
 //   - Nickname: никнейм игрока (отображается в таблице результатов)
+//   - OpponentIDs: идентификаторы соперников (пустой у старых результатов)
+//   - Variant: вариант правил, по которым сыграна партия
+//   - BoardSize: размер поля в формате "<ширина>x<высота>" (пустой у старых результатов)
+//   - Placement: место игрока в партии (1 - первое, при ничьей все на первом месте)
+//   - Players: число игроков в партии
+//   - Symbol: символ, которым играл пользователь (пустой у старых результатов)
+//   - Moves: число ходов в партии (0 — ходов не было или результат старый)
+//   - IsRated: партия сыграна в рейтинговой комнате и изменила рейтинг игрока
 //   - CreatedAt: дата создания записи (может быть опущена в JSON)
 //
 // Валидация:
 //   - UserID должен быть валидным UUID
 //   - IsWon должен быть булевым значением (0 или 1)
 type Score struct {
-	ID        uint64    `json:"-"`
-	UserID    string    `json:"-" validate:"required,uuid"`
-	IsWon     float64   `json:"is_won" validate:"required,boolean"`
-	Nickname  string    `json:"nickname"`
-	CreatedAt time.Time `json:"created_at,omitempty"`
+	ID          uint64      `json:"-"`
+	UserID      string      `json:"-" validate:"required,uuid"`
+	IsWon       float64     `json:"is_won" validate:"required,boolean"`
+	Result      string      `json:"result"`
+	Termination string      `json:"termination"`
+	RoomID      *uint64     `json:"room_id,omitempty"`
+	GameID      *uint64     `json:"game_id,omitempty"`
+	Nickname    string      `json:"nickname"`
+	OpponentIDs []uuid.UUID `json:"opponent_ids"`
+	Variant     string      `json:"variant"`
+	BoardSize   string      `json:"board_size"`
+	Placement   uint8       `json:"placement"`
+	Players     uint8       `json:"players"`
+	Symbol      string      `json:"symbol"`
+	Moves       uint        `json:"moves"`
+	IsRated     bool        `json:"is_rated"`
+	CreatedAt   time.Time   `json:"created_at,omitempty"`
 }
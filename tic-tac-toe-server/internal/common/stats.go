@@ -0,0 +1,65 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"github.com/google/uuid"
+)
+
+// ResultStats представляет счёт партий: победы, поражения, ничьи и процент побед.
+// Поля:
+//   - Games: число партий
+//   - Wins, Losses, Draws: число побед, поражений и ничьих
+//   - WinRate: доля побед от всех партий (от 0 до 1)
+type ResultStats struct {
+	Games   uint    `json:"games"`
+	Wins    uint    `json:"wins"`
+	Losses  uint    `json:"losses"`
+	Draws   uint    `json:"draws"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// DailyStats представляет результаты партий за день (для графиков).
+// Поля:
+//   - Day: день в формате "2006-01-02"
+//   - Wins, Losses, Draws: число побед, поражений и ничьих за день
+type DailyStats struct {
+	Day    string `json:"day"`
+	Wins   uint   `json:"wins"`
+	Losses uint   `json:"losses"`
+	Draws  uint   `json:"draws"`
+}
+
+// UserStats представляет статистику пользователя по всем партиям или по одному
+// варианту правил и размеру поля.
+// Поля:
+//   - Variant, BoardSize: вариант и размер поля (опускаются в общей статистике)
+//   - ResultStats: счёт партий
+//   - CurrentStreak: победы подряд в последних партиях
+//   - BestStreak: наибольшая серия побед подряд
+//   - AverageLength: среднее число ходов в партии (опускается, если длина партий неизвестна)
+//   - BySymbol: счёт партий по символу, которым играл пользователь (X, O, ...)
+//   - Daily: результаты по дням, от старых к новым
+type UserStats struct {
+	Variant   string `json:"variant,omitempty"`
+	BoardSize string `json:"board_size,omitempty"`
+	ResultStats
+	CurrentStreak uint                    `json:"current_streak"`
+	BestStreak    uint                    `json:"best_streak"`
+	AverageLength *float64                `json:"average_length,omitempty"`
+	BySymbol      map[string]*ResultStats `json:"by_symbol"`
+	Daily         []*DailyStats           `json:"daily"`
+}
+
+// UserStatsResponse представляет статистику пользователя.
+// Поля:
+//   - UserID: идентификатор пользователя
+//   - Name: имя пользователя
+//   - Overall: статистика по всем партиям
+//   - Pools: статистика по каждому сочетанию варианта правил и размера поля
+type UserStatsResponse struct {
+	UserID  uuid.UUID    `json:"user_id"`
+	Name    string       `json:"name"`
+	Overall *UserStats   `json:"overall"`
+	Pools   []*UserStats `json:"pools"`
+}
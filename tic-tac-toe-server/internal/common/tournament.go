@@ -0,0 +1,211 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tournament представляет модель турнира в базе данных.
+// Поля:
+//   - ID: уникальный идентификатор
+//   - Name: название турнира
+//   - CreatorID: администратор, создавший турнир
+//   - Format: формат турнира (single_elimination — олимпийская система,
+//     swiss — швейцарская система, round_robin — круговая система)
+//   - Status: состояние турнира (registration/running/finished)
+//   - Variant: вариант правил партий
+//   - BoardSize: размер поля "<ширина>x<высота>" (пустой — поле варианта по умолчанию)
+//   - WinLength: длина выигрышной линии (0 — по умолчанию)
+//   - IsRated: партии турнира рейтинговые
+//   - MaxParticipants: наибольшее число участников
+//   - Rounds: число кругов (до старта — заданное число кругов швейцарской системы или 0)
+//   - CurrentRound: круг, матчи которого сейчас играются (0 до старта)
+//   - StartsAt: время автоматического старта турнира (nil — старт по команде администратора)
+//   - RoundInterval: через сколько минут после начала круга начинается следующий
+//     (0 — по команде администратора; только для швейцарской и круговой систем)
+//   - RoundStartedAt: время начала текущего круга
+//   - WinnerID: победитель турнира (nil, пока турнир не окончен)
+//   - CreatedAt, StartedAt, FinishedAt: даты создания, старта и окончания
+type Tournament struct {
+	ID              uint64     `json:"id"`
+	Name            string     `json:"name"`
+	CreatorID       uuid.UUID  `json:"creator_id"`
+	Format          string     `json:"format"`
+	Status          string     `json:"status"`
+	Variant         string     `json:"variant"`
+	BoardSize       string     `json:"board_size"`
+	WinLength       uint8      `json:"win_length"`
+	IsRated         bool       `json:"is_rated"`
+	MaxParticipants uint16     `json:"max_participants"`
+	Rounds          uint8      `json:"rounds"`
+	CurrentRound    uint8      `json:"current_round"`
+	StartsAt        *time.Time `json:"starts_at"`
+	RoundInterval   uint16     `json:"round_interval"`
+	RoundStartedAt  *time.Time `json:"round_started_at"`
+	WinnerID        *uuid.UUID `json:"winner_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+}
+
+// TournamentParticipant представляет участника турнира.
+// Поля:
+//   - TournamentID: турнир
+//   - UserID: участник
+//   - Name: имя участника
+//   - Seed: номер посева (1 — сильнейший, 0 до старта)
+//   - Rating: рейтинг участника при регистрации, на старте — рейтинг посева
+//   - EliminatedRound: круг, в котором участник выбыл (0 — не выбыл)
+//   - CreatedAt: дата регистрации
+type TournamentParticipant struct {
+	TournamentID    uint64    `json:"-"`
+	UserID          uuid.UUID `json:"user_id"`
+	Name            string    `json:"name"`
+	Seed            uint16    `json:"seed"`
+	Rating          float64   `json:"rating"`
+	EliminatedRound uint8     `json:"eliminated_round"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TournamentMatch представляет матч турнирной сетки.
+// Поля:
+//   - ID: уникальный идентификатор
+//   - TournamentID: турнир
+//   - Round: круг (начиная с 1)
+//   - Position: номер матча в круге (начиная с 0); победитель попадает
+//     в матч Position/2 следующего круга (в турнире на выбывание)
+//   - FirstID, SecondID: участники (nil — ещё не определён или свободный проход);
+//     в швейцарской и круговой системах FirstID ходит первым
+//   - WinnerID: победитель матча (nil в завершённом матче — ничья)
+//   - Status: состояние матча (pending/playing/finished/bye)
+//   - RoomID: комната матча
+//   - RoomPassword: пароль комнаты (сообщается только участникам матча)
+//   - GameID: партия, решившая матч
+//   - UpdatedAt: дата последнего изменения
+type TournamentMatch struct {
+	ID           uint64     `json:"id"`
+	TournamentID uint64     `json:"-"`
+	Round        uint8      `json:"round"`
+	Position     uint16     `json:"position"`
+	FirstID      *uuid.UUID `json:"first_id"`
+	SecondID     *uuid.UUID `json:"second_id"`
+	WinnerID     *uuid.UUID `json:"winner_id"`
+	Status       string     `json:"status"`
+	RoomID       *uint64    `json:"room_id"`
+	RoomPassword string     `json:"-"`
+	GameID       *uint64    `json:"game_id"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TournamentRequest представляет запрос на создание турнира.
+// Поля с валидацией:
+//   - Name: название турнира (обязательное, 4-255 символов)
+//   - Format: формат турнира (необязательное, single_elimination, swiss или round_robin)
+//   - Variant: вариант правил (необязательное, по умолчанию classic)
+//   - BoardSize: размер поля "<ширина>x<высота>" (необязательное, только для вариантов с настраиваемым полем)
+//   - WinLength: длина выигрышной линии (необязательное, 3-15)
+//   - IsRated: рейтинговые партии (необязательное, по умолчанию товарищеские)
+//   - MaxParticipants: наибольшее число участников (необязательное, 2-128, по умолчанию 32)
+//   - Rounds: число кругов (необязательное, 1-15, только для швейцарской системы)
+//   - StartsAt: время автоматического старта (необязательное, в будущем)
+//   - RoundInterval: минуты между началами кругов (необязательное, 1-1440,
+//     только для швейцарской и круговой систем)
+type TournamentRequest struct {
+	Name            string     `validate:"required,min=4,max=255" json:"name"`
+	Format          string     `validate:"omitempty,oneof=single_elimination swiss round_robin" json:"format"`
+	Variant         string     `validate:"omitempty,max=64" json:"variant"`
+	BoardSize       string     `validate:"omitempty,max=5" json:"board_size"`
+	WinLength       uint8      `validate:"omitempty,min=3,max=15" json:"win_length"`
+	IsRated         *bool      `validate:"omitempty,boolean" json:"is_rated"`
+	MaxParticipants uint16     `validate:"omitempty,min=2,max=128" json:"max_participants"`
+	Rounds          uint8      `validate:"omitempty,min=1,max=15" json:"rounds"`
+	StartsAt        *time.Time `json:"starts_at"`
+	RoundInterval   uint16     `validate:"omitempty,min=1,max=1440" json:"round_interval"`
+}
+
+// TournamentMatchWinnerRequest представляет запрос администратора на решение матча турнира.
+// Поля с валидацией:
+//   - WinnerID: победитель матча (необязательное, UUID участника матча;
+//     пустое — ничья, только для швейцарской и круговой систем)
+type TournamentMatchWinnerRequest struct {
+	WinnerID string `validate:"omitempty,uuid" json:"winner_id"`
+}
+
+// TournamentMatchResponse представляет матч турнирной сетки для API ответов.
+// Поля:
+//   - ID, Position, Status: матч, его номер в круге и состояние
+//   - First, Second: участники (могут быть опущены, пока не определены)
+//   - WinnerID: победитель (может быть опущен)
+//   - RoomID: комната матча (может быть опущена)
+//   - Password: пароль комнаты (только для участников матча, который играется)
+//   - GameID: партия, решившая матч (может быть опущена)
+//   - PlayersIn: сколько участников сейчас в комнате матча
+//   - GameStatus: состояние партии в комнате (choose symbol/in process/game end, может быть опущено)
+//   - Moves: число ходов текущей партии в комнате
+type TournamentMatchResponse struct {
+	ID         uint64        `json:"id"`
+	Position   uint16        `json:"position"`
+	Status     string        `json:"status"`
+	First      *UserResponse `json:"first,omitempty"`
+	Second     *UserResponse `json:"second,omitempty"`
+	WinnerID   *uuid.UUID    `json:"winner_id,omitempty"`
+	RoomID     *uint64       `json:"room_id,omitempty"`
+	Password   string        `json:"password,omitempty"`
+	GameID     *uint64       `json:"game_id,omitempty"`
+	PlayersIn  int           `json:"players_in"`
+	GameStatus string        `json:"game_status,omitempty"`
+	Moves      uint64        `json:"moves"`
+}
+
+// TournamentRoundResponse представляет круг турнирной сетки.
+// Поля:
+//   - Round: номер круга
+//   - Matches: матчи круга по порядку
+type TournamentRoundResponse struct {
+	Round   uint8                      `json:"round"`
+	Matches []*TournamentMatchResponse `json:"matches"`
+}
+
+// TournamentStanding представляет строку турнирной таблицы.
+// Поля:
+//   - Rank: место в таблице
+//   - UserID, Name, Seed: участник и его номер посева
+//   - Points: очки (победа и свободный проход — 1, ничья — 0.5)
+//   - Played, Wins, Draws, Losses: сыгранные матчи, победы, ничьи и поражения
+//   - Byes: свободные проходы
+//   - FirstMoves: матчи, в которых участник ходил первым
+//   - Buchholz: сумма очков соперников
+//   - SonnebornBerger: сумма очков побеждённых соперников и половины очков соперников, сыгранных вничью
+type TournamentStanding struct {
+	Rank            int       `json:"rank"`
+	UserID          uuid.UUID `json:"user_id"`
+	Name            string    `json:"name"`
+	Seed            uint16    `json:"seed"`
+	Points          float64   `json:"points"`
+	Played          int       `json:"played"`
+	Wins            int       `json:"wins"`
+	Draws           int       `json:"draws"`
+	Losses          int       `json:"losses"`
+	Byes            int       `json:"byes"`
+	FirstMoves      int       `json:"first_moves"`
+	Buchholz        float64   `json:"buchholz"`
+	SonnebornBerger float64   `json:"sonneborn_berger"`
+}
+
+// TournamentResponse представляет турнир для API ответов.
+// Поля:
+//   - Tournament: настройки и состояние турнира
+//   - Participants: участники по номеру посева (до старта — по времени регистрации)
+//   - Bracket: круги сетки (пустой до старта)
+//   - Standings: турнирная таблица (только для швейцарской и круговой систем после старта)
+//   - IsRegistered: текущий пользователь зарегистрирован в турнире
+type TournamentResponse struct {
+	Tournament   *Tournament                `json:"tournament"`
+	Participants []*TournamentParticipant   `json:"participants"`
+	Bracket      []*TournamentRoundResponse `json:"bracket"`
+	Standings    []*TournamentStanding      `json:"standings,omitempty"`
+	IsRegistered bool                       `json:"is_registered"`
+}
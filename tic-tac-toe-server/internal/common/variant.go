@@ -0,0 +1,68 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VariantSpec описывает пользовательский вариант правил, заданный в JSON или YAML без кода на Go.
+// Поля с валидацией:
+//   - Name: идентификатор варианта (обязательное, 3-64 символа: a-z, 0-9, "-" и "_")
+//   - Title: название варианта для интерфейса (необязательное, до 255 символов)
+//   - Width, Height: ширина и высота поля (обязательные, 3-15)
+//   - WinLength: длина выигрышной линии (обязательное, 3-15, не больше большей стороны поля)
+//   - Directions: направления засчитываемых линий (horizontal/vertical/diagonal/anti_diagonal,
+//     по умолчанию все)
+//   - Gravity: фишка падает в нижнюю свободную клетку столбца
+//   - PieceLimit: лимит фишек игрока на поле (необязательное, 1-40)
+//   - Misere: собравший линию проигрывает (только для двух игроков)
+//   - FirstMovePolicy: кто ходит первым (creator/random/alternate/loser, по умолчанию — настройка комнаты)
+//   - BlockedCells: заблокированные клетки "i-j" (необязательное, не больше трети поля)
+type VariantSpec struct {
+	Name            string   `json:"name" yaml:"name" validate:"required,min=3,max=64"`
+	Title           string   `json:"title" yaml:"title" validate:"max=255"`
+	Width           uint8    `json:"width" yaml:"width" validate:"required,min=3,max=15"`
+	Height          uint8    `json:"height" yaml:"height" validate:"required,min=3,max=15"`
+	WinLength       uint8    `json:"win_length" yaml:"win_length" validate:"required,min=3,max=15"`
+	Directions      []string `json:"directions" yaml:"directions" validate:"omitempty,max=4,dive,oneof=horizontal vertical diagonal anti_diagonal"`
+	Gravity         bool     `json:"gravity" yaml:"gravity"`
+	PieceLimit      uint8    `json:"piece_limit" yaml:"piece_limit" validate:"omitempty,min=1,max=40"`
+	Misere          bool     `json:"misere" yaml:"misere"`
+	FirstMovePolicy string   `json:"first_move_policy" yaml:"first_move_policy" validate:"omitempty,oneof=creator random alternate loser"`
+	BlockedCells    []string `json:"blocked_cells" yaml:"blocked_cells" validate:"omitempty,max=75"`
+}
+
+// CustomVariant представляет модель пользовательского варианта правил в базе данных.
+// Поля:
+//   - ID: уникальный идентификатор записи
+//   - Name: идентификатор варианта (совпадает с Spec.Name)
+//   - Spec: описание правил
+//   - CreatorID: администратор, загрузивший вариант
+//   - CreatedAt: дата создания
+//   - UpdatedAt: дата последнего обновления описания
+type CustomVariant struct {
+	ID        uint64      `json:"id"`
+	Name      string      `json:"name"`
+	Spec      VariantSpec `json:"spec"`
+	CreatorID uuid.UUID   `json:"creator_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// VariantResponse представляет вариант правил в списке доступных вариантов.
+// Поля:
+//   - Name: идентификатор варианта
+//   - Custom: вариант загружен администратором, а не встроен в сервер
+//   - Multiplayer: вариант доступен для комнат больше чем на двух игроков
+//   - BorderSize: фиксированный размер поля (0 — размер настраивается)
+//   - Spec: описание пользовательского варианта (только для Custom)
+type VariantResponse struct {
+	Name        string       `json:"name"`
+	Custom      bool         `json:"custom"`
+	Multiplayer bool         `json:"multiplayer"`
+	BorderSize  uint64       `json:"border_size"`
+	Spec        *VariantSpec `json:"spec,omitempty"`
+}
@@ -0,0 +1,53 @@
+// Package common содержит общие структуры данных и константы для всего приложения.
+// Включает DTO (Data Transfer Objects) для запросов/ответов API и базовые модели.
+package common
+
+import (
+	"time"
+)
+
+// VersusGame представляет партию пользователя, в которой участвовал соперник.
+// Поля:
+//   - IsWon: результат пользователя (1 - победа, 0 - поражение, -1 - ничья)
+//   - Result: результат пользователя (win, loss, draw, forfeit, timeout, abandoned)
+//   - Termination: причина завершения партии
+//   - GameID: сохранённая партия (опускается, если партия не была доиграна)
+//   - Variant, BoardSize: вариант правил и размер поля
+//   - Symbol: символ, которым играл пользователь
+//   - Moves: число ходов в партии
+//   - Players: число игроков в партии
+//   - IsRated: партия рейтинговая
+//   - RatingBefore, RatingAfter: общий рейтинг пользователя до и после партии
+//     (опускаются для товарищеских партий)
+//   - CreatedAt: дата партии
+type VersusGame struct {
+	IsWon        float64   `json:"is_won"`
+	Result       string    `json:"result"`
+	Termination  string    `json:"termination"`
+	GameID       *uint64   `json:"game_id,omitempty"`
+	Variant      string    `json:"variant"`
+	BoardSize    string    `json:"board_size"`
+	Symbol       string    `json:"symbol"`
+	Moves        uint      `json:"moves"`
+	Players      uint8     `json:"players"`
+	IsRated      bool      `json:"is_rated"`
+	RatingBefore *float64  `json:"rating_before,omitempty"`
+	RatingAfter  *float64  `json:"rating_after,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// VersusResponse представляет личные встречи пользователя с соперником.
+// Поля:
+//   - User, Opponent: пользователь и соперник
+//   - Record: счёт пользователя в партиях с соперником (без брошенных партий)
+//   - RatedGames: число рейтинговых партий с соперником
+//   - RatingChange: изменение общего рейтинга пользователя за эти партии
+//   - Recent: последние партии с соперником, начиная с новых
+type VersusResponse struct {
+	User         *UserResponse `json:"user"`
+	Opponent     *UserResponse `json:"opponent"`
+	Record       ResultStats   `json:"record"`
+	RatedGames   uint          `json:"rated_games"`
+	RatingChange float64       `json:"rating_change"`
+	Recent       []*VersusGame `json:"recent"`
+}
@@ -4,6 +4,9 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 )
@@ -27,11 +30,12 @@ func init() {
 // При ошибках парсинга числовых значений завершает работу приложения с panic.
 //
 // Загружаемые параметры:
-//   - LOG_LEVEL: уровень логирования (число)
-//   - BCRYPT_POWER: сложность хеширования bcrypt (число)
+//   - LOG_LEVEL: уровень логирования (число, по умолчанию 0 - Info)
+//   - BCRYPT_POWER: сложность хеширования bcrypt (число, по умолчанию bcrypt.DefaultCost)
 //   - SERVER_PORT: порт сервера
 //   - DB_*: параметры подключения к БД
 //   - JWT_*: параметры JWT токенов
+//   - DAILY_TIMEZONE: часовой пояс смены ежедневной задачи (по умолчанию UTC)
 //
 // Возвращает:
 //   - Инициализирует глобальную переменную ServerConfig
@@ -39,21 +43,31 @@ func init() {
 // Может вызвать panic при:
 //   - Ошибках парсинга числовых параметров
 //   - Отсутствии обязательных переменных окружения
+//   - Неизвестном часовом поясе
 func NewConfig() {
-	logLevel, err := strconv.ParseInt(os.Getenv("LOG_LEVEL"), 10, 8)
+	logLevel, err := parseIntEnv("LOG_LEVEL", 0)
 	if err != nil {
 		slog.Error(err.Error())
 		panic(err.Error())
 	}
-	bcryptPower, err := strconv.ParseInt(os.Getenv("BCRYPT_POWER"), 10, 8)
+	bcryptPower, err := parseIntEnv("BCRYPT_POWER", int64(bcrypt.DefaultCost))
 	if err != nil {
 		slog.Error(err.Error())
 		panic(err.Error())
 	}
+	dailyTimezone := os.Getenv("DAILY_TIMEZONE")
+	if dailyTimezone == "" {
+		dailyTimezone = "UTC"
+	}
+	if _, err := time.LoadLocation(dailyTimezone); err != nil {
+		slog.Error(err.Error())
+		panic(err.Error())
+	}
 	ServerConfig = &common.ServerConfig{
-		Port:        os.Getenv("SERVER_PORT"),
-		LogLevel:    int8(logLevel),
-		BcryptPower: int(bcryptPower),
+		Port:          os.Getenv("SERVER_PORT"),
+		LogLevel:      int8(logLevel),
+		BcryptPower:   int(bcryptPower),
+		DailyTimezone: dailyTimezone,
 		DbConfig: common.DBConfig{
 			Username: os.Getenv("DB_USERNAME"),
 			Password: os.Getenv("DB_PASSWORD"),
@@ -68,3 +82,13 @@ func NewConfig() {
 		},
 	}
 }
+
+// parseIntEnv читает числовую переменную окружения.
+// Пустая переменная заменяется значением по умолчанию, некорректная возвращает ошибку.
+func parseIntEnv(name string, fallback int64) (int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.ParseInt(value, 10, 8)
+}
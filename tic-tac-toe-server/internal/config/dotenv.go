@@ -1,13 +1,16 @@
 package config
 
 import (
+	"errors"
+	"io/fs"
 	"log/slog"
 
 	"github.com/joho/godotenv"
 )
 
 // mustLoadEnv загружает переменные окружения из файла .env.
-// В случае ошибки завершает работу приложения с panic.
+// Если файла нет, переменные берутся из окружения процесса.
+// В случае любой другой ошибки завершает работу приложения с panic.
 //
 // Использует:
 //   - godotenv для загрузки переменных окружения
@@ -15,13 +18,18 @@ import (
 // Особенности:
 //   - Вызывается при инициализации приложения
 //   - Критическая для работы приложения функция
-//   - При отсутствии/недоступности .env файла вызывает panic
+//   - Отсутствие .env не является ошибкой (контейнеры, go test)
+//   - При недоступности или некорректном .env файле вызывает panic
 //
 // Пример использования:
 //
-//	mustLoadEnv() // Загружает .env или завершает приложение
+//	mustLoadEnv() // Загружает .env, если он есть, или завершает приложение
 func mustLoadEnv() {
 	err := godotenv.Load()
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Debug(".env not found, using process environment")
+		return
+	}
 	if err != nil {
 		slog.Error("can't load .env")
 		panic(err)
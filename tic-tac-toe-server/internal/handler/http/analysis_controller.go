@@ -0,0 +1,90 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// AnalysisHandler обрабатывает HTTP запросы для анализа позиций.
+type AnalysisHandler struct {
+	service service.AnalysisService
+}
+
+// NewAnalysisHandler создает новый экземпляр AnalysisHandler.
+//
+// Параметры:
+//   - service: сервис анализа позиций (хранит общую таблицу транспозиций)
+//
+// Возвращает:
+//   - *AnalysisHandler: указатель на созданный обработчик
+func NewAnalysisHandler(service service.AnalysisService) *AnalysisHandler {
+	return &AnalysisHandler{
+		service: service,
+	}
+}
+
+// Analyze обрабатывает запрос на анализ позиции.
+//
+// Логика работы:
+//  1. Проверяет Content-Type запроса
+//  2. Читает и парсит JSON тело запроса (макс. 1MB)
+//  3. Валидирует входные данные (позиция задаётся полями или строкой в нотации позиции)
+//  4. Возвращает исход позиции, лучшие ходы и оценки всех ходов
+//
+// Возможные коды ответа:
+//   - 200: позиция проанализирована
+//   - 400: ошибка парсинга JSON
+//   - 422: ошибки валидации, клетка вне поля/занята дважды или неверная нотация позиции
+//   - 500: внутренняя ошибка сервера
+func (h *AnalysisHandler) Analyze(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	var form common.AnalysisRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	err := json.NewDecoder(r.Body).Decode(&form)
+	if err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(&form)
+	if err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	analysis, err := h.service.Analyze(r.Context(), form)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPosition) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = analysis
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
@@ -0,0 +1,174 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// DailyHandler обрабатывает HTTP запросы для работы с ежедневной задачей.
+type DailyHandler struct {
+	service service.DailyService
+}
+
+// NewDailyHandler создает новый экземпляр DailyHandler.
+//
+// Параметры:
+//   - service: сервис ежедневной задачи
+//
+// Возвращает:
+//   - *DailyHandler: указатель на созданный обработчик
+func NewDailyHandler(service service.DailyService) *DailyHandler {
+	return &DailyHandler{
+		service: service,
+	}
+}
+
+// GetDaily возвращает задачу дня и быстрейшие решения.
+// Первый запрос пользователя за день начинает его попытку.
+//
+// Возможные коды ответа:
+//   - 200: задача без решений, попытка пользователя и таблица решений
+//   - 404: задача дня не выбрана и задач нет
+//   - 500: внутренняя ошибка сервера
+func (h *DailyHandler) GetDaily(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	daily, err := h.service.Get(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrDailyNotFound) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = daily
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// SolveDaily проверяет ходы решения задачи дня.
+//
+// Возможные коды ответа:
+//   - 200: ходы проверены
+//   - 400: ошибка парсинга JSON
+//   - 404: задача дня не выбрана
+//   - 409: задача не открыта или попытка уже использована
+//   - 422: ошибки валидации или ход в занятую клетку/вне поля
+//   - 500: внутренняя ошибка сервера
+func (h *DailyHandler) SolveDaily(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	var form common.PuzzleSolveRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	err := json.NewDecoder(r.Body).Decode(&form)
+	if err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(&form)
+	if err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	result, err := h.service.Solve(r.Context(), form)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDailyNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrDailyNotStarted),
+			errors.Is(err, service.ErrDailyAlreadyAttempted):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidPosition):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = result
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// ChooseDaily назначает задачу дня (только для администраторов).
+//
+// Возможные коды ответа:
+//   - 200: задача дня сохранена
+//   - 400: ошибка парсинга JSON
+//   - 409: задачу дня уже начали решать
+//   - 422: ошибки валидации, прошедший день или задача не найдена
+//   - 500: внутренняя ошибка сервера
+func (h *DailyHandler) ChooseDaily(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	var form common.DailyChooseRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	err := json.NewDecoder(r.Body).Decode(&form)
+	if err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(&form)
+	if err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	challenge, err := h.service.Choose(r.Context(), form)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDailyLocked):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidDailyDay),
+			errors.Is(err, service.ErrPuzzleNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = challenge
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
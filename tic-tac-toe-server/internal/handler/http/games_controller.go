@@ -0,0 +1,65 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// GameHandler обрабатывает HTTP запросы для работы с сохранёнными партиями.
+type GameHandler struct {
+	service service.GameService
+}
+
+// NewGameHandler создает новый экземпляр GameHandler.
+//
+// Параметры:
+//   - service: сервис партий
+//
+// Возвращает:
+//   - *GameHandler: указатель на созданный обработчик
+func NewGameHandler(service service.GameService) *GameHandler {
+	return &GameHandler{
+		service: service,
+	}
+}
+
+// GetGameAnalysis возвращает разбор партии её участнику.
+// Пока разбор не готов, возвращается состояние pending без разбора.
+//
+// Возможные коды ответа:
+//   - 200: состояние разбора и разбор, если он готов
+//   - 403: пользователь не участвовал в партии
+//   - 404: неверный ID или партия не найдена
+//   - 500: внутренняя ошибка сервера
+func (h *GameHandler) GetGameAnalysis(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	analysis, err := h.service.GetAnalysis(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGameNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrGameAccessDenied):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusForbidden)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = analysis
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
@@ -0,0 +1,93 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// LeaderboardHandler обрабатывает HTTP запросы для получения таблиц лидеров.
+type LeaderboardHandler struct {
+	service service.LeaderboardService
+}
+
+// NewLeaderboardHandler создает новый экземпляр LeaderboardHandler.
+//
+// Параметры:
+//   - service: сервис таблиц лидеров
+//
+// Возвращает:
+//   - *LeaderboardHandler: указатель на созданный обработчик
+func NewLeaderboardHandler(service service.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		service: service,
+	}
+}
+
+// GetLeaderboard возвращает страницу таблицы лидеров.
+// Параметры строки запроса: variant, board_size, period (all/month/week),
+// sort (rating/wins), page, per_page.
+//
+// Возможные коды ответа:
+//   - 200: страница таблицы и строка текущего пользователя
+//   - 400: page или per_page не число
+//   - 422: ошибки валидации, неверный размер поля или пул рейтинга
+//   - 500: внутренняя ошибка сервера
+func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	query := r.URL.Query()
+	form := common.LeaderboardRequest{
+		Variant:   query.Get("variant"),
+		BoardSize: query.Get("board_size"),
+		Period:    query.Get("period"),
+		Sort:      query.Get("sort"),
+	}
+	for param, value := range map[string]*int{"page": &form.Page, "per_page": &form.PerPage} {
+		if query.Get(param) == "" {
+			continue
+		}
+		number, err := strconv.Atoi(query.Get(param))
+		if err != nil {
+			resp.ResponseWrite(w, r, http.StatusBadRequest)
+			return
+		}
+		*value = number
+	}
+	validate := validator.New()
+	err := validate.Struct(&form)
+	if err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	leaderboard, err := h.service.Get(r.Context(), form)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidLeaderboardFilter) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = leaderboard
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
@@ -0,0 +1,124 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// MatchmakingHandler обрабатывает HTTP запросы очереди поиска соперника.
+type MatchmakingHandler struct {
+	service service.MatchmakingService
+}
+
+// NewMatchmakingHandler создает новый экземпляр MatchmakingHandler.
+//
+// Параметры:
+//   - service: сервис подбора соперников
+//
+// Возвращает:
+//   - *MatchmakingHandler: указатель на созданный обработчик
+func NewMatchmakingHandler(service service.MatchmakingService) *MatchmakingHandler {
+	return &MatchmakingHandler{
+		service: service,
+	}
+}
+
+// JoinQueue ставит текущего пользователя в очередь поиска соперника.
+//
+// Возможные коды ответа:
+//   - 200: пользователь в очереди (состояние в очереди)
+//   - 400: ошибка парсинга JSON
+//   - 422: ошибки валидации, неизвестный вариант правил, неверный размер поля или контроль времени
+//   - 500: внутренняя ошибка сервера
+func (h *MatchmakingHandler) JoinQueue(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	var form common.MatchmakingRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	if err := validator.New().Struct(&form); err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	ticket, err := h.service.Join(r.Context(), form)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMatchmaking) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = ticket
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// GetTicket возвращает состояние текущего пользователя в очереди.
+// После подбора пары в ответе есть комната, её пароль и соперник.
+//
+// Возможные коды ответа:
+//   - 200: состояние в очереди
+//   - 404: пользователь не стоит в очереди
+//   - 500: внутренняя ошибка сервера
+func (h *MatchmakingHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	ticket, err := h.service.Get(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrNotInMatchmaking) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = ticket
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// LeaveQueue убирает текущего пользователя из очереди.
+//
+// Возможные коды ответа:
+//   - 200: пользователь убран из очереди
+//   - 404: пользователь не стоит в очереди
+//   - 500: внутренняя ошибка сервера
+func (h *MatchmakingHandler) LeaveQueue(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if err := h.service.Leave(r.Context()); err != nil {
+		if errors.Is(err, service.ErrNotInMatchmaking) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
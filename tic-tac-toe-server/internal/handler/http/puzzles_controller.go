@@ -0,0 +1,128 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// PuzzleHandler обрабатывает HTTP запросы для работы с задачами.
+type PuzzleHandler struct {
+	service service.PuzzleService
+}
+
+// NewPuzzleHandler создает новый экземпляр PuzzleHandler.
+//
+// Параметры:
+//   - service: сервис задач
+//
+// Возвращает:
+//   - *PuzzleHandler: указатель на созданный обработчик
+func NewPuzzleHandler(service service.PuzzleService) *PuzzleHandler {
+	return &PuzzleHandler{
+		service: service,
+	}
+}
+
+// GetNextPuzzle возвращает следующую задачу для текущего пользователя.
+//
+// Возможные коды ответа:
+//   - 200: задача без решений
+//   - 404: подходящих задач нет
+//   - 500: внутренняя ошибка сервера
+func (h *PuzzleHandler) GetNextPuzzle(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	puzzle, err := h.service.Next(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrPuzzleNotFound) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = puzzle
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// SolvePuzzle проверяет ходы решения задачи.
+//
+// Логика работы:
+//  1. Извлекает ID задачи из URL параметров
+//  2. Читает и валидирует ходы решающего
+//  3. Возвращает состояние решения, ответ соперника и рейтинг решения задач
+//
+// Возможные коды ответа:
+//   - 200: ходы проверены
+//   - 400: ошибка парсинга JSON
+//   - 404: неверный ID или задача не найдена
+//   - 409: задача — сегодняшняя задача дня, попытка которой ещё не завершена
+//   - 422: ошибки валидации или ход в занятую клетку/вне поля
+//   - 500: внутренняя ошибка сервера
+func (h *PuzzleHandler) SolvePuzzle(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	var form common.PuzzleSolveRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	err = json.NewDecoder(r.Body).Decode(&form)
+	if err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(&form)
+	if err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	result, err := h.service.Solve(r.Context(), id, form)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPuzzleNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrPuzzleIsDaily):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidPosition):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = result
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
@@ -3,6 +3,7 @@ package http_handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -127,7 +128,9 @@ func (h *RoomHandler) GetRoom(ws *service.WSServer) http.HandlerFunc {
 // Возможные коды ответа:
 //   - 200: комната успешно создана
 //   - 400: ошибка парсинга JSON
-//   - 422: ошибки валидации
+//   - 422: ошибки валидации, неизвестный вариант правил, неверные настройки варианта,
+//     заблокированные клетки не помещаются на поле, неверная начальная позиция
+//     или бот в комнате больше чем на двух игроков
 //   - 500: внутренняя ошибка сервера
 func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 	resp := helper.Response{}
@@ -160,6 +163,15 @@ func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.Create(r.Context(), form); err != nil {
+		if errors.Is(err, service.ErrInvalidRoomLayout) ||
+			errors.Is(err, service.ErrUnknownVariant) ||
+			errors.Is(err, service.ErrInvalidVariantOptions) ||
+			errors.Is(err, service.ErrInvalidStartPosition) ||
+			errors.Is(err, service.ErrInvalidBotFill) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
 		resp.ResponseWrite(w, r, http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,341 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// TournamentHandler обрабатывает HTTP запросы для работы с турнирами.
+type TournamentHandler struct {
+	service service.TournamentService
+}
+
+// NewTournamentHandler создает новый экземпляр TournamentHandler.
+//
+// Параметры:
+//   - service: сервис турниров
+//
+// Возвращает:
+//   - *TournamentHandler: указатель на созданный обработчик
+func NewTournamentHandler(service service.TournamentService) *TournamentHandler {
+	return &TournamentHandler{
+		service: service,
+	}
+}
+
+// GetTournaments возвращает последние турниры.
+//
+// Возможные коды ответа:
+//   - 200: список турниров, начиная с новых
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) GetTournaments(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	tournaments, err := h.service.GetAll(r.Context())
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = tournaments
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// GetTournament возвращает обработчик для получения турнира с живой сеткой.
+//
+// Параметры:
+//   - ws: WebSocket сервер для получения состояния комнат матчей
+//
+// Возможные коды ответа:
+//   - 200: турнир, участники, круги сетки и турнирная таблица (для швейцарской и круговой систем)
+//   - 404: неверный ID или турнир не найден
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) GetTournament(ws *service.WSServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := helper.Response{}
+		param := chi.URLParam(r, "id")
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		tournament, err := h.service.GetById(r.Context(), id, ws)
+		if err != nil {
+			if errors.Is(err, service.ErrTournamentNotFound) {
+				resp.Message = err.Error()
+				resp.ResponseWrite(w, r, http.StatusNotFound)
+				return
+			}
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Data = tournament
+		resp.ResponseWrite(w, r, http.StatusOK)
+	}
+}
+
+// CreateTournament создаёт турнир (только для администратора).
+//
+// Возможные коды ответа:
+//   - 200: турнир создан
+//   - 400: ошибка парсинга JSON
+//   - 422: ошибки валидации, неизвестный вариант правил, неверный размер поля,
+//     круги или интервал кругов для неподходящего формата или время старта в прошлом
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) CreateTournament(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	var form common.TournamentRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	if err := validator.New().Struct(&form); err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	tournament, err := h.service.Create(r.Context(), form)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTournament) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = tournament
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// RegisterParticipant регистрирует текущего пользователя в турнире.
+//
+// Возможные коды ответа:
+//   - 200: пользователь зарегистрирован
+//   - 404: неверный ID или турнир не найден
+//   - 409: регистрация закрыта или свободных мест нет
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) RegisterParticipant(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	if err := h.service.Register(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrTournamentClosed), errors.Is(err, service.ErrTournamentFull):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// WithdrawParticipant снимает текущего пользователя с турнира.
+//
+// Возможные коды ответа:
+//   - 200: пользователь снят с турнира
+//   - 404: неверный ID, турнир не найден или пользователь не зарегистрирован
+//   - 409: турнир уже начался
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) WithdrawParticipant(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	if err := h.service.Withdraw(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound), errors.Is(err, service.ErrNotRegistered):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrTournamentClosed):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// StartTournament закрывает регистрацию, посеивает участников и строит сетку
+// или первый круг (только для администратора). Для матчей первого круга создаются комнаты.
+//
+// Возможные коды ответа:
+//   - 200: турнир начат
+//   - 404: неверный ID или турнир не найден
+//   - 409: турнир уже начался
+//   - 422: участников меньше двух
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) StartTournament(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	tournament, err := h.service.Start(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrTournamentClosed):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrNotEnoughParticipants):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = tournament
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// StartRound начинает следующий круг швейцарского или кругового турнира,
+// не дожидаясь интервала кругов (только для администратора).
+//
+// Возможные коды ответа:
+//   - 200: круг начат
+//   - 404: неверный ID или турнир не найден
+//   - 409: турнир не идёт, все круги уже начаты или матчи текущего круга не решены
+//   - 422: круги турнира на выбывание начинаются сами
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) StartRound(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	param := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	tournament, err := h.service.StartRound(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrTournamentNotRunning),
+			errors.Is(err, service.ErrNoMoreRounds),
+			errors.Is(err, service.ErrRoundInProgress):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidTournament):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = tournament
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// SetMatchWinner решает матч турнира, который не может закончиться сам, например
+// если участник не пришёл или комната матча закрыта (только для администратора).
+//
+// Возможные коды ответа:
+//   - 200: матч решён
+//   - 400: ошибка парсинга JSON
+//   - 404: неверный ID, турнир или матч не найден
+//   - 409: турнир не идёт или матч уже решён либо ещё ждёт участника
+//   - 422: ошибки валидации, победитель не играет в матче или ничья в турнире на выбывание
+//   - 500: внутренняя ошибка сервера
+func (h *TournamentHandler) SetMatchWinner(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	if resp.IsValidMediaType(w, r) {
+		return
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	matchID, err := strconv.ParseUint(chi.URLParam(r, "matchId"), 10, 64)
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	var form common.TournamentMatchWinnerRequest
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		slog.Error("Error decoding JSON: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return
+	}
+	if err := validator.New().Struct(&form); err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	tournament, err := h.service.SetMatchWinner(r.Context(), id, matchID, form)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTournamentNotFound), errors.Is(err, service.ErrTournamentMatchNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrTournamentNotRunning), errors.Is(err, service.ErrTournamentMatchDecided):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidMatchWinner):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = tournament
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
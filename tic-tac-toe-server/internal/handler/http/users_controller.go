@@ -2,8 +2,12 @@
 package http_handler
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
 )
@@ -44,3 +48,70 @@ func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	resp.Data = user
 	resp.ResponseWrite(w, r, http.StatusOK)
 }
+
+// GetUserStats возвращает статистику партий пользователя: счёт, серии побед,
+// среднюю длину партии, результаты по символам и по дням — в целом и по вариантам
+// правил и размерам поля.
+//
+// Возможные коды ответа:
+//   - 200: статистика пользователя
+//   - 404: неверный ID или пользователь не найден
+//   - 500: внутренняя ошибка сервера
+func (h *UserHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	stats, err := h.service.GetStats(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Data = stats
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// GetUserVersus возвращает личные встречи пользователя с соперником: счёт,
+// изменение общего рейтинга и последние партии.
+//
+// Возможные коды ответа:
+//   - 200: личные встречи
+//   - 404: неверный ID или пользователь не найден
+//   - 422: пользователь и соперник совпадают
+//   - 500: внутренняя ошибка сервера
+func (h *UserHandler) GetUserVersus(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	opponentID, err := uuid.Parse(chi.URLParam(r, "otherId"))
+	if err != nil {
+		resp.ResponseWrite(w, r, http.StatusNotFound)
+		return
+	}
+	versus, err := h.service.GetVersus(r.Context(), id, opponentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusNotFound)
+		case errors.Is(err, service.ErrInvalidVersus):
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		default:
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.Data = versus
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
@@ -0,0 +1,141 @@
+// Package http_handler предоставляет HTTP обработчики для API игры "Крестики-нолики".
+package http_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/service"
+)
+
+// VariantHandler обрабатывает HTTP запросы для работы с вариантами правил.
+type VariantHandler struct {
+	service service.VariantService
+}
+
+// NewVariantHandler создает новый экземпляр VariantHandler.
+//
+// Параметры:
+//   - service: сервис вариантов правил
+//
+// Возвращает:
+//   - *VariantHandler: указатель на созданный обработчик
+func NewVariantHandler(service service.VariantService) *VariantHandler {
+	return &VariantHandler{
+		service: service,
+	}
+}
+
+// GetVariants возвращает список встроенных и пользовательских вариантов правил.
+//
+// Возможные коды ответа:
+//   - 200: успешное получение списка
+func (h *VariantHandler) GetVariants(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	resp.Data = h.service.GetAll()
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// ValidateVariant проверяет описание варианта без сохранения.
+//
+// Возможные коды ответа:
+//   - 200: описание корректно
+//   - 400: ошибка парсинга JSON или YAML
+//   - 415: тело не JSON и не YAML
+//   - 422: ошибки валидации или противоречивое описание
+//   - 500: внутренняя ошибка сервера
+func (h *VariantHandler) ValidateVariant(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	spec, ok := h.decodeSpec(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Validate(*spec); err != nil {
+		resp.Message = err.Error()
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return
+	}
+	resp.Message = "Valid!"
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// CreateVariant проверяет и сохраняет описание пользовательского варианта (только для администраторов).
+// Вариант с уже существующим именем заменяется, новые партии играются по новому описанию.
+//
+// Возможные коды ответа:
+//   - 200: вариант сохранён и доступен для создания комнат
+//   - 400: ошибка парсинга JSON или YAML
+//   - 403: пользователь не администратор
+//   - 415: тело не JSON и не YAML
+//   - 422: ошибки валидации или противоречивое описание
+//   - 500: внутренняя ошибка сервера
+func (h *VariantHandler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	resp := helper.Response{}
+	spec, ok := h.decodeSpec(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Save(r.Context(), *spec); err != nil {
+		if errors.Is(err, service.ErrInvalidVariantSpec) {
+			resp.Message = err.Error()
+			resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+			return
+		}
+		resp.ResponseWrite(w, r, http.StatusInternalServerError)
+		return
+	}
+	resp.Message = "Created!"
+	resp.ResponseWrite(w, r, http.StatusOK)
+}
+
+// decodeSpec читает описание варианта из тела запроса и проверяет теги validate.
+// Формат тела определяется по Content-Type: application/json, application/yaml
+// или text/yaml; на другие типы отвечает 415.
+// При ошибке сам записывает ответ и возвращает false.
+func (h *VariantHandler) decodeSpec(w http.ResponseWriter, r *http.Request) (*common.VariantSpec, bool) {
+	resp := helper.Response{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var spec common.VariantSpec
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var err error
+	switch mediaType {
+	case "application/json":
+		err = json.NewDecoder(r.Body).Decode(&spec)
+	case "application/yaml", "text/yaml":
+		err = yaml.NewDecoder(r.Body).Decode(&spec)
+	default:
+		resp.Message = "Not valid content-type"
+		resp.ResponseWrite(w, r, http.StatusUnsupportedMediaType)
+		return nil, false
+	}
+	if err != nil {
+		slog.Error("Error decoding variant spec: ", slog.String("error", err.Error()))
+		resp.ResponseWrite(w, r, http.StatusBadRequest)
+		return nil, false
+	}
+	validate := validator.New()
+	if err := validate.Struct(&spec); err != nil {
+		errs := err.(validator.ValidationErrors)
+		humanReadableErrors, err := helper.LocalizedValidationMessages(
+			r.Context(),
+			errs,
+		)
+		if err != nil {
+			slog.Error("Error localizing validation messages: " + err.Error())
+			resp.ResponseWrite(w, r, http.StatusInternalServerError)
+			return nil, false
+		}
+		resp.Errors = humanReadableErrors
+		resp.ResponseWrite(w, r, http.StatusUnprocessableEntity)
+		return nil, false
+	}
+	return &spec, true
+}
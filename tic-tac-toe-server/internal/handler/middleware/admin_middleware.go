@@ -0,0 +1,25 @@
+// Package middleware содержит промежуточные обработчики HTTP запросов
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/helper"
+)
+
+// AdminMiddleware пропускает запрос только от администратора.
+// Должен подключаться после AuthMiddleware, который кладёт пользователя в контекст.
+// Если пользователь не администратор, возвращает HTTP 403.
+func AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(common.USER).(*common.User)
+		if !ok || !user.IsAdmin {
+			resp := helper.Response{}
+			resp.Message = "You should be an administrator!"
+			resp.ResponseWrite(w, r, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
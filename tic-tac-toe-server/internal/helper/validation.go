@@ -56,6 +56,7 @@ func getAttribute(locale string, attribute string) string {
 //   - Поддерживает русский ("ru") и английский ("en") языки
 //   - Английский используется по умолчанию
 //   - Имена полей преобразуются в snake_case
+//   - Параметры правил, не являющиеся полями (числа, списки значений), подставляются как есть
 //   - Сообщения берутся из языковых пакетов (internal/lang)
 func LocalizedValidationMessages(
 	ctx context.Context,
@@ -78,9 +79,13 @@ func LocalizedValidationMessages(
 			"{field}", getAttribute(locale, strcase.ToSnake(err.Field())),
 		)
 		if err.Param() != "" {
+			param := getAttribute(locale, strcase.ToSnake(err.Param()))
+			if param == "" {
+				param = err.Param()
+			}
 			res = strings.ReplaceAll(
 				res,
-				"{param}", getAttribute(locale, strcase.ToSnake(err.Param())),
+				"{param}", param,
 			)
 		}
 		validatedMessages[strcase.ToSnake(err.Field())] = res
@@ -14,6 +14,43 @@ var attribute = map[string]string{
 	"text":                  "Text",
 	"is_private":            "Private",
 	"creator_id":            "Creator",
+	"capacity":              "Capacity",
+	"first_move_policy":     "First move policy",
+	"variant":               "Variant",
+	"gravity":               "Gravity",
+	"win_length":            "Win length",
+	"piece_limit":           "Piece limit",
+	"width":                 "Width",
+	"height":                "Height",
+	"blocked_cells":         "Blocked cells",
+	"blocked_count":         "Blocked cells count",
+	"title":                 "Title",
+	"directions":            "Line directions",
+	"misere":                "Misère",
+	"border_size":           "Board size",
+	"positions":             "Positions",
+	"turn":                  "Side to move",
+	"time_budget":           "Time budget",
+	"moves":                 "Moves",
+	"puzzle_id":             "Puzzle",
+	"day":                   "Day",
+	"position":              "Position",
+	"start_position":        "Start position",
+	"is_rated":              "Rated",
+	"board_size":            "Board size",
+	"time_control":          "Time control",
+	"period":                "Period",
+	"sort":                  "Sort",
+	"page":                  "Page",
+	"per_page":              "Per page",
+	"bot_fill":              "Bot opponent",
+	"bot_after":             "Bot wait time",
+	"format":                "Format",
+	"max_participants":      "Maximum participants",
+	"rounds":                "Rounds",
+	"winner_id":             "Winner",
+	"starts_at":             "Start time",
+	"round_interval":        "Round interval",
 }
 
 func GetAttribute(field string) string {
@@ -1,13 +1,19 @@
 package eng
 
 var messages = map[string]string{
-	"required": "The {field} field is required.",
-	"email":    "The {field} must be a valid email address.",
-	"min":      "The {field} must be at least {param} characters long.",
-	"max":      "The {field} must be at most {param} characters long.",
-	"gte":      "The {field} must be greater than or equal to {param}.",
-	"lte":      "The {field} must be less than or equal to {param}.",
-	"eqfield":  "The field {field} must be equal to the field {param}.",
+	"required":         "The {field} field is required.",
+	"email":            "The {field} must be a valid email address.",
+	"min":              "The {field} must be at least {param} characters long.",
+	"max":              "The {field} must be at most {param} characters long.",
+	"gte":              "The {field} must be greater than or equal to {param}.",
+	"lte":              "The {field} must be less than or equal to {param}.",
+	"eqfield":          "The field {field} must be equal to the field {param}.",
+	"oneof":            "The {field} must be one of: {param}.",
+	"required_with":    "The {field} field is required when {param} is present.",
+	"excluded_with":    "The {field} field must be empty when {param} is present.",
+	"ltefield":         "The {field} must be less than or equal to the field {param}.",
+	"datetime":         "The {field} must match the format {param}.",
+	"required_without": "The {field} field is required when {param} is not present.",
 }
 
 func GetMessages() map[string]string {
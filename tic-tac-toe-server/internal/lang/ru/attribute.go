@@ -1,16 +1,53 @@
 package ru
 
 var attribute = map[string]string{
-	"user_id":     "Пользователь",
-	"category_id": "Категория",
-	"platform_id": "Платформа",
-	"passowrd":    "Пароль",
-	"mail":        "Почта",
-	"name":        "Название",
-	"firstname":   "Имя",
-	"lastname":    "Фамилия",
-	"patronymic":  "Отчество",
-	"text":        "Текст",
+	"user_id":           "Пользователь",
+	"category_id":       "Категория",
+	"platform_id":       "Платформа",
+	"passowrd":          "Пароль",
+	"mail":              "Почта",
+	"name":              "Название",
+	"firstname":         "Имя",
+	"lastname":          "Фамилия",
+	"patronymic":        "Отчество",
+	"text":              "Текст",
+	"capacity":          "Вместимость",
+	"first_move_policy": "Правило первого хода",
+	"variant":           "Вариант правил",
+	"gravity":           "Гравитация",
+	"win_length":        "Длина линии",
+	"piece_limit":       "Лимит фишек",
+	"width":             "Ширина",
+	"height":            "Высота",
+	"blocked_cells":     "Заблокированные клетки",
+	"blocked_count":     "Число заблокированных клеток",
+	"title":             "Название",
+	"directions":        "Направления линий",
+	"misere":            "Поддавки",
+	"border_size":       "Размер поля",
+	"positions":         "Фишки",
+	"turn":              "Ходящий игрок",
+	"time_budget":       "Время на анализ",
+	"moves":             "Ходы",
+	"puzzle_id":         "Задача",
+	"day":               "День",
+	"position":          "Позиция",
+	"start_position":    "Начальная позиция",
+	"is_rated":          "Рейтинговая",
+	"board_size":        "Размер поля",
+	"time_control":      "Контроль времени",
+	"period":            "Период",
+	"sort":              "Сортировка",
+	"page":              "Страница",
+	"per_page":          "Строк на странице",
+	"bot_fill":          "Бот-соперник",
+	"bot_after":         "Ожидание бота",
+	"format":            "Формат",
+	"max_participants":  "Число участников",
+	"rounds":            "Число кругов",
+	"winner_id":         "Победитель",
+	"starts_at":         "Время старта",
+	"round_interval":    "Интервал кругов",
 }
 
 func GetAttribute(field string) string {
@@ -1,13 +1,19 @@
 package ru
 
 var messages = map[string]string{
-	"required": "Поле {field} обязательно для заполнения.",
-	"email":    "Поле {field} должно быть корректным адресом электронной почты.",
-	"min":      "Поле {field} должно содержать не менее {param} символов.",
-	"max":      "Поле {field} должно содержать не более {param} символов.",
-	"gte":      "Поле {field} должно быть больше или равно {param}.",
-	"lte":      "Поле {field} должно быть меньше или равно {param}.",
-	"eqfield":  "Поле {field} должно быть равно полью {param}.",
+	"required":         "Поле {field} обязательно для заполнения.",
+	"email":            "Поле {field} должно быть корректным адресом электронной почты.",
+	"min":              "Поле {field} должно содержать не менее {param} символов.",
+	"max":              "Поле {field} должно содержать не более {param} символов.",
+	"gte":              "Поле {field} должно быть больше или равно {param}.",
+	"lte":              "Поле {field} должно быть меньше или равно {param}.",
+	"eqfield":          "Поле {field} должно быть равно полью {param}.",
+	"oneof":            "Поле {field} должно иметь одно из значений: {param}.",
+	"required_with":    "Поле {field} обязательно, если указано поле {param}.",
+	"excluded_with":    "Поле {field} должно быть пустым, если указано поле {param}.",
+	"ltefield":         "Поле {field} должно быть не больше поля {param}.",
+	"datetime":         "Поле {field} должно соответствовать формату {param}.",
+	"required_without": "Поле {field} обязательно, если не указано поле {param}.",
 }
 
 func GetMessages() map[string]string {
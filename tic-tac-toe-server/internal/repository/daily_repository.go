@@ -0,0 +1,276 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// DailyRepo реализует DailyRepository для работы с PostgreSQL
+type DailyRepo struct {
+	db *sql.DB
+}
+
+// DailyRepository определяет контракт для работы с хранилищем ежедневных задач
+type DailyRepository interface {
+	// FindByDay находит ежедневную задачу за день
+	FindByDay(ctx context.Context, day string) (*common.DailyChallenge, error)
+
+	// Create сохраняет ежедневную задачу, если за этот день задача ещё не выбрана
+	Create(ctx context.Context, challenge *common.DailyChallenge) error
+
+	// SetPuzzle сохраняет или заменяет задачу дня, если её ещё не начали решать
+	SetPuzzle(ctx context.Context, challenge *common.DailyChallenge) (bool, error)
+
+	// PickPuzzleId выбирает задачу для автоматической ежедневной задачи
+	PickPuzzleId(ctx context.Context) (uint64, error)
+
+	// StartAttempt отмечает первое открытие ежедневной задачи пользователем
+	StartAttempt(ctx context.Context, dailyID uint64, userID uuid.UUID) error
+
+	// FindAttempt находит попытку пользователя
+	FindAttempt(ctx context.Context, dailyID uint64, userID uuid.UUID) (*common.DailyAttempt, error)
+
+	// HasUnfinishedDaily проверяет, что задача — задача дня, попытку которой пользователь не завершил
+	HasUnfinishedDaily(ctx context.Context, puzzleID uint64, userID uuid.UUID, day string) (bool, error)
+
+	// FinishAttempt завершает попытку пользователя и возвращает время решения
+	FinishAttempt(ctx context.Context, id uint64, solved bool) (int64, error)
+
+	// FindLeaderboard возвращает быстрейшие решения ежедневной задачи
+	FindLeaderboard(ctx context.Context, dailyID uint64, limit int) ([]*common.DailyLeaderboardEntry, error)
+}
+
+// NewDailyRepository создает новый экземпляр DailyRepository
+func NewDailyRepository(db *sql.DB) DailyRepository {
+	return &DailyRepo{
+		db: db,
+	}
+}
+
+// FindByDay находит ежедневную задачу за день
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - day: день в формате "2006-01-02"
+//
+// Возвращает:
+//   - *common.DailyChallenge: найденная ежедневная задача
+//   - error: sql.ErrNoRows, если задача за день не выбрана, или ошибка запроса
+func (repo *DailyRepo) FindByDay(ctx context.Context, day string) (*common.DailyChallenge, error) {
+	var challenge common.DailyChallenge
+	var date time.Time
+	query := "SELECT id, day, puzzle_id, chosen_by, created_at FROM daily_challenges WHERE day = $1"
+	row := repo.db.QueryRowContext(ctx, query, day)
+	err := row.Scan(
+		&challenge.ID,
+		&date,
+		&challenge.PuzzleID,
+		&challenge.ChosenBy,
+		&challenge.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	challenge.Day = date.Format(time.DateOnly)
+	return &challenge, nil
+}
+
+// Create сохраняет автоматически выбранную ежедневную задачу
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - challenge: день и задача
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Если за день задача уже выбрана (например, администратором), запись пропускается без ошибки
+func (repo *DailyRepo) Create(ctx context.Context, challenge *common.DailyChallenge) error {
+	query := "INSERT INTO daily_challenges (day, puzzle_id, chosen_by) VALUES ($1, $2, $3) ON CONFLICT (day) DO NOTHING"
+	_, err := repo.db.ExecContext(ctx, query, challenge.Day, challenge.PuzzleID, challenge.ChosenBy)
+	return err
+}
+
+// SetPuzzle сохраняет или заменяет задачу дня, если её ещё не начали решать
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - challenge: день, задача и выбравший её администратор
+//
+// Возвращает:
+//   - bool: false, если у задачи дня уже есть попытки и она не заменена
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Запись дня блокируется до проверки попыток: внешний ключ попытки блокирует ту же
+//     запись, поэтому попытка, начатая одновременно с заменой, либо видна проверке,
+//     либо ждёт замены и начинается уже с новой задачей
+func (repo *DailyRepo) SetPuzzle(ctx context.Context, challenge *common.DailyChallenge) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	query := "SELECT id FROM daily_challenges WHERE day = $1 FOR UPDATE"
+	if _, err := tx.ExecContext(ctx, query, challenge.Day); err != nil {
+		return false, err
+	}
+	query = "INSERT INTO daily_challenges (day, puzzle_id, chosen_by) VALUES ($1, $2, $3) ON CONFLICT (day) DO UPDATE SET puzzle_id = EXCLUDED.puzzle_id, chosen_by = EXCLUDED.chosen_by WHERE NOT EXISTS (SELECT 1 FROM daily_attempts WHERE daily_attempts.daily_id = daily_challenges.id)"
+	result, err := tx.ExecContext(ctx, query, challenge.Day, challenge.PuzzleID, challenge.ChosenBy)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	return true, tx.Commit()
+}
+
+// PickPuzzleId выбирает задачу для автоматической ежедневной задачи
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//
+// Возвращает:
+//   - uint64: идентификатор случайной задачи, которая ещё не была задачей дня
+//     (если таких нет — любой случайной задачи)
+//   - error: sql.ErrNoRows, если задач нет, или ошибка запроса
+func (repo *DailyRepo) PickPuzzleId(ctx context.Context) (uint64, error) {
+	var id uint64
+	query := "SELECT id FROM puzzles ORDER BY EXISTS (SELECT 1 FROM daily_challenges WHERE daily_challenges.puzzle_id = puzzles.id), random() LIMIT 1"
+	err := repo.db.QueryRowContext(ctx, query).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// StartAttempt отмечает первое открытие ежедневной задачи пользователем
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - dailyID: идентификатор ежедневной задачи
+//   - userID: идентификатор пользователя
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Повторное открытие не меняет время начала попытки
+func (repo *DailyRepo) StartAttempt(ctx context.Context, dailyID uint64, userID uuid.UUID) error {
+	query := "INSERT INTO daily_attempts (daily_id, user_id) VALUES ($1, $2) ON CONFLICT (daily_id, user_id) DO NOTHING"
+	_, err := repo.db.ExecContext(ctx, query, dailyID, userID)
+	return err
+}
+
+// FindAttempt находит попытку пользователя
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - dailyID: идентификатор ежедневной задачи
+//   - userID: идентификатор пользователя
+//
+// Возвращает:
+//   - *common.DailyAttempt: найденная попытка
+//   - error: sql.ErrNoRows, если пользователь не открывал задачу, или ошибка запроса
+func (repo *DailyRepo) FindAttempt(ctx context.Context, dailyID uint64, userID uuid.UUID) (*common.DailyAttempt, error) {
+	var attempt common.DailyAttempt
+	query := "SELECT id, daily_id, user_id, solved, solve_time, started_at, finished_at FROM daily_attempts WHERE daily_id = $1 AND user_id = $2"
+	row := repo.db.QueryRowContext(ctx, query, dailyID, userID)
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.DailyID,
+		&attempt.UserID,
+		&attempt.Solved,
+		&attempt.SolveTime,
+		&attempt.StartedAt,
+		&attempt.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// HasUnfinishedDaily проверяет, что задача — задача дня, попытку которой пользователь не завершил
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - puzzleID: идентификатор задачи
+//   - userID: идентификатор пользователя
+//   - day: день в формате "2006-01-02"
+//
+// Возвращает:
+//   - bool: задача выбрана задачей дня, а пользователь её не открывал или ещё решает
+//   - error: ошибка запроса
+func (repo *DailyRepo) HasUnfinishedDaily(ctx context.Context, puzzleID uint64, userID uuid.UUID, day string) (bool, error) {
+	var pending bool
+	query := "SELECT EXISTS (SELECT 1 FROM daily_challenges WHERE daily_challenges.puzzle_id = $1 AND daily_challenges.day = $3 AND NOT EXISTS (SELECT 1 FROM daily_attempts WHERE daily_attempts.daily_id = daily_challenges.id AND daily_attempts.user_id = $2 AND daily_attempts.finished_at IS NOT NULL))"
+	err := repo.db.QueryRowContext(ctx, query, puzzleID, userID, day).Scan(&pending)
+	if err != nil {
+		return false, err
+	}
+	return pending, nil
+}
+
+// FinishAttempt завершает попытку пользователя
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор попытки
+//   - solved: задача решена
+//
+// Возвращает:
+//   - int64: время от первого открытия задачи до завершения попытки в миллисекундах
+//   - error: sql.ErrNoRows, если попытка уже завершена, или ошибка запроса
+//
+// Особенности:
+//   - Время считается по часам базы данных, как и время начала попытки
+func (repo *DailyRepo) FinishAttempt(ctx context.Context, id uint64, solved bool) (int64, error) {
+	var solveTime int64
+	query := "UPDATE daily_attempts SET solved = $1, finished_at = CURRENT_TIMESTAMP, solve_time = (EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - started_at) * 1000)::INT WHERE id = $2 AND finished_at IS NULL RETURNING solve_time"
+	err := repo.db.QueryRowContext(ctx, query, solved, id).Scan(&solveTime)
+	if err != nil {
+		return 0, err
+	}
+	return solveTime, nil
+}
+
+// FindLeaderboard возвращает быстрейшие решения ежедневной задачи
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - dailyID: идентификатор ежедневной задачи
+//   - limit: наибольшее число строк
+//
+// Возвращает:
+//   - []*common.DailyLeaderboardEntry: решившие задачу по возрастанию времени решения
+//   - error: ошибка запроса
+func (repo *DailyRepo) FindLeaderboard(ctx context.Context, dailyID uint64, limit int) ([]*common.DailyLeaderboardEntry, error) {
+	entries := make([]*common.DailyLeaderboardEntry, 0)
+	query := "SELECT users.name, daily_attempts.solve_time FROM daily_attempts JOIN users ON users.id = daily_attempts.user_id WHERE daily_attempts.daily_id = $1 AND daily_attempts.solved ORDER BY daily_attempts.solve_time, daily_attempts.finished_at LIMIT $2"
+	rows, err := repo.db.QueryContext(ctx, query, dailyID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		entry := common.DailyLeaderboardEntry{Place: len(entries) + 1}
+		if err := rows.Scan(&entry.Name, &entry.SolveTime); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
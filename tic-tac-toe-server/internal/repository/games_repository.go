@@ -0,0 +1,191 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// GameRepo реализует GameRepository для работы с PostgreSQL
+type GameRepo struct {
+	db *sql.DB
+}
+
+// GameRepository определяет контракт для работы с хранилищем завершённых партий
+type GameRepository interface {
+	// Create сохраняет завершённую партию и возвращает её идентификатор
+	Create(ctx context.Context, game *common.Game) (uint64, error)
+
+	// FindById находит партию по идентификатору
+	FindById(ctx context.Context, id uint64) (*common.Game, error)
+
+	// FindIdsByAnalysisStatus возвращает идентификаторы партий с указанным состоянием разбора
+	FindIdsByAnalysisStatus(ctx context.Context, status string, limit int) ([]uint64, error)
+
+	// SaveAnalysis сохраняет состояние разбора партии и сам разбор
+	SaveAnalysis(ctx context.Context, id uint64, status string, analysis *common.GameAnalysis) error
+}
+
+// NewGameRepository создает новый экземпляр GameRepository
+func NewGameRepository(db *sql.DB) GameRepository {
+	return &GameRepo{
+		db: db,
+	}
+}
+
+// Create сохраняет завершённую партию
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - game: партия с участниками и ходами
+//
+// Возвращает:
+//   - uint64: идентификатор созданной партии
+//   - error: ошибка, если партия не была сохранена
+//
+// Особенности:
+//   - Участники и ходы хранятся в колонках players и moves как JSONB
+//   - Состояние разбора берётся из game.AnalysisStatus
+func (repo *GameRepo) Create(ctx context.Context, game *common.Game) (uint64, error) {
+	players, err := json.Marshal(game.Players)
+	if err != nil {
+		return 0, err
+	}
+	moves, err := json.Marshal(game.Moves)
+	if err != nil {
+		return 0, err
+	}
+	var id uint64
+	query := "INSERT INTO games (room_id, variant, width, height, win_length, gravity, piece_limit, blocked_cells, start_position, players, moves, winner_symbol, analysis_status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id"
+	err = repo.db.QueryRowContext(
+		ctx,
+		query,
+		game.RoomID,
+		game.Variant,
+		game.Width,
+		game.Height,
+		game.WinLength,
+		game.Gravity,
+		game.PieceLimit,
+		pq.Array(game.BlockedCells),
+		game.StartPosition,
+		players,
+		moves,
+		game.WinnerSymbol,
+		game.AnalysisStatus,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindById находит партию по идентификатору
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор партии
+//
+// Возвращает:
+//   - *common.Game: найденная партия вместе с разбором (если он готов)
+//   - error: ошибка, если партия не найдена или произошла ошибка запроса
+func (repo *GameRepo) FindById(ctx context.Context, id uint64) (*common.Game, error) {
+	var game common.Game
+	var players, moves, analysis []byte
+	query := "SELECT id, room_id, variant, width, height, win_length, gravity, piece_limit, blocked_cells, start_position, players, moves, winner_symbol, analysis_status, analysis, created_at, analyzed_at FROM games WHERE id = $1"
+	row := repo.db.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&game.ID,
+		&game.RoomID,
+		&game.Variant,
+		&game.Width,
+		&game.Height,
+		&game.WinLength,
+		&game.Gravity,
+		&game.PieceLimit,
+		pq.Array(&game.BlockedCells),
+		&game.StartPosition,
+		&players,
+		&moves,
+		&game.WinnerSymbol,
+		&game.AnalysisStatus,
+		&analysis,
+		&game.CreatedAt,
+		&game.AnalyzedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(players, &game.Players); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(moves, &game.Moves); err != nil {
+		return nil, err
+	}
+	if analysis != nil {
+		if err := json.Unmarshal(analysis, &game.Analysis); err != nil {
+			return nil, err
+		}
+	}
+	return &game, nil
+}
+
+// FindIdsByAnalysisStatus возвращает идентификаторы партий с указанным состоянием разбора
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - status: состояние разбора (например, pending)
+//   - limit: наибольшее число идентификаторов
+//
+// Возвращает:
+//   - []uint64: идентификаторы, старые партии первыми
+//   - error: ошибка запроса
+func (repo *GameRepo) FindIdsByAnalysisStatus(ctx context.Context, status string, limit int) ([]uint64, error) {
+	ids := make([]uint64, 0)
+	query := "SELECT id FROM games WHERE analysis_status = $1 ORDER BY id LIMIT $2"
+	rows, err := repo.db.QueryContext(ctx, query, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SaveAnalysis сохраняет состояние разбора партии
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор партии
+//   - status: новое состояние разбора
+//   - analysis: разбор партии (nil, если разбора нет)
+//
+// Возвращает:
+//   - error: ошибка, если не удалось обновить запись
+//
+// Особенности:
+//   - Устанавливает analyzed_at в текущее время
+func (repo *GameRepo) SaveAnalysis(ctx context.Context, id uint64, status string, analysis *common.GameAnalysis) error {
+	var raw []byte
+	if analysis != nil {
+		var err error
+		raw, err = json.Marshal(analysis)
+		if err != nil {
+			return err
+		}
+	}
+	query := "UPDATE games SET analysis_status = $1, analysis = $2, analyzed_at = CURRENT_TIMESTAMP WHERE id = $3"
+	_, err := repo.db.ExecContext(ctx, query, status, raw, id)
+	return err
+}
@@ -0,0 +1,154 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// LeaderboardRepo реализует LeaderboardRepository для работы с PostgreSQL
+type LeaderboardRepo struct {
+	db *sql.DB
+}
+
+// LeaderboardRepository определяет контракт для построения таблиц лидеров
+type LeaderboardRepository interface {
+	// FindPage возвращает страницу таблицы лидеров и число игроков в ней
+	FindPage(ctx context.Context, form *common.LeaderboardRequest) ([]*common.LeaderboardEntry, int, error)
+
+	// FindEntry возвращает строку пользователя в таблице лидеров
+	FindEntry(ctx context.Context, form *common.LeaderboardRequest, userID uuid.UUID) (*common.LeaderboardEntry, error)
+}
+
+// NewLeaderboardRepository создает новый экземпляр LeaderboardRepository
+func NewLeaderboardRepository(db *sql.DB) LeaderboardRepository {
+	return &LeaderboardRepo{
+		db: db,
+	}
+}
+
+// leaderboardPeriods сопоставляет периоду таблицы лидеров единицу date_trunc,
+// с начала которой считаются партии (период all не ограничивает партии).
+var leaderboardPeriods = map[string]string{
+	"month": "month",
+	"week":  "week",
+}
+
+// FindPage возвращает страницу таблицы лидеров
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - form: фильтры, сортировка и страница (заполненные значениями по умолчанию)
+//
+// Возвращает:
+//   - []*common.LeaderboardEntry: строки страницы по местам
+//   - int: число игроков в таблице
+//   - error: ошибка запроса
+func (repo *LeaderboardRepo) FindPage(ctx context.Context, form *common.LeaderboardRequest) ([]*common.LeaderboardEntry, int, error) {
+	standings, args := leaderboardStandings(form)
+	var total int
+	query := "SELECT COUNT(*) FROM (" + standings + ") standings"
+	if err := repo.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	query = fmt.Sprintf(
+		"SELECT user_id, name, value, games, place FROM (%s) ranked ORDER BY place, name, user_id LIMIT $%d OFFSET $%d",
+		leaderboardRanked(standings), len(args)+1, len(args)+2,
+	)
+	args = append(args, form.PerPage, (form.Page-1)*form.PerPage)
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	entries := make([]*common.LeaderboardEntry, 0)
+	for rows.Next() {
+		entry, err := scanLeaderboardEntry(rows, form.Sort)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
+
+// FindEntry возвращает строку пользователя в таблице лидеров
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - form: фильтры и сортировка таблицы
+//   - userID: идентификатор пользователя
+//
+// Возвращает:
+//   - *common.LeaderboardEntry: строка пользователя с его местом во всей таблице
+//   - error: sql.ErrNoRows, если пользователя нет в таблице, или ошибка запроса
+func (repo *LeaderboardRepo) FindEntry(ctx context.Context, form *common.LeaderboardRequest, userID uuid.UUID) (*common.LeaderboardEntry, error) {
+	standings, args := leaderboardStandings(form)
+	query := fmt.Sprintf(
+		"SELECT user_id, name, value, games, place FROM (%s) ranked WHERE user_id = $%d",
+		leaderboardRanked(standings), len(args)+1,
+	)
+	args = append(args, userID)
+	return scanLeaderboardEntry(repo.db.QueryRowContext(ctx, query, args...), form.Sort)
+}
+
+// leaderboardStandings строит запрос значений таблицы лидеров (user_id, value, games)
+//
+// Особенности:
+//   - Таблица по рейтингу берётся из пула рейтинга (пустые вариант и размер поля — общий рейтинг);
+//     за месяц или неделю в неё входят игроки, сыгравшие в пуле рейтинговую партию за период
+//   - Таблица по победам считается по результатам партий без брошенных партий;
+//     в неё входят игроки хотя бы с одной победой
+//   - Оба запроса используют индексы ratings_pool_rating_index, ratings_updated_at_index,
+//     scores_leaderboard_index и scores_created_at_index
+func leaderboardStandings(form *common.LeaderboardRequest) (string, []any) {
+	if form.Sort == "rating" {
+		query := "SELECT user_id, rating AS value, games FROM ratings WHERE variant = $1 AND board_size = $2"
+		if unit, ok := leaderboardPeriods[form.Period]; ok {
+			query += fmt.Sprintf(" AND updated_at >= date_trunc('%s', LOCALTIMESTAMP)", unit)
+		}
+		return query, []any{form.Variant, form.BoardSize}
+	}
+	query := "SELECT user_id, COUNT(*) FILTER (WHERE result = 'win') AS value, COUNT(*) AS games FROM scores WHERE result <> 'abandoned' AND deleted_at IS NULL"
+	args := make([]any, 0, 2)
+	if form.Variant != "" {
+		args = append(args, form.Variant)
+		query += fmt.Sprintf(" AND variant = $%d", len(args))
+	}
+	if form.BoardSize != "" {
+		args = append(args, form.BoardSize)
+		query += fmt.Sprintf(" AND board_size = $%d", len(args))
+	}
+	if unit, ok := leaderboardPeriods[form.Period]; ok {
+		query += fmt.Sprintf(" AND created_at >= date_trunc('%s', LOCALTIMESTAMP)", unit)
+	}
+	query += " GROUP BY user_id HAVING COUNT(*) FILTER (WHERE result = 'win') > 0"
+	return query, args
+}
+
+// leaderboardRanked добавляет к значениям таблицы лидеров имена игроков и места.
+func leaderboardRanked(standings string) string {
+	return "SELECT standings.user_id, users.name, standings.value, standings.games, RANK() OVER (ORDER BY standings.value DESC) AS place FROM (" + standings + ") standings JOIN users ON users.id = standings.user_id"
+}
+
+// scanLeaderboardEntry читает строку таблицы лидеров; значение записывается
+// в рейтинг или число побед в зависимости от сортировки.
+func scanLeaderboardEntry(row interface{ Scan(...any) error }, sort string) (*common.LeaderboardEntry, error) {
+	var entry common.LeaderboardEntry
+	var value float64
+	if err := row.Scan(&entry.UserID, &entry.Name, &value, &entry.Games, &entry.Place); err != nil {
+		return nil, err
+	}
+	if sort == "rating" {
+		entry.Rating = &value
+	} else {
+		wins := uint(value)
+		entry.Wins = &wins
+	}
+	return &entry, nil
+}
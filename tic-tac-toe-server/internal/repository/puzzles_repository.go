@@ -0,0 +1,183 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// PuzzleRepo реализует PuzzleRepository для работы с PostgreSQL
+type PuzzleRepo struct {
+	db *sql.DB
+}
+
+// PuzzleRepository определяет контракт для работы с хранилищем задач
+type PuzzleRepository interface {
+	// Create сохраняет задачу, если задачи с той же позицией ещё нет
+	Create(ctx context.Context, puzzle *common.Puzzle) error
+
+	// FindById находит задачу по идентификатору
+	FindById(ctx context.Context, id uint64) (*common.Puzzle, error)
+
+	// FindNextForUser находит нерешавшуюся пользователем задачу с ближайшей к рейтингу сложностью,
+	// кроме задачи дня
+	FindNextForUser(ctx context.Context, userID uuid.UUID, rating int, day string) (*common.Puzzle, error)
+
+	// RecordAttempt сохраняет первую попытку пользователя и его новый рейтинг решения задач
+	RecordAttempt(ctx context.Context, userID uuid.UUID, puzzleID uint64, solved bool, ratingBefore, ratingAfter int) (bool, error)
+}
+
+// NewPuzzleRepository создает новый экземпляр PuzzleRepository
+func NewPuzzleRepository(db *sql.DB) PuzzleRepository {
+	return &PuzzleRepo{
+		db: db,
+	}
+}
+
+// puzzleColumns перечисляет колонки задачи в порядке сканирования scanPuzzle.
+const puzzleColumns = "id, game_id, key, variant, border_size, win_length, positions, turn, win_in, solutions, rating, created_at"
+
+// scanPuzzle читает задачу из строки результата запроса.
+func scanPuzzle(row *sql.Row) (*common.Puzzle, error) {
+	var puzzle common.Puzzle
+	var positions, solutions []byte
+	err := row.Scan(
+		&puzzle.ID,
+		&puzzle.GameID,
+		&puzzle.Key,
+		&puzzle.Variant,
+		&puzzle.BorderSize,
+		&puzzle.WinLength,
+		&positions,
+		&puzzle.Turn,
+		&puzzle.WinIn,
+		&solutions,
+		&puzzle.Rating,
+		&puzzle.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(positions, &puzzle.Positions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(solutions, &puzzle.Solutions); err != nil {
+		return nil, err
+	}
+	return &puzzle, nil
+}
+
+// Create сохраняет задачу
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - puzzle: задача с позицией и решениями
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Фишки и решения хранятся в колонках positions и solutions как JSONB
+//   - Задача с уже сохранённым ключом позиции пропускается без ошибки
+func (repo *PuzzleRepo) Create(ctx context.Context, puzzle *common.Puzzle) error {
+	positions, err := json.Marshal(puzzle.Positions)
+	if err != nil {
+		return err
+	}
+	solutions, err := json.Marshal(puzzle.Solutions)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO puzzles (game_id, key, variant, border_size, win_length, positions, turn, win_in, solutions, rating) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (key) DO NOTHING"
+	_, err = repo.db.ExecContext(
+		ctx,
+		query,
+		puzzle.GameID,
+		puzzle.Key,
+		puzzle.Variant,
+		puzzle.BorderSize,
+		puzzle.WinLength,
+		positions,
+		puzzle.Turn,
+		puzzle.WinIn,
+		solutions,
+		puzzle.Rating,
+	)
+	return err
+}
+
+// FindById находит задачу по идентификатору
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор задачи
+//
+// Возвращает:
+//   - *common.Puzzle: найденная задача
+//   - error: ошибка, если задача не найдена или произошла ошибка запроса
+func (repo *PuzzleRepo) FindById(ctx context.Context, id uint64) (*common.Puzzle, error) {
+	query := "SELECT " + puzzleColumns + " FROM puzzles WHERE id = $1"
+	return scanPuzzle(repo.db.QueryRowContext(ctx, query, id))
+}
+
+// FindNextForUser подбирает задачу пользователю
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//   - rating: рейтинг решения задач пользователя
+//   - day: текущий день в формате "2006-01-02", задача этого дня не предлагается
+//
+// Возвращает:
+//   - *common.Puzzle: задача, которую пользователь ещё не решал, со сложностью ближе всего к рейтингу
+//   - error: sql.ErrNoRows, если подходящих задач нет, или ошибка запроса
+func (repo *PuzzleRepo) FindNextForUser(ctx context.Context, userID uuid.UUID, rating int, day string) (*common.Puzzle, error) {
+	query := "SELECT " + puzzleColumns + " FROM puzzles WHERE NOT EXISTS (SELECT 1 FROM puzzle_attempts WHERE puzzle_attempts.puzzle_id = puzzles.id AND puzzle_attempts.user_id = $1) AND NOT EXISTS (SELECT 1 FROM daily_challenges WHERE daily_challenges.puzzle_id = puzzles.id AND daily_challenges.day = $3) ORDER BY ABS(rating - $2), id LIMIT 1"
+	return scanPuzzle(repo.db.QueryRowContext(ctx, query, userID, rating, day))
+}
+
+// RecordAttempt сохраняет попытку решения задачи
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//   - puzzleID: идентификатор задачи
+//   - solved: задача решена
+//   - ratingBefore, ratingAfter: рейтинг решения задач до и после попытки
+//
+// Возвращает:
+//   - bool: попытка первая и рейтинг пользователя обновлён
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Попытка и рейтинг сохраняются в одной транзакции
+//   - Повторные попытки той же задачи не сохраняются и не меняют рейтинг
+func (repo *PuzzleRepo) RecordAttempt(ctx context.Context, userID uuid.UUID, puzzleID uint64, solved bool, ratingBefore, ratingAfter int) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	query := "INSERT INTO puzzle_attempts (user_id, puzzle_id, solved, rating_before, rating_after) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id, puzzle_id) DO NOTHING"
+	result, err := tx.ExecContext(ctx, query, userID, puzzleID, solved, ratingBefore, ratingAfter)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	query = "UPDATE users SET puzzle_rating = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2"
+	if _, err := tx.ExecContext(ctx, query, ratingAfter, userID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
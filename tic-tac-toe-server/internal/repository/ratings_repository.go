@@ -0,0 +1,102 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// RatingRepo реализует RatingRepository для работы с PostgreSQL
+type RatingRepo struct {
+	db *sql.DB
+}
+
+// RatingRepository определяет контракт для работы с хранилищем рейтингов
+// (рейтинги сохраняются вместе с результатами партий, см. ScoreRepository.CreateRated)
+type RatingRepository interface {
+	// FindByUsers возвращает рейтинги пользователей в пуле
+	FindByUsers(ctx context.Context, userIDs []uuid.UUID, variant, boardSize string) ([]*common.Rating, error)
+
+	// FindByUser возвращает рейтинги пользователя во всех пулах
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]*common.Rating, error)
+}
+
+// NewRatingRepository создает новый экземпляр RatingRepository
+func NewRatingRepository(db *sql.DB) RatingRepository {
+	return &RatingRepo{
+		db: db,
+	}
+}
+
+// ratingColumns перечисляет столбцы рейтинга в порядке scanRatings.
+const ratingColumns = "user_id, variant, board_size, rating, rd, volatility, games, updated_at"
+
+// FindByUsers возвращает рейтинги пользователей в пуле
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userIDs: идентификаторы пользователей
+//   - variant, boardSize: пул рейтинга (пустые — общий рейтинг)
+//
+// Возвращает:
+//   - []*common.Rating: найденные рейтинги (пользователей без рейтинговых партий в пуле нет в списке)
+//   - error: ошибка запроса
+func (repo *RatingRepo) FindByUsers(ctx context.Context, userIDs []uuid.UUID, variant, boardSize string) ([]*common.Rating, error) {
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+	query := "SELECT " + ratingColumns + " FROM ratings WHERE user_id = ANY($1::uuid[]) AND variant = $2 AND board_size = $3"
+	rows, err := repo.db.QueryContext(ctx, query, pq.Array(ids), variant, boardSize)
+	if err != nil {
+		return nil, err
+	}
+	return scanRatings(rows)
+}
+
+// FindByUser возвращает рейтинги пользователя во всех пулах
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//
+// Возвращает:
+//   - []*common.Rating: рейтинги, начиная с общего, затем по вариантам и размерам поля
+//   - error: ошибка запроса
+func (repo *RatingRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]*common.Rating, error) {
+	query := "SELECT " + ratingColumns + " FROM ratings WHERE user_id = $1 ORDER BY variant, board_size"
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRatings(rows)
+}
+
+// scanRatings читает рейтинги из результата запроса и закрывает его.
+func scanRatings(rows *sql.Rows) ([]*common.Rating, error) {
+	defer rows.Close()
+	ratings := make([]*common.Rating, 0)
+	for rows.Next() {
+		var rating common.Rating
+		err := rows.Scan(
+			&rating.UserID,
+			&rating.Variant,
+			&rating.BoardSize,
+			&rating.Rating,
+			&rating.RD,
+			&rating.Volatility,
+			&rating.Games,
+			&rating.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, &rating)
+	}
+	return ratings, rows.Err()
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/lib/pq"
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 )
 
@@ -22,8 +23,8 @@ type RoomRepository interface {
 	// FindById находит комнату по идентификатору
 	FindById(ctx context.Context, id uint64) (*common.Room, error)
 
-	// Create создает новую комнату в базе данных
-	Create(ctx context.Context, room common.Room) error
+	// Create создает новую комнату в базе данных и возвращает её идентификатор
+	Create(ctx context.Context, room common.Room) (uint64, error)
 
 	// DeleteById помечает комнату как удаленную (soft delete)
 	DeleteById(ctx context.Context, id uint64) error
@@ -50,7 +51,8 @@ func NewRoomRepository(db *sql.DB) RoomRepository {
 //   - Если комнат нет, возвращает пустой слайс (не nil)
 func (repo *RoomRepo) FindAll(ctx context.Context) ([]*common.Room, error) {
 	var rooms []*common.Room
-	rows, err := repo.db.QueryContext(ctx, "SELECT * FROM rooms WHERE deleted_at IS NULL")
+	query := "SELECT id, name, is_private, password, creator_id, capacity, first_move_policy, variant, variant_options, gravity, win_length, piece_limit, width, height, blocked_cells, blocked_seed, start_position, is_rated, bot_fill, bot_after, created_at, updated_at, deleted_at FROM rooms WHERE deleted_at IS NULL"
+	rows, err := repo.db.QueryContext(ctx, query)
 	defer func() {
 		rows.Close()
 	}()
@@ -66,6 +68,20 @@ func (repo *RoomRepo) FindAll(ctx context.Context) ([]*common.Room, error) {
 			&room.Password,
 			&room.CreatorID,
 			&room.Capacity,
+			&room.FirstMovePolicy,
+			&room.Variant,
+			(*[]byte)(&room.VariantOptions),
+			&room.Gravity,
+			&room.WinLength,
+			&room.PieceLimit,
+			&room.Width,
+			&room.Height,
+			pq.Array(&room.BlockedCells),
+			&room.BlockedSeed,
+			&room.StartPosition,
+			&room.IsRated,
+			&room.BotFill,
+			&room.BotAfter,
 			&room.CreatedAt,
 			&room.UpdatedAt,
 			&room.DeletedAt,
@@ -96,7 +112,7 @@ func (repo *RoomRepo) FindAll(ctx context.Context) ([]*common.Room, error) {
 //   - Не выбирает поля updated_at и deleted_at
 func (repo *RoomRepo) FindById(ctx context.Context, id uint64) (*common.Room, error) {
 	var room common.Room
-	query := "SELECT id, name, is_private, password, creator_id, capacity, created_at FROM rooms WHERE id = $1 AND deleted_at IS NULL"
+	query := "SELECT id, name, is_private, password, creator_id, capacity, first_move_policy, variant, variant_options, gravity, win_length, piece_limit, width, height, blocked_cells, blocked_seed, start_position, is_rated, bot_fill, bot_after, created_at FROM rooms WHERE id = $1 AND deleted_at IS NULL"
 	row := repo.db.QueryRowContext(ctx, query, id)
 	err := row.Scan(
 		&room.ID,
@@ -105,6 +121,20 @@ func (repo *RoomRepo) FindById(ctx context.Context, id uint64) (*common.Room, er
 		&room.Password,
 		&room.CreatorID,
 		&room.Capacity,
+		&room.FirstMovePolicy,
+		&room.Variant,
+		(*[]byte)(&room.VariantOptions),
+		&room.Gravity,
+		&room.WinLength,
+		&room.PieceLimit,
+		&room.Width,
+		&room.Height,
+		pq.Array(&room.BlockedCells),
+		&room.BlockedSeed,
+		&room.StartPosition,
+		&room.IsRated,
+		&room.BotFill,
+		&room.BotAfter,
 		&room.CreatedAt,
 	)
 	if err != nil {
@@ -120,33 +150,48 @@ func (repo *RoomRepo) FindById(ctx context.Context, id uint64) (*common.Room, er
 //   - room: данные комнаты для создания
 //
 // Возвращает:
+//   - uint64: идентификатор созданной комнаты
 //   - error: ошибка, если не удалось создать комнату
 //
 // Особенности:
-//   - Обязательные поля: name, is_private, creator_id
+//   - Обязательные поля: name, is_private, creator_id, capacity, first_move_policy, variant, variant_options,
+//     gravity, win_length, piece_limit,
+//     width, height, blocked_cells
+//   - Поле blocked_seed заполняется только для случайной раскладки заблокированных клеток
+//   - Поле start_position пустое для комнат, партии в которых начинаются с пустого поля
+//   - Поле is_rated отмечает рейтинговые комнаты
+//   - Поля bot_fill и bot_after задают подсадку бота к одинокому игроку
 //   - Поле password может быть пустым для публичных комнат
-//   - Проверяет количество затронутых строк (rowsAffected)
-func (repo *RoomRepo) Create(ctx context.Context, room common.Room) error {
-	query := "INSERT INTO rooms (name, is_private, creator_id, password) VALUES ($1, $2, $3, $4)"
-	result, err := repo.db.ExecContext(
+func (repo *RoomRepo) Create(ctx context.Context, room common.Room) (uint64, error) {
+	var id uint64
+	query := "INSERT INTO rooms (name, is_private, creator_id, password, capacity, first_move_policy, variant, variant_options, gravity, win_length, piece_limit, width, height, blocked_cells, blocked_seed, start_position, is_rated, bot_fill, bot_after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) RETURNING id"
+	err := repo.db.QueryRowContext(
 		ctx,
 		query,
 		room.Name,
 		room.IsPrivate,
 		room.CreatorID,
 		room.Password,
-	)
+		room.Capacity,
+		room.FirstMovePolicy,
+		room.Variant,
+		[]byte(room.VariantOptions),
+		room.Gravity,
+		room.WinLength,
+		room.PieceLimit,
+		room.Width,
+		room.Height,
+		pq.Array(room.BlockedCells),
+		room.BlockedSeed,
+		room.StartPosition,
+		room.IsRated,
+		room.BotFill,
+		room.BotAfter,
+	).Scan(&id)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return errors.New("room was not created")
-	}
-	return nil
+	return id, nil
 }
 
 // DeleteById выполняет мягкое удаление комнаты (soft delete)
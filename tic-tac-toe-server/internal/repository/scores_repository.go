@@ -6,6 +6,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 )
@@ -25,10 +29,32 @@ type ScoreRepository interface {
 	// FindAllByUser возвращает последние 50 результатов игр для указанного пользователя
 	FindAllByUser(ctx context.Context, user *common.User) ([]*common.Score, error)
 
+	// CreateRated сохраняет результаты рейтинговой партии вместе с изменениями рейтинга
+	CreateRated(ctx context.Context, scores []*common.Score, userIDs []uuid.UUID, pools [][2]string, rate RatingUpdate) error
+
+	// FindResultsByUser возвращает все результаты игр пользователя в порядке их сохранения
+	FindResultsByUser(ctx context.Context, userID uuid.UUID) ([]*common.Score, error)
+
+	// GetVersusRecord возвращает счёт пользователя в партиях с соперником
+	GetVersusRecord(ctx context.Context, userID, opponentID uuid.UUID) (*common.ResultStats, error)
+
+	// GetVersusRatingChange возвращает изменение общего рейтинга пользователя в партиях с соперником
+	GetVersusRatingChange(ctx context.Context, userID, opponentID uuid.UUID) (uint, float64, error)
+
+	// FindVersusGames возвращает последние партии пользователя с соперником
+	FindVersusGames(ctx context.Context, userID, opponentID uuid.UUID, limit int) ([]*common.VersusGame, error)
+
 	// GetWonScore возвращает количество побед указанного пользователя
 	GetWonScore(ctx context.Context, user *common.User) (uint, error)
+
+	// GetWonScoreByVariant возвращает количество побед пользователя в разрезе вариантов правил
+	GetWonScoreByVariant(ctx context.Context, user *common.User) (map[string]uint, error)
 }
 
+// RatingUpdate вычисляет новые рейтинги и записи истории рейтинга в пуле
+// по текущим рейтингам игроков, прочитанным внутри транзакции CreateRated.
+type RatingUpdate func(variant, boardSize string, current []*common.Rating) ([]*common.Rating, []*common.RatingChange, error)
+
 // NewScoreRepository создает новый экземпляр ScoreRepository
 func NewScoreRepository(db *sql.DB) ScoreRepository {
 	return &ScoreRepo{
@@ -46,12 +72,34 @@ func NewScoreRepository(db *sql.DB) ScoreRepository {
 //   - error: ошибка, если не удалось создать запись
 //
 // Особенности:
-//   - Сохраняет nickname, user_id и флаг победы (is_won)
+//   - Сохраняет nickname, user_id, идентификаторы соперников (opponent_ids), флаг победы (is_won),
+//     результат (result), причину завершения (termination), комнату и партию (room_id, game_id),
+//     вариант правил (variant), размер поля (board_size), место игрока (placement),
+//     число игроков (players), символ игрока (symbol), число ходов (moves)
+//     и флаг рейтинговой партии (is_rated)
 //   - Проверяет количество затронутых строк (rowsAffected)
 //   - Возвращает ошибку "room was not created" если не была создана запись
 func (repo ScoreRepo) Create(ctx context.Context, score *common.Score) error {
-	query := "INSERT INTO scores (name, user_id, is_won) VALUES ($1, $2, $3)"
-	result, err := repo.db.ExecContext(ctx, query, score.Nickname, score.UserID, score.IsWon)
+	query := "INSERT INTO scores (name, user_id, opponent_ids, is_won, result, termination, room_id, game_id, variant, board_size, placement, players, symbol, moves, is_rated) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)"
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		score.Nickname,
+		score.UserID,
+		pq.Array(score.OpponentIDs),
+		score.IsWon,
+		score.Result,
+		score.Termination,
+		score.RoomID,
+		score.GameID,
+		score.Variant,
+		score.BoardSize,
+		score.Placement,
+		score.Players,
+		score.Symbol,
+		score.Moves,
+		score.IsRated,
+	)
 	if err != nil {
 		return err
 	}
@@ -65,6 +113,119 @@ func (repo ScoreRepo) Create(ctx context.Context, score *common.Score) error {
 	return nil
 }
 
+// CreateRated сохраняет результаты рейтинговой партии
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - scores: результаты игроков партии
+//   - userIDs: все игроки партии
+//   - pools: пулы рейтинга партии (вариант и размер поля)
+//   - rate: расчёт новых рейтингов и записей истории рейтинга
+//     (ScoreID заполняется по результату того же игрока)
+//
+// Возвращает:
+//   - error: ошибка запроса или расчёта рейтинга
+//
+// Особенности:
+//   - Результаты, рейтинги и история рейтинга сохраняются в одной транзакции:
+//     при ошибке не сохраняется ничего
+//   - Игроки блокируются транзакционными advisory-блокировками в порядке ID, а их рейтинги
+//     читаются с FOR UPDATE, поэтому одновременные партии одного игрока пересчитываются
+//     по очереди (блокировка нужна и для игроков, у которых строки рейтинга ещё нет)
+//   - Идентификаторы сохранённых результатов записываются в score.ID
+func (repo ScoreRepo) CreateRated(ctx context.Context, scores []*common.Score, userIDs []uuid.UUID, pools [][2]string, rate RatingUpdate) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", id); err != nil {
+			return err
+		}
+	}
+	ratings := make([]*common.Rating, 0, len(scores)*len(pools))
+	changes := make([]*common.RatingChange, 0, len(scores)*len(pools))
+	query := "SELECT " + ratingColumns + " FROM ratings WHERE user_id = ANY($1::uuid[]) AND variant = $2 AND board_size = $3 ORDER BY user_id FOR UPDATE"
+	for _, pool := range pools {
+		rows, err := tx.QueryContext(ctx, query, pq.Array(ids), pool[0], pool[1])
+		if err != nil {
+			return err
+		}
+		current, err := scanRatings(rows)
+		if err != nil {
+			return err
+		}
+		poolRatings, poolChanges, err := rate(pool[0], pool[1], current)
+		if err != nil {
+			return err
+		}
+		ratings = append(ratings, poolRatings...)
+		changes = append(changes, poolChanges...)
+	}
+	scoreIDs := make(map[string]uint64, len(scores))
+	query = "INSERT INTO scores (name, user_id, opponent_ids, is_won, result, termination, room_id, game_id, variant, board_size, placement, players, symbol, moves, is_rated) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id"
+	for _, score := range scores {
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			score.Nickname,
+			score.UserID,
+			pq.Array(score.OpponentIDs),
+			score.IsWon,
+			score.Result,
+			score.Termination,
+			score.RoomID,
+			score.GameID,
+			score.Variant,
+			score.BoardSize,
+			score.Placement,
+			score.Players,
+			score.Symbol,
+			score.Moves,
+			score.IsRated,
+		).Scan(&score.ID)
+		if err != nil {
+			return err
+		}
+		scoreIDs[score.UserID] = score.ID
+	}
+	query = "INSERT INTO ratings (user_id, variant, board_size, rating, rd, volatility, games, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP) ON CONFLICT (user_id, variant, board_size) DO UPDATE SET rating = EXCLUDED.rating, rd = EXCLUDED.rd, volatility = EXCLUDED.volatility, games = EXCLUDED.games, updated_at = EXCLUDED.updated_at"
+	for _, rating := range ratings {
+		_, err := tx.ExecContext(ctx, query, rating.UserID, rating.Variant, rating.BoardSize, rating.Rating, rating.RD, rating.Volatility, rating.Games)
+		if err != nil {
+			return err
+		}
+	}
+	query = "INSERT INTO rating_history (user_id, score_id, variant, board_size, rating_before, rating_after, rd_before, rd_after, volatility_before, volatility_after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)"
+	for _, change := range changes {
+		change.ScoreID = scoreIDs[change.UserID.String()]
+		_, err := tx.ExecContext(
+			ctx,
+			query,
+			change.UserID,
+			change.ScoreID,
+			change.Variant,
+			change.BoardSize,
+			change.RatingBefore,
+			change.RatingAfter,
+			change.RDBefore,
+			change.RDAfter,
+			change.VolatilityBefore,
+			change.VolatilityAfter,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // FindAllByUser возвращает историю результатов для пользователя
 //
 // Параметры:
@@ -83,7 +244,7 @@ func (repo ScoreRepo) Create(ctx context.Context, score *common.Score) error {
 func (repo ScoreRepo) FindAllByUser(ctx context.Context, user *common.User) ([]*common.Score, error) {
 	var scores []*common.Score
 	query := fmt.Sprintf(
-		"SELECT id, name, user_id, is_won, created_at FROM %v WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC LIMIT 50",
+		"SELECT id, name, user_id, opponent_ids, is_won, result, termination, room_id, game_id, variant, board_size, placement, players, symbol, moves, is_rated, created_at FROM %v WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC LIMIT 50",
 		TABLE_NAME,
 	)
 	rows, err := repo.db.QueryContext(ctx, query, user.ID)
@@ -99,7 +260,19 @@ func (repo ScoreRepo) FindAllByUser(ctx context.Context, user *common.User) ([]*
 			&score.ID,
 			&score.Nickname,
 			&score.UserID,
+			pq.Array(&score.OpponentIDs),
 			&score.IsWon,
+			&score.Result,
+			&score.Termination,
+			&score.RoomID,
+			&score.GameID,
+			&score.Variant,
+			&score.BoardSize,
+			&score.Placement,
+			&score.Players,
+			&score.Symbol,
+			&score.Moves,
+			&score.IsRated,
 			&score.CreatedAt,
 		)
 		if err != nil {
@@ -113,6 +286,169 @@ func (repo ScoreRepo) FindAllByUser(ctx context.Context, user *common.User) ([]*
 	return scores, nil
 }
 
+// FindResultsByUser возвращает все результаты игр пользователя для статистики
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//
+// Возвращает:
+//   - []*common.Score: результаты от старых к новым (без соперников)
+//   - error: ошибка выполнения запроса
+//
+// Особенности:
+//   - Не учитывает удаленные записи (deleted_at IS NULL)
+//   - Использует индекс scores_user_created_at_index
+func (repo ScoreRepo) FindResultsByUser(ctx context.Context, userID uuid.UUID) ([]*common.Score, error) {
+	query := fmt.Sprintf(
+		"SELECT id, user_id, opponent_ids, is_won, result, termination, room_id, game_id, variant, board_size, placement, players, symbol, moves, is_rated, created_at FROM %v WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at, id",
+		TABLE_NAME,
+	)
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	scores := make([]*common.Score, 0)
+	for rows.Next() {
+		var score common.Score
+		err := rows.Scan(
+			&score.ID,
+			&score.UserID,
+			pq.Array(&score.OpponentIDs),
+			&score.IsWon,
+			&score.Result,
+			&score.Termination,
+			&score.RoomID,
+			&score.GameID,
+			&score.Variant,
+			&score.BoardSize,
+			&score.Placement,
+			&score.Players,
+			&score.Symbol,
+			&score.Moves,
+			&score.IsRated,
+			&score.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, &score)
+	}
+	return scores, rows.Err()
+}
+
+// GetVersusRecord возвращает счёт пользователя в партиях с соперником
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//   - opponentID: идентификатор соперника
+//
+// Возвращает:
+//   - *common.ResultStats: победы, поражения и ничьи пользователя в партиях, где играл соперник
+//   - error: ошибка выполнения запроса
+//
+// Особенности:
+//   - Соперник ищется по идентификаторам соперников (индекс scores_opponent_ids_index),
+//     поэтому смена имени не влияет на счёт
+//   - В партиях на несколько игроков учитывается результат пользователя в партии
+//   - Сдача (forfeit) и поражение по времени (timeout) считаются поражениями,
+//     брошенные партии (abandoned) не учитываются
+func (repo ScoreRepo) GetVersusRecord(ctx context.Context, userID, opponentID uuid.UUID) (*common.ResultStats, error) {
+	var record common.ResultStats
+	query := fmt.Sprintf(
+		"SELECT COUNT(*), COUNT(*) FILTER (WHERE result = 'win'), COUNT(*) FILTER (WHERE result IN ('loss', 'forfeit', 'timeout')), COUNT(*) FILTER (WHERE result = 'draw') FROM %v WHERE user_id = $1 AND opponent_ids @> ARRAY[$2::uuid] AND result <> 'abandoned' AND deleted_at IS NULL",
+		TABLE_NAME,
+	)
+	err := repo.db.QueryRowContext(ctx, query, userID, opponentID).Scan(
+		&record.Games,
+		&record.Wins,
+		&record.Losses,
+		&record.Draws,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if record.Games > 0 {
+		record.WinRate = float64(record.Wins) / float64(record.Games)
+	}
+	return &record, nil
+}
+
+// GetVersusRatingChange возвращает изменение общего рейтинга пользователя в партиях с соперником
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//   - opponentID: идентификатор соперника
+//
+// Возвращает:
+//   - uint: число рейтинговых партий с соперником
+//   - float64: сумма изменений общего рейтинга пользователя в этих партиях
+//   - error: ошибка выполнения запроса
+func (repo ScoreRepo) GetVersusRatingChange(ctx context.Context, userID, opponentID uuid.UUID) (uint, float64, error) {
+	var games uint
+	var change float64
+	query := fmt.Sprintf(
+		"SELECT COUNT(*), COALESCE(SUM(rating_history.rating_after - rating_history.rating_before), 0) FROM %v JOIN rating_history ON rating_history.score_id = scores.id AND rating_history.variant = '' AND rating_history.board_size = '' WHERE scores.user_id = $1 AND scores.opponent_ids @> ARRAY[$2::uuid] AND scores.deleted_at IS NULL",
+		TABLE_NAME,
+	)
+	err := repo.db.QueryRowContext(ctx, query, userID, opponentID).Scan(&games, &change)
+	if err != nil {
+		return 0, 0, err
+	}
+	return games, change, nil
+}
+
+// FindVersusGames возвращает последние партии пользователя с соперником
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор пользователя
+//   - opponentID: идентификатор соперника
+//   - limit: наибольшее число партий
+//
+// Возвращает:
+//   - []*common.VersusGame: партии от новых к старым с общим рейтингом пользователя
+//     до и после рейтинговых партий
+//   - error: ошибка выполнения запроса
+func (repo ScoreRepo) FindVersusGames(ctx context.Context, userID, opponentID uuid.UUID, limit int) ([]*common.VersusGame, error) {
+	query := fmt.Sprintf(
+		"SELECT scores.is_won, scores.result, scores.termination, scores.game_id, scores.variant, scores.board_size, scores.symbol, scores.moves, scores.players, scores.is_rated, rating_history.rating_before, rating_history.rating_after, scores.created_at FROM %v LEFT JOIN rating_history ON rating_history.score_id = scores.id AND rating_history.variant = '' AND rating_history.board_size = '' WHERE scores.user_id = $1 AND scores.opponent_ids @> ARRAY[$2::uuid] AND scores.deleted_at IS NULL ORDER BY scores.created_at DESC, scores.id DESC LIMIT $3",
+		TABLE_NAME,
+	)
+	rows, err := repo.db.QueryContext(ctx, query, userID, opponentID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	games := make([]*common.VersusGame, 0)
+	for rows.Next() {
+		var game common.VersusGame
+		err := rows.Scan(
+			&game.IsWon,
+			&game.Result,
+			&game.Termination,
+			&game.GameID,
+			&game.Variant,
+			&game.BoardSize,
+			&game.Symbol,
+			&game.Moves,
+			&game.Players,
+			&game.IsRated,
+			&game.RatingBefore,
+			&game.RatingAfter,
+			&game.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, &game)
+	}
+	return games, rows.Err()
+}
+
 // GetWonScore возвращает количество побед пользователя
 //
 // Параметры:
@@ -124,13 +460,13 @@ func (repo ScoreRepo) FindAllByUser(ctx context.Context, user *common.User) ([]*
 //   - error: ошибка выполнения запроса
 //
 // Особенности:
-//   - Считает только записи с is_won = true
+//   - Считает только записи с результатом win
 //   - Не учитывает удаленные записи (deleted_at IS NULL)
 //   - Возвращает 0 в случае ошибки
 func (repo ScoreRepo) GetWonScore(ctx context.Context, user *common.User) (uint, error) {
 	var currentScore *uint
 	query := fmt.Sprintf(
-		"SELECT COUNT(*) as current_score FROM %v WHERE user_id = $1 AND is_won = 1",
+		"SELECT COUNT(*) as current_score FROM %v WHERE user_id = $1 AND result = 'win'",
 		TABLE_NAME,
 	)
 	row := repo.db.QueryRowContext(ctx, query, user.ID)
@@ -142,3 +478,39 @@ func (repo ScoreRepo) GetWonScore(ctx context.Context, user *common.User) (uint,
 	}
 	return *currentScore, nil
 }
+
+// GetWonScoreByVariant возвращает количество побед пользователя по каждому варианту правил
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - user: указатель на структуру пользователя
+//
+// Возвращает:
+//   - map[string]uint: количество побед, где ключ — название варианта
+//   - error: ошибка выполнения запроса
+//
+// Особенности:
+//   - Считает только записи с результатом win
+//   - Не учитывает удаленные записи (deleted_at IS NULL)
+//   - Варианты без побед в результат не попадают
+func (repo ScoreRepo) GetWonScoreByVariant(ctx context.Context, user *common.User) (map[string]uint, error) {
+	query := fmt.Sprintf(
+		"SELECT variant, COUNT(*) FROM %v WHERE user_id = $1 AND result = 'win' AND deleted_at IS NULL GROUP BY variant",
+		TABLE_NAME,
+	)
+	rows, err := repo.db.QueryContext(ctx, query, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	wonScores := make(map[string]uint)
+	for rows.Next() {
+		var variant string
+		var wonScore uint
+		if err := rows.Scan(&variant, &wonScore); err != nil {
+			return nil, err
+		}
+		wonScores[variant] = wonScore
+	}
+	return wonScores, rows.Err()
+}
@@ -0,0 +1,600 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// TournamentRepo реализует TournamentRepository для работы с PostgreSQL
+type TournamentRepo struct {
+	db *sql.DB
+}
+
+// TournamentRepository определяет контракт для работы с хранилищем турниров
+type TournamentRepository interface {
+	// Create сохраняет турнир и возвращает его идентификатор
+	Create(ctx context.Context, tournament *common.Tournament) (uint64, error)
+
+	// FindAll возвращает последние турниры, начиная с новых
+	FindAll(ctx context.Context, limit int) ([]*common.Tournament, error)
+
+	// FindDue возвращает турниры, которым по расписанию пора начаться или начать следующий круг
+	FindDue(ctx context.Context, now time.Time) ([]*common.Tournament, error)
+
+	// FindById находит турнир по идентификатору
+	FindById(ctx context.Context, id uint64) (*common.Tournament, error)
+
+	// Update сохраняет состояние турнира
+	Update(ctx context.Context, tournament *common.Tournament) error
+
+	// Start сохраняет старт турнира: состояние, посев участников и матчи сетки
+	Start(ctx context.Context, tournament *common.Tournament, participants []*common.TournamentParticipant, matches []*common.TournamentMatch) error
+
+	// AddParticipant регистрирует участника турнира
+	AddParticipant(ctx context.Context, participant *common.TournamentParticipant) error
+
+	// RemoveParticipant снимает участника с турнира
+	RemoveParticipant(ctx context.Context, tournamentID uint64, userID uuid.UUID) error
+
+	// FindParticipants возвращает участников турнира
+	FindParticipants(ctx context.Context, tournamentID uint64) ([]*common.TournamentParticipant, error)
+
+	// FinishEliminationMatch сохраняет итог матча на выбывание: выбывшего, изменённые матчи и турнир
+	FinishEliminationMatch(ctx context.Context, tournament *common.Tournament, loserID uuid.UUID, round uint8, matches []*common.TournamentMatch) error
+
+	// StartRound сохраняет начало круга швейцарского или кругового турнира: новые и открытые матчи и турнир
+	StartRound(ctx context.Context, tournament *common.Tournament, added, opened []*common.TournamentMatch) error
+
+	// FinishRoundMatch сохраняет итог матча швейцарского или кругового турнира и турнир
+	FinishRoundMatch(ctx context.Context, tournament *common.Tournament, match *common.TournamentMatch) error
+
+	// FindMatches возвращает матчи турнира
+	FindMatches(ctx context.Context, tournamentID uint64) ([]*common.TournamentMatch, error)
+
+	// FindMatchByRoom находит матч по его комнате
+	FindMatchByRoom(ctx context.Context, roomID uint64) (*common.TournamentMatch, error)
+
+	// FindMatchById находит матч турнира по идентификатору
+	FindMatchById(ctx context.Context, tournamentID, id uint64) (*common.TournamentMatch, error)
+
+	// UpdateMatch сохраняет состояние матча
+	UpdateMatch(ctx context.Context, match *common.TournamentMatch) error
+}
+
+// NewTournamentRepository создает новый экземпляр TournamentRepository
+func NewTournamentRepository(db *sql.DB) TournamentRepository {
+	return &TournamentRepo{
+		db: db,
+	}
+}
+
+// Create сохраняет турнир
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: название, создатель, формат, настройки партий, число участников
+//     и расписание кругов
+//
+// Возвращает:
+//   - uint64: идентификатор созданного турнира
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Турнир создаётся в состоянии, переданном в tournament.Status (registration)
+func (repo *TournamentRepo) Create(ctx context.Context, tournament *common.Tournament) (uint64, error) {
+	var id uint64
+	query := "INSERT INTO tournaments (name, creator_id, format, status, variant, board_size, win_length, is_rated, max_participants, rounds, starts_at, round_interval) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id"
+	err := repo.db.QueryRowContext(
+		ctx,
+		query,
+		tournament.Name,
+		tournament.CreatorID,
+		tournament.Format,
+		tournament.Status,
+		tournament.Variant,
+		tournament.BoardSize,
+		tournament.WinLength,
+		tournament.IsRated,
+		tournament.MaxParticipants,
+		tournament.Rounds,
+		tournament.StartsAt,
+		tournament.RoundInterval,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindAll возвращает последние турниры
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - limit: наибольшее число турниров
+//
+// Возвращает:
+//   - []*common.Tournament: турниры, начиная с новых (пустой слайс, если турниров нет)
+//   - error: ошибка запроса
+func (repo *TournamentRepo) FindAll(ctx context.Context, limit int) ([]*common.Tournament, error) {
+	query := "SELECT id, name, creator_id, format, status, variant, board_size, win_length, is_rated, max_participants, rounds, current_round, starts_at, round_interval, round_started_at, winner_id, created_at, started_at, finished_at FROM tournaments ORDER BY id DESC LIMIT $1"
+	rows, err := repo.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tournaments := make([]*common.Tournament, 0)
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	return tournaments, rows.Err()
+}
+
+// FindById находит турнир по идентификатору
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор турнира
+//
+// Возвращает:
+//   - *common.Tournament: найденный турнир
+//   - error: sql.ErrNoRows, если турнир не найден, или ошибка запроса
+func (repo *TournamentRepo) FindById(ctx context.Context, id uint64) (*common.Tournament, error) {
+	query := "SELECT id, name, creator_id, format, status, variant, board_size, win_length, is_rated, max_participants, rounds, current_round, starts_at, round_interval, round_started_at, winner_id, created_at, started_at, finished_at FROM tournaments WHERE id = $1"
+	return scanTournament(repo.db.QueryRowContext(ctx, query, id))
+}
+
+// FindDue возвращает турниры, которым по расписанию пора начаться или начать следующий круг
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - now: текущее время
+//
+// Возвращает:
+//   - []*common.Tournament: турниры в регистрации, время старта которых наступило,
+//     и идущие турниры с интервалом кругов, у которых интервал текущего круга истёк
+//   - error: ошибка запроса
+func (repo *TournamentRepo) FindDue(ctx context.Context, now time.Time) ([]*common.Tournament, error) {
+	query := "SELECT id, name, creator_id, format, status, variant, board_size, win_length, is_rated, max_participants, rounds, current_round, starts_at, round_interval, round_started_at, winner_id, created_at, started_at, finished_at FROM tournaments WHERE (status = 'registration' AND starts_at <= $1) OR (status = 'running' AND round_interval > 0 AND round_started_at + round_interval * INTERVAL '1 minute' <= $1) ORDER BY id"
+	rows, err := repo.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tournaments := make([]*common.Tournament, 0)
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	return tournaments, rows.Err()
+}
+
+// Update сохраняет состояние турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: турнир с новым состоянием, числом кругов, текущим кругом и временем его начала,
+//     победителем и датами старта и окончания
+//
+// Возвращает:
+//   - error: ошибка запроса
+func (repo *TournamentRepo) Update(ctx context.Context, tournament *common.Tournament) error {
+	return updateTournament(ctx, repo.db, tournament)
+}
+
+// Start сохраняет старт турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: турнир с новым состоянием
+//   - participants: участники с номерами посева и рейтингами посева
+//   - matches: матчи сетки
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Всё сохраняется в одной транзакции: при ошибке турнир остаётся в регистрации
+//   - Идентификаторы сохранённых матчей записываются в match.ID
+func (repo *TournamentRepo) Start(ctx context.Context, tournament *common.Tournament, participants []*common.TournamentParticipant, matches []*common.TournamentMatch) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := updateTournament(ctx, tx, tournament); err != nil {
+		return err
+	}
+	query := "UPDATE tournament_participants SET seed = $1, rating = $2 WHERE tournament_id = $3 AND user_id = $4"
+	for _, participant := range participants {
+		_, err := tx.ExecContext(ctx, query, participant.Seed, participant.Rating, tournament.ID, participant.UserID)
+		if err != nil {
+			return err
+		}
+	}
+	if err := insertTournamentMatches(ctx, tx, tournament.ID, matches); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddParticipant регистрирует участника турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - participant: турнир, пользователь и его рейтинг при регистрации
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Повторная регистрация не меняет время регистрации
+func (repo *TournamentRepo) AddParticipant(ctx context.Context, participant *common.TournamentParticipant) error {
+	query := "INSERT INTO tournament_participants (tournament_id, user_id, rating) VALUES ($1, $2, $3) ON CONFLICT (tournament_id, user_id) DO NOTHING"
+	_, err := repo.db.ExecContext(ctx, query, participant.TournamentID, participant.UserID, participant.Rating)
+	return err
+}
+
+// RemoveParticipant снимает участника с турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournamentID: идентификатор турнира
+//   - userID: идентификатор участника
+//
+// Возвращает:
+//   - error: sql.ErrNoRows, если пользователь не зарегистрирован, или ошибка запроса
+func (repo *TournamentRepo) RemoveParticipant(ctx context.Context, tournamentID uint64, userID uuid.UUID) error {
+	result, err := repo.db.ExecContext(
+		ctx,
+		"DELETE FROM tournament_participants WHERE tournament_id = $1 AND user_id = $2",
+		tournamentID,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindParticipants возвращает участников турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournamentID: идентификатор турнира
+//
+// Возвращает:
+//   - []*common.TournamentParticipant: участники с именами по номеру посева,
+//     а при одинаковом номере (до старта) — по времени регистрации
+//   - error: ошибка запроса
+func (repo *TournamentRepo) FindParticipants(ctx context.Context, tournamentID uint64) ([]*common.TournamentParticipant, error) {
+	query := "SELECT tournament_participants.tournament_id, tournament_participants.user_id, users.name, tournament_participants.seed, tournament_participants.rating, tournament_participants.eliminated_round, tournament_participants.created_at FROM tournament_participants JOIN users ON users.id = tournament_participants.user_id WHERE tournament_participants.tournament_id = $1 ORDER BY tournament_participants.seed, tournament_participants.created_at"
+	rows, err := repo.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	participants := make([]*common.TournamentParticipant, 0)
+	for rows.Next() {
+		var participant common.TournamentParticipant
+		err := rows.Scan(
+			&participant.TournamentID,
+			&participant.UserID,
+			&participant.Name,
+			&participant.Seed,
+			&participant.Rating,
+			&participant.EliminatedRound,
+			&participant.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, &participant)
+	}
+	return participants, rows.Err()
+}
+
+// FinishEliminationMatch сохраняет итог матча турнира на выбывание
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: турнир с новым текущим кругом (и победителем, если решён финал)
+//   - loserID: участник, выбывший из турнира
+//   - round: круг, в котором участник проиграл
+//   - matches: решённый матч и матч следующего круга, в который прошёл победитель
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Всё сохраняется в одной транзакции: при ошибке сетка остаётся такой, какой была до матча
+func (repo *TournamentRepo) FinishEliminationMatch(ctx context.Context, tournament *common.Tournament, loserID uuid.UUID, round uint8, matches []*common.TournamentMatch) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := "UPDATE tournament_participants SET eliminated_round = $1 WHERE tournament_id = $2 AND user_id = $3"
+	if _, err := tx.ExecContext(ctx, query, round, tournament.ID, loserID); err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := updateTournamentMatch(ctx, tx, match); err != nil {
+			return err
+		}
+	}
+	if err := updateTournament(ctx, tx, tournament); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// StartRound сохраняет начало круга швейцарского или кругового турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: турнир с новым текущим кругом и временем его начала
+//   - added: новые матчи круга (в швейцарской системе пары составляются к началу круга)
+//   - opened: матчи круга, для которых созданы комнаты
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Всё сохраняется в одной транзакции: при ошибке круг остаётся неначатым
+//   - Идентификаторы новых матчей записываются в match.ID
+func (repo *TournamentRepo) StartRound(ctx context.Context, tournament *common.Tournament, added, opened []*common.TournamentMatch) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := insertTournamentMatches(ctx, tx, tournament.ID, added); err != nil {
+		return err
+	}
+	for _, match := range opened {
+		if err := updateTournamentMatch(ctx, tx, match); err != nil {
+			return err
+		}
+	}
+	if err := updateTournament(ctx, tx, tournament); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FinishRoundMatch сохраняет итог матча швейцарского или кругового турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournament: турнир (с победителем, если решён последний матч последнего круга)
+//   - match: решённый матч
+//
+// Возвращает:
+//   - error: ошибка запроса
+//
+// Особенности:
+//   - Матч и турнир сохраняются в одной транзакции
+func (repo *TournamentRepo) FinishRoundMatch(ctx context.Context, tournament *common.Tournament, match *common.TournamentMatch) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := updateTournamentMatch(ctx, tx, match); err != nil {
+		return err
+	}
+	if err := updateTournament(ctx, tx, tournament); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindMatches возвращает матчи турнира
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournamentID: идентификатор турнира
+//
+// Возвращает:
+//   - []*common.TournamentMatch: матчи по кругам и номерам в круге
+//   - error: ошибка запроса
+func (repo *TournamentRepo) FindMatches(ctx context.Context, tournamentID uint64) ([]*common.TournamentMatch, error) {
+	query := "SELECT id, tournament_id, round, position, first_id, second_id, winner_id, status, room_id, room_password, game_id, updated_at FROM tournament_matches WHERE tournament_id = $1 ORDER BY round, position"
+	rows, err := repo.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	matches := make([]*common.TournamentMatch, 0)
+	for rows.Next() {
+		match, err := scanTournamentMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// FindMatchByRoom находит матч по его комнате
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - roomID: идентификатор комнаты
+//
+// Возвращает:
+//   - *common.TournamentMatch: матч, для которого создана комната
+//   - error: sql.ErrNoRows, если комната не турнирная, или ошибка запроса
+func (repo *TournamentRepo) FindMatchByRoom(ctx context.Context, roomID uint64) (*common.TournamentMatch, error) {
+	query := "SELECT id, tournament_id, round, position, first_id, second_id, winner_id, status, room_id, room_password, game_id, updated_at FROM tournament_matches WHERE room_id = $1"
+	return scanTournamentMatch(repo.db.QueryRowContext(ctx, query, roomID))
+}
+
+// UpdateMatch сохраняет состояние матча
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - match: матч с участниками, победителем, состоянием, комнатой и партией
+//
+// Возвращает:
+//   - error: ошибка запроса
+func (repo *TournamentRepo) UpdateMatch(ctx context.Context, match *common.TournamentMatch) error {
+	return updateTournamentMatch(ctx, repo.db, match)
+}
+
+// FindMatchById находит матч турнира по идентификатору
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - tournamentID: идентификатор турнира
+//   - id: идентификатор матча
+//
+// Возвращает:
+//   - *common.TournamentMatch: найденный матч
+//   - error: sql.ErrNoRows, если матча нет в турнире, или ошибка запроса
+func (repo *TournamentRepo) FindMatchById(ctx context.Context, tournamentID, id uint64) (*common.TournamentMatch, error) {
+	query := "SELECT id, tournament_id, round, position, first_id, second_id, winner_id, status, room_id, room_password, game_id, updated_at FROM tournament_matches WHERE tournament_id = $1 AND id = $2"
+	return scanTournamentMatch(repo.db.QueryRowContext(ctx, query, tournamentID, id))
+}
+
+// updateTournamentMatch сохраняет состояние матча в базе или транзакции.
+func updateTournamentMatch(ctx context.Context, db execer, match *common.TournamentMatch) error {
+	query := "UPDATE tournament_matches SET first_id = $1, second_id = $2, winner_id = $3, status = $4, room_id = $5, room_password = $6, game_id = $7, updated_at = CURRENT_TIMESTAMP WHERE id = $8"
+	_, err := db.ExecContext(
+		ctx,
+		query,
+		match.FirstID,
+		match.SecondID,
+		match.WinnerID,
+		match.Status,
+		match.RoomID,
+		match.RoomPassword,
+		match.GameID,
+		match.ID,
+	)
+	return err
+}
+
+// rowScanner — общий интерфейс *sql.Row и *sql.Rows для чтения одной строки.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// execer — общий интерфейс *sql.DB и *sql.Tx для запросов без результата.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// updateTournament сохраняет состояние турнира в базе или транзакции.
+func updateTournament(ctx context.Context, db execer, tournament *common.Tournament) error {
+	query := "UPDATE tournaments SET status = $1, rounds = $2, current_round = $3, round_started_at = $4, winner_id = $5, started_at = $6, finished_at = $7 WHERE id = $8"
+	_, err := db.ExecContext(
+		ctx,
+		query,
+		tournament.Status,
+		tournament.Rounds,
+		tournament.CurrentRound,
+		tournament.RoundStartedAt,
+		tournament.WinnerID,
+		tournament.StartedAt,
+		tournament.FinishedAt,
+		tournament.ID,
+	)
+	return err
+}
+
+// insertTournamentMatches сохраняет матчи турнира в транзакции и записывает их идентификаторы.
+func insertTournamentMatches(ctx context.Context, tx *sql.Tx, tournamentID uint64, matches []*common.TournamentMatch) error {
+	query := "INSERT INTO tournament_matches (tournament_id, round, position, first_id, second_id, winner_id, status) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, updated_at"
+	for _, match := range matches {
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			tournamentID,
+			match.Round,
+			match.Position,
+			match.FirstID,
+			match.SecondID,
+			match.WinnerID,
+			match.Status,
+		).Scan(&match.ID, &match.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		match.TournamentID = tournamentID
+	}
+	return nil
+}
+
+// scanTournament читает турнир из строки результата.
+func scanTournament(row rowScanner) (*common.Tournament, error) {
+	var tournament common.Tournament
+	err := row.Scan(
+		&tournament.ID,
+		&tournament.Name,
+		&tournament.CreatorID,
+		&tournament.Format,
+		&tournament.Status,
+		&tournament.Variant,
+		&tournament.BoardSize,
+		&tournament.WinLength,
+		&tournament.IsRated,
+		&tournament.MaxParticipants,
+		&tournament.Rounds,
+		&tournament.CurrentRound,
+		&tournament.StartsAt,
+		&tournament.RoundInterval,
+		&tournament.RoundStartedAt,
+		&tournament.WinnerID,
+		&tournament.CreatedAt,
+		&tournament.StartedAt,
+		&tournament.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tournament, nil
+}
+
+// scanTournamentMatch читает матч турнира из строки результата.
+func scanTournamentMatch(row rowScanner) (*common.TournamentMatch, error) {
+	var match common.TournamentMatch
+	err := row.Scan(
+		&match.ID,
+		&match.TournamentID,
+		&match.Round,
+		&match.Position,
+		&match.FirstID,
+		&match.SecondID,
+		&match.WinnerID,
+		&match.Status,
+		&match.RoomID,
+		&match.RoomPassword,
+		&match.GameID,
+		&match.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/google/uuid"
+
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 )
 
@@ -19,6 +21,9 @@ type UserRepository interface {
 	// FindByEmail находит пользователя по email
 	FindByEmail(ctx context.Context, email string) (*common.User, error)
 
+	// FindById находит пользователя по идентификатору
+	FindById(ctx context.Context, id uuid.UUID) (*common.User, error)
+
 	// Create создает нового пользователя в системе
 	Create(ctx context.Context, form common.AuthSignUpRequest) error
 }
@@ -42,16 +47,49 @@ func NewUserRepository(db *sql.DB) UserRepository {
 //
 // Особенности:
 //   - Возвращает только активных пользователей (deleted_at IS NULL)
-//   - Включает в результат: ID, имя, email, хэш пароля и дату создания
+//   - Включает в результат: ID, имя, email, хэш пароля, признак администратора, рейтинг решения задач и дату создания
 func (repo *UserRepo) FindByEmail(ctx context.Context, email string) (*common.User, error) {
 	var user common.User
-	query := "SELECT id, name, email, password, created_at FROM users WHERE email = $1 AND deleted_at IS NULL"
+	query := "SELECT id, name, email, password, is_admin, puzzle_rating, created_at FROM users WHERE email = $1 AND deleted_at IS NULL"
 	row := repo.db.QueryRowContext(ctx, query, email)
 	err := row.Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
 		&user.Password,
+		&user.IsAdmin,
+		&user.PuzzleRating,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindById ищет пользователя по идентификатору
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор пользователя
+//
+// Возвращает:
+//   - *common.User: найденный пользователь
+//   - error: sql.ErrNoRows, если пользователь не найден, или ошибка запроса
+//
+// Особенности:
+//   - Возвращает только активных пользователей (deleted_at IS NULL)
+func (repo *UserRepo) FindById(ctx context.Context, id uuid.UUID) (*common.User, error) {
+	var user common.User
+	query := "SELECT id, name, email, password, is_admin, puzzle_rating, created_at FROM users WHERE id = $1 AND deleted_at IS NULL"
+	row := repo.db.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.IsAdmin,
+		&user.PuzzleRating,
 		&user.CreatedAt,
 	)
 	if err != nil {
@@ -0,0 +1,114 @@
+// Package repository предоставляет реализации репозиториев для работы с данными приложения.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// VariantRepo реализует VariantRepository для работы с PostgreSQL
+type VariantRepo struct {
+	db *sql.DB
+}
+
+// VariantRepository определяет контракт для работы с хранилищем пользовательских вариантов правил
+type VariantRepository interface {
+	// FindAll возвращает все пользовательские варианты правил
+	FindAll(ctx context.Context) ([]*common.CustomVariant, error)
+
+	// Upsert создаёт вариант правил или заменяет описание варианта с тем же именем
+	Upsert(ctx context.Context, variant *common.CustomVariant) error
+}
+
+// NewVariantRepository создает новый экземпляр VariantRepository
+func NewVariantRepository(db *sql.DB) VariantRepository {
+	return &VariantRepo{
+		db: db,
+	}
+}
+
+// FindAll возвращает все пользовательские варианты правил
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//
+// Возвращает:
+//   - []*common.CustomVariant: варианты, отсортированные по имени
+//   - error: ошибка запроса или разбора описания варианта
+func (repo *VariantRepo) FindAll(ctx context.Context) ([]*common.CustomVariant, error) {
+	var variants []*common.CustomVariant
+	query := "SELECT id, name, spec, creator_id, created_at, updated_at FROM custom_variants ORDER BY name"
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var variant common.CustomVariant
+		var spec []byte
+		err := rows.Scan(
+			&variant.ID,
+			&variant.Name,
+			&spec,
+			&variant.CreatorID,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(spec, &variant.Spec); err != nil {
+			return nil, err
+		}
+		variants = append(variants, &variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if variants == nil {
+		variants = []*common.CustomVariant{}
+	}
+	return variants, nil
+}
+
+// Upsert сохраняет пользовательский вариант правил
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - variant: вариант с описанием и автором
+//
+// Возвращает:
+//   - error: ошибка, если вариант не был сохранён
+//
+// Особенности:
+//   - Описание хранится в колонке spec как JSONB
+//   - При совпадении имени заменяет описание и автора и обновляет updated_at
+func (repo *VariantRepo) Upsert(ctx context.Context, variant *common.CustomVariant) error {
+	spec, err := json.Marshal(variant.Spec)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO custom_variants (name, spec, creator_id) VALUES ($1, $2, $3) ON CONFLICT (name) DO UPDATE SET spec = EXCLUDED.spec, creator_id = EXCLUDED.creator_id, updated_at = CURRENT_TIMESTAMP"
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		variant.Name,
+		spec,
+		variant.CreatorID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("variant was not saved")
+	}
+	return nil
+}
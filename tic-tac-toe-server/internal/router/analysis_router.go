@@ -0,0 +1,19 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+)
+
+// analysisRouterGroup регистрирует маршруты для анализа позиций
+//
+// Параметры:
+//   - analysis: chi.Router - роутер для регистрации маршрутов анализа
+//   - dependencies: содержит обработчики запросов (AnalysisHandler)
+//
+// Регистрируемые маршруты:
+//
+//	POST / - исход позиции и оценки ходов
+func analysisRouterGroup(analysis chi.Router) {
+	analysis.Post("/", dependencies.AnalysisHandler.Analyze)
+}
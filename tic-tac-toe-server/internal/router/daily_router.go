@@ -0,0 +1,25 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/handler/middleware"
+)
+
+// dailyRouterGroup регистрирует маршруты для работы с ежедневной задачей
+//
+// Параметры:
+//   - daily: chi.Router - роутер для регистрации маршрутов ежедневной задачи
+//   - dependencies: содержит обработчики запросов (DailyHandler)
+//
+// Регистрируемые маршруты:
+//
+//	GET / - задача дня и быстрейшие решения (первый запрос начинает попытку)
+//	POST /solve - проверка ходов решения задачи дня
+//	PUT / - назначение задачи дня (только для администраторов)
+func dailyRouterGroup(daily chi.Router) {
+	daily.Get("/", dependencies.DailyHandler.GetDaily)
+	daily.Post("/solve", dependencies.DailyHandler.SolveDaily)
+	daily.With(middleware.AdminMiddleware).Put("/", dependencies.DailyHandler.ChooseDaily)
+}
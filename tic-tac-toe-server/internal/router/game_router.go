@@ -0,0 +1,19 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+)
+
+// gamesRouterGroup регистрирует маршруты для работы с сохранёнными партиями
+//
+// Параметры:
+//   - games: chi.Router - роутер для регистрации маршрутов партий
+//   - dependencies: содержит обработчики запросов (GameHandler)
+//
+// Регистрируемые маршруты:
+//
+//	GET /{id}/analysis - разбор партии ход за ходом и точность игроков
+func gamesRouterGroup(games chi.Router) {
+	games.Get("/{id}/analysis", dependencies.GameHandler.GetGameAnalysis)
+}
@@ -0,0 +1,19 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+)
+
+// leaderboardsRouterGroup регистрирует маршруты таблиц лидеров
+//
+// Параметры:
+//   - leaderboards: chi.Router - роутер для регистрации маршрутов таблиц лидеров
+//   - dependencies: содержит обработчики запросов (LeaderboardHandler)
+//
+// Регистрируемые маршруты:
+//
+//	GET / - страница таблицы лидеров с фильтрами по варианту, размеру поля и периоду
+func leaderboardsRouterGroup(leaderboards chi.Router) {
+	leaderboards.Get("/", dependencies.LeaderboardHandler.GetLeaderboard)
+}
@@ -0,0 +1,23 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+)
+
+// matchmakingRouterGroup регистрирует маршруты очереди поиска соперника
+//
+// Параметры:
+//   - matchmaking: chi.Router - роутер для регистрации маршрутов очереди
+//   - dependencies: содержит обработчики запросов (MatchmakingHandler)
+//
+// Регистрируемые маршруты:
+//
+//	POST / - постановка в очередь с условиями партии
+//	GET / - состояние в очереди (после подбора — комната и соперник)
+//	DELETE / - выход из очереди
+func matchmakingRouterGroup(matchmaking chi.Router) {
+	matchmaking.Post("/", dependencies.MatchmakingHandler.JoinQueue)
+	matchmaking.Get("/", dependencies.MatchmakingHandler.GetTicket)
+	matchmaking.Delete("/", dependencies.MatchmakingHandler.LeaveQueue)
+}
@@ -0,0 +1,21 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+)
+
+// puzzlesRouterGroup регистрирует маршруты для работы с задачами
+//
+// Параметры:
+//   - puzzles: chi.Router - роутер для регистрации маршрутов задач
+//   - dependencies: содержит обработчики запросов (PuzzleHandler)
+//
+// Регистрируемые маршруты:
+//
+//	GET /next - следующая задача по рейтингу решения задач
+//	POST /{id}/solve - проверка ходов решения
+func puzzlesRouterGroup(puzzles chi.Router) {
+	puzzles.Get("/next", dependencies.PuzzleHandler.GetNextPuzzle)
+	puzzles.Post("/{id}/solve", dependencies.PuzzleHandler.SolvePuzzle)
+}
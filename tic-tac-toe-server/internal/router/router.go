@@ -39,9 +39,17 @@ func NewRouter(deps *dependency.AppDependencies) *chi.Mux {
 			v1.Use(middleware.AuthMiddleware(deps)) // Middleware аутентификации
 
 			// Группы маршрутов:
-			v1.Route("/rooms", roomsRouterGroup)   // Управление комнатами
-			v1.Route("/users", usersRouterGroup)   // Работа с пользователями
-			v1.Route("/scores", scoresRouterGroup) // Управление результатами игр
+			v1.Route("/rooms", roomsRouterGroup)               // Управление комнатами
+			v1.Route("/users", usersRouterGroup)               // Работа с пользователями
+			v1.Route("/scores", scoresRouterGroup)             // Управление результатами игр
+			v1.Route("/variants", variantsRouterGroup)         // Варианты правил
+			v1.Route("/analysis", analysisRouterGroup)         // Анализ позиций
+			v1.Route("/games", gamesRouterGroup)               // Сохранённые партии и их разбор
+			v1.Route("/puzzles", puzzlesRouterGroup)           // Задачи "выигрыш за N ходов"
+			v1.Route("/daily", dailyRouterGroup)               // Ежедневная задача
+			v1.Route("/leaderboards", leaderboardsRouterGroup) // Таблицы лидеров
+			v1.Route("/matchmaking", matchmakingRouterGroup)   // Поиск соперника
+			v1.Route("/tournaments", tournamentsRouterGroup)   // Турниры
 		})
 	})
 
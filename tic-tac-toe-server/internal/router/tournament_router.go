@@ -0,0 +1,35 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/handler/middleware"
+)
+
+// tournamentsRouterGroup регистрирует маршруты для работы с турнирами
+//
+// Параметры:
+//   - tournaments: chi.Router - роутер для регистрации маршрутов турниров
+//   - dependencies: содержит обработчики запросов (TournamentHandler) и WebSocket сервер
+//
+// Регистрируемые маршруты:
+//
+//	GET / - последние турниры
+//	GET /{id} - турнир с участниками и живой сеткой
+//	POST /{id}/participants - регистрация в турнире
+//	DELETE /{id}/participants - отказ от участия в турнире
+//	POST / - создание турнира (только для администраторов)
+//	POST /{id}/start - старт турнира (только для администраторов)
+//	POST /{id}/rounds - старт следующего круга швейцарского или кругового турнира (только для администраторов)
+//	PUT /{id}/matches/{matchId}/winner - решение зависшего матча (только для администраторов)
+func tournamentsRouterGroup(tournaments chi.Router) {
+	tournaments.Get("/", dependencies.TournamentHandler.GetTournaments)
+	tournaments.Get("/{id}", dependencies.TournamentHandler.GetTournament(dependencies.WSServer))
+	tournaments.Post("/{id}/participants", dependencies.TournamentHandler.RegisterParticipant)
+	tournaments.Delete("/{id}/participants", dependencies.TournamentHandler.WithdrawParticipant)
+	tournaments.With(middleware.AdminMiddleware).Post("/", dependencies.TournamentHandler.CreateTournament)
+	tournaments.With(middleware.AdminMiddleware).Post("/{id}/start", dependencies.TournamentHandler.StartTournament)
+	tournaments.With(middleware.AdminMiddleware).Post("/{id}/rounds", dependencies.TournamentHandler.StartRound)
+	tournaments.With(middleware.AdminMiddleware).Put("/{id}/matches/{matchId}/winner", dependencies.TournamentHandler.SetMatchWinner)
+}
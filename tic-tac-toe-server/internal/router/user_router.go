@@ -14,6 +14,10 @@ import (
 // Регистрируемые маршруты:
 //
 //	GET /current - получение информации о текущем пользователе
+//	GET /{id}/stats - статистика партий пользователя
+//	GET /{id}/versus/{otherId} - личные встречи пользователя с соперником
 func usersRouterGroup(users chi.Router) {
 	users.Get("/current", dependencies.UserHandler.GetCurrentUser)
+	users.Get("/{id}/stats", dependencies.UserHandler.GetUserStats)
+	users.Get("/{id}/versus/{otherId}", dependencies.UserHandler.GetUserVersus)
 }
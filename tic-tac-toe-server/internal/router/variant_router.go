@@ -0,0 +1,25 @@
+// Package router предоставляет функциональность для настройки маршрутизации HTTP запросов.
+package router
+
+import (
+	"github.com/go-chi/chi"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/handler/middleware"
+)
+
+// variantsRouterGroup регистрирует маршруты для работы с вариантами правил
+//
+// Параметры:
+//   - variants: chi.Router - роутер для регистрации маршрутов вариантов
+//   - dependencies: содержит обработчики запросов (VariantHandler)
+//
+// Регистрируемые маршруты:
+//
+//	GET / - список встроенных и пользовательских вариантов
+//	POST /validate - проверка описания варианта без сохранения
+//	POST / - загрузка описания варианта (только для администраторов)
+func variantsRouterGroup(variants chi.Router) {
+	variants.Get("/", dependencies.VariantHandler.GetVariants)
+	variants.Post("/validate", dependencies.VariantHandler.ValidateVariant)
+	variants.With(middleware.AdminMiddleware).Post("/", dependencies.VariantHandler.CreateVariant)
+}
@@ -0,0 +1 @@
+ALTER TABLE rooms DROP COLUMN first_move_policy;
\ No newline at end of file
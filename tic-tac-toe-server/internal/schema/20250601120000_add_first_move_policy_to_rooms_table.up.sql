@@ -0,0 +1 @@
+ALTER TABLE rooms ADD first_move_policy VARCHAR(32) NOT NULL DEFAULT 'creator';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE rooms DROP COLUMN variant;
\ No newline at end of file
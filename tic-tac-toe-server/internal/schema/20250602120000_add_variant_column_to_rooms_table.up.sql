@@ -0,0 +1 @@
+ALTER TABLE rooms ADD variant VARCHAR(32) NOT NULL DEFAULT 'classic';
\ No newline at end of file
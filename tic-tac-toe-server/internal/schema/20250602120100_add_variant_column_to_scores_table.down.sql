@@ -0,0 +1 @@
+ALTER TABLE scores DROP COLUMN variant;
\ No newline at end of file
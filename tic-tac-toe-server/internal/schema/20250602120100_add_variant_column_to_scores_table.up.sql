@@ -0,0 +1 @@
+ALTER TABLE scores ADD variant VARCHAR(32) NOT NULL DEFAULT 'classic';
\ No newline at end of file
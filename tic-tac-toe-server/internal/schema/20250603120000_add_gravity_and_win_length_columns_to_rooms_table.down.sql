@@ -0,0 +1,2 @@
+ALTER TABLE rooms DROP COLUMN win_length;
+ALTER TABLE rooms DROP COLUMN gravity;
\ No newline at end of file
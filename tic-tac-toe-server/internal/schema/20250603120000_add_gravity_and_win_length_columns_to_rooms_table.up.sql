@@ -0,0 +1,2 @@
+ALTER TABLE rooms ADD gravity BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE rooms ADD win_length SMALLINT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE rooms DROP COLUMN piece_limit;
\ No newline at end of file
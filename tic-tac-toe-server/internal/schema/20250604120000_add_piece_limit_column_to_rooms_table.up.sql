@@ -0,0 +1 @@
+ALTER TABLE rooms ADD piece_limit SMALLINT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE scores DROP COLUMN players;
+ALTER TABLE scores DROP COLUMN placement;
\ No newline at end of file
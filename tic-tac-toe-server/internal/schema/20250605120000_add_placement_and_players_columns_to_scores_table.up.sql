@@ -0,0 +1,2 @@
+ALTER TABLE scores ADD placement SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE scores ADD players SMALLINT NOT NULL DEFAULT 2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE rooms DROP COLUMN blocked_seed;
+ALTER TABLE rooms DROP COLUMN blocked_cells;
+ALTER TABLE rooms DROP COLUMN height;
+ALTER TABLE rooms DROP COLUMN width;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE rooms ADD width SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE rooms ADD height SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE rooms ADD blocked_cells TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE rooms ADD blocked_seed BIGINT;
\ No newline at end of file
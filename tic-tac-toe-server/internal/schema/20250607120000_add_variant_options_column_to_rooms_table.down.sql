@@ -0,0 +1 @@
+ALTER TABLE rooms DROP COLUMN variant_options;
\ No newline at end of file
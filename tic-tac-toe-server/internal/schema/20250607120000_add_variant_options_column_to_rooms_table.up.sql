@@ -0,0 +1 @@
+ALTER TABLE rooms ADD variant_options JSONB NOT NULL DEFAULT '{}';
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE scores ALTER COLUMN variant TYPE VARCHAR(32);
+ALTER TABLE rooms ALTER COLUMN variant TYPE VARCHAR(32);
+
+DROP TABLE custom_variants;
+
+ALTER TABLE users DROP COLUMN is_admin;
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE custom_variants (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    spec JSONB NOT NULL,
+    creator_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE rooms ALTER COLUMN variant TYPE VARCHAR(64);
+ALTER TABLE scores ALTER COLUMN variant TYPE VARCHAR(64);
\ No newline at end of file
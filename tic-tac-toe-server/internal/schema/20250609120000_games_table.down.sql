@@ -0,0 +1 @@
+DROP TABLE games;
\ No newline at end of file
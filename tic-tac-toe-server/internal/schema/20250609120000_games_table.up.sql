@@ -0,0 +1,20 @@
+CREATE TABLE games (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT NOT NULL,
+    variant VARCHAR(64) NOT NULL DEFAULT 'classic',
+    width SMALLINT NOT NULL,
+    height SMALLINT NOT NULL,
+    win_length SMALLINT NOT NULL,
+    gravity BOOLEAN NOT NULL DEFAULT FALSE,
+    piece_limit SMALLINT NOT NULL DEFAULT 0,
+    blocked_cells TEXT[] NOT NULL DEFAULT '{}',
+    players JSONB NOT NULL DEFAULT '[]',
+    moves JSONB NOT NULL DEFAULT '[]',
+    winner_symbol VARCHAR(16) NOT NULL DEFAULT '',
+    analysis_status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    analysis JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    analyzed_at TIMESTAMP
+);
+
+CREATE INDEX games_analysis_status_index ON games (analysis_status);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE puzzle_attempts;
+
+DROP TABLE puzzles;
+
+ALTER TABLE users DROP COLUMN puzzle_rating;
\ No newline at end of file
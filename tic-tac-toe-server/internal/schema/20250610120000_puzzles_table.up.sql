@@ -0,0 +1,29 @@
+ALTER TABLE users ADD puzzle_rating INT NOT NULL DEFAULT 1500;
+
+CREATE TABLE puzzles (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL,
+    key VARCHAR(2048) NOT NULL UNIQUE,
+    variant VARCHAR(64) NOT NULL DEFAULT 'classic',
+    border_size SMALLINT NOT NULL,
+    win_length SMALLINT NOT NULL,
+    positions JSONB NOT NULL DEFAULT '[]',
+    turn VARCHAR(16) NOT NULL,
+    win_in SMALLINT NOT NULL,
+    solutions JSONB NOT NULL DEFAULT '[]',
+    rating INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX puzzles_rating_index ON puzzles (rating);
+
+CREATE TABLE puzzle_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    puzzle_id BIGINT NOT NULL,
+    solved BOOLEAN NOT NULL,
+    rating_before INT NOT NULL,
+    rating_after INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, puzzle_id)
+);
\ No newline at end of file
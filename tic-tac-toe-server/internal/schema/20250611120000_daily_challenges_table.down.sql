@@ -0,0 +1,3 @@
+DROP TABLE daily_attempts;
+
+DROP TABLE daily_challenges;
\ No newline at end of file
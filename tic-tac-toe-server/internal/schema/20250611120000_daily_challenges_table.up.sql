@@ -0,0 +1,20 @@
+CREATE TABLE daily_challenges (
+    id BIGSERIAL PRIMARY KEY,
+    day DATE NOT NULL UNIQUE,
+    puzzle_id BIGINT NOT NULL,
+    chosen_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE daily_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    daily_id BIGINT NOT NULL,
+    user_id UUID NOT NULL,
+    solved BOOLEAN,
+    solve_time INT,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP,
+    UNIQUE (daily_id, user_id)
+);
+
+CREATE INDEX daily_attempts_leaderboard_index ON daily_attempts (daily_id, solved, solve_time);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE games DROP COLUMN start_position;
+
+ALTER TABLE rooms DROP COLUMN start_position;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE rooms ADD start_position VARCHAR(512) NOT NULL DEFAULT '';
+
+ALTER TABLE games ADD start_position VARCHAR(512) NOT NULL DEFAULT '';
\ No newline at end of file
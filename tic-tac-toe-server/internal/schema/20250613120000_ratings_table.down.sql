@@ -0,0 +1,7 @@
+DROP TABLE rating_history;
+
+DROP TABLE ratings;
+
+ALTER TABLE scores DROP COLUMN is_rated;
+
+ALTER TABLE rooms DROP COLUMN is_rated;
\ No newline at end of file
@@ -0,0 +1,33 @@
+ALTER TABLE rooms ADD is_rated BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE scores ADD is_rated BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE ratings (
+    user_id UUID NOT NULL,
+    variant VARCHAR(64) NOT NULL DEFAULT '',
+    board_size VARCHAR(8) NOT NULL DEFAULT '',
+    rating DOUBLE PRECISION NOT NULL,
+    rd DOUBLE PRECISION NOT NULL,
+    volatility DOUBLE PRECISION NOT NULL,
+    games INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, variant, board_size)
+);
+
+CREATE INDEX ratings_pool_rating_index ON ratings (variant, board_size, rating DESC);
+
+CREATE TABLE rating_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    score_id BIGINT NOT NULL,
+    variant VARCHAR(64) NOT NULL DEFAULT '',
+    board_size VARCHAR(8) NOT NULL DEFAULT '',
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after DOUBLE PRECISION NOT NULL,
+    rd_before DOUBLE PRECISION NOT NULL,
+    rd_after DOUBLE PRECISION NOT NULL,
+    volatility_before DOUBLE PRECISION NOT NULL,
+    volatility_after DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX rating_history_user_index ON rating_history (user_id, created_at);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX ratings_updated_at_index;
+
+DROP INDEX scores_created_at_index;
+
+DROP INDEX scores_leaderboard_index;
+
+ALTER TABLE scores DROP COLUMN board_size;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE scores ADD board_size VARCHAR(8) NOT NULL DEFAULT '';
+
+CREATE INDEX scores_leaderboard_index ON scores (variant, board_size, created_at) WHERE deleted_at IS NULL;
+
+CREATE INDEX scores_created_at_index ON scores (created_at) WHERE deleted_at IS NULL;
+
+CREATE INDEX ratings_updated_at_index ON ratings (variant, board_size, updated_at);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX scores_user_created_at_index;
+
+ALTER TABLE scores DROP COLUMN moves;
+
+ALTER TABLE scores DROP COLUMN symbol;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE scores ADD symbol VARCHAR(16) NOT NULL DEFAULT '';
+
+ALTER TABLE scores ADD moves INT NOT NULL DEFAULT 0;
+
+CREATE INDEX scores_user_created_at_index ON scores (user_id, created_at) WHERE deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX scores_opponent_ids_index;
+
+ALTER TABLE scores DROP COLUMN opponent_ids;
\ No newline at end of file
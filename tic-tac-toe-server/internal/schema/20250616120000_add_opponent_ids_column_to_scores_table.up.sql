@@ -0,0 +1,3 @@
+ALTER TABLE scores ADD opponent_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX scores_opponent_ids_index ON scores USING GIN (opponent_ids);
\ No newline at end of file
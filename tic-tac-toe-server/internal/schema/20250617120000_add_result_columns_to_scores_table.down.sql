@@ -0,0 +1,9 @@
+DROP INDEX scores_game_id_index;
+
+ALTER TABLE scores DROP COLUMN game_id;
+
+ALTER TABLE scores DROP COLUMN room_id;
+
+ALTER TABLE scores DROP COLUMN termination;
+
+ALTER TABLE scores DROP COLUMN result;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE scores ADD result VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE scores ADD termination VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE scores ADD room_id BIGINT;
+ALTER TABLE scores ADD game_id BIGINT;
+
+UPDATE scores SET result = CASE is_won WHEN 1 THEN 'win' WHEN 0 THEN 'loss' ELSE 'draw' END;
+
+CREATE INDEX scores_game_id_index ON scores (game_id);
\ No newline at end of file
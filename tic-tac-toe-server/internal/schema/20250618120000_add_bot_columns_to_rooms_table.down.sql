@@ -0,0 +1,3 @@
+ALTER TABLE rooms DROP COLUMN bot_after;
+
+ALTER TABLE rooms DROP COLUMN bot_fill;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE rooms ADD bot_fill VARCHAR(8) NOT NULL DEFAULT 'off';
+ALTER TABLE rooms ADD bot_after SMALLINT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE tournament_matches;
+
+DROP TABLE tournament_participants;
+
+DROP TABLE tournaments;
\ No newline at end of file
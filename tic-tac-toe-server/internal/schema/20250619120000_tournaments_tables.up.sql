@@ -0,0 +1,46 @@
+CREATE TABLE tournaments (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    creator_id UUID NOT NULL,
+    format VARCHAR(32) NOT NULL DEFAULT 'single_elimination',
+    status VARCHAR(16) NOT NULL DEFAULT 'registration',
+    variant VARCHAR(64) NOT NULL DEFAULT 'classic',
+    board_size VARCHAR(8) NOT NULL DEFAULT '',
+    win_length SMALLINT NOT NULL DEFAULT 0,
+    is_rated BOOLEAN NOT NULL DEFAULT false,
+    max_participants SMALLINT NOT NULL,
+    rounds SMALLINT NOT NULL DEFAULT 0,
+    current_round SMALLINT NOT NULL DEFAULT 0,
+    winner_id UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE tournament_participants (
+    tournament_id BIGINT NOT NULL,
+    user_id UUID NOT NULL,
+    seed SMALLINT NOT NULL DEFAULT 0,
+    rating DOUBLE PRECISION NOT NULL,
+    eliminated_round SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tournament_id, user_id)
+);
+
+CREATE TABLE tournament_matches (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id BIGINT NOT NULL,
+    round SMALLINT NOT NULL,
+    position SMALLINT NOT NULL,
+    first_id UUID,
+    second_id UUID,
+    winner_id UUID,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    room_id BIGINT,
+    room_password VARCHAR(64) NOT NULL DEFAULT '',
+    game_id BIGINT,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_id, round, position)
+);
+
+CREATE INDEX tournament_matches_room_id_index ON tournament_matches (room_id);
\ No newline at end of file
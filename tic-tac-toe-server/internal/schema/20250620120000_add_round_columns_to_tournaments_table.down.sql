@@ -0,0 +1,5 @@
+ALTER TABLE tournaments DROP COLUMN round_started_at;
+
+ALTER TABLE tournaments DROP COLUMN round_interval;
+
+ALTER TABLE tournaments DROP COLUMN starts_at;
\ No newline at end of file
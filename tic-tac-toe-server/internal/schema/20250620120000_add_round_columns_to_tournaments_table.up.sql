@@ -0,0 +1,3 @@
+ALTER TABLE tournaments ADD starts_at TIMESTAMP;
+ALTER TABLE tournaments ADD round_interval SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE tournaments ADD round_started_at TIMESTAMP;
\ No newline at end of file
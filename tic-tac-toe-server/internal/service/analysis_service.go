@@ -0,0 +1,211 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// ErrInvalidPosition возвращается, если анализируемая позиция не может возникнуть на поле.
+var ErrInvalidPosition = errors.New("invalid position")
+
+// Исходы партии для игрока, который ходит.
+const (
+	winOutcome     = "win"
+	lossOutcome    = "loss"
+	drawOutcome    = "draw"
+	unknownOutcome = "unknown"
+)
+
+// AnalysisService предоставляет анализ позиций: теоретический исход и лучшие ходы.
+type AnalysisService struct {
+	table *transpositionTable
+}
+
+// NewAnalysisService создаёт новый экземпляр AnalysisService с пустой таблицей транспозиций.
+// Таблица общая для всех запросов, поэтому повторный анализ известных позиций не требует перебора.
+func NewAnalysisService() *AnalysisService {
+	return &AnalysisService{
+		table: newTranspositionTable(),
+	}
+}
+
+// Analyze оценивает позицию для игрока, который ходит
+//
+// Параметры:
+//   - ctx: контекст запроса (его дедлайн сокращает время на анализ)
+//   - form: поле, длина линии, фишки, ходящий и время на поиск
+//     (или позиция в нотации позиции вместо поля, длины линии, фишек, ходящего и варианта)
+//
+// Возвращает:
+//   - *common.AnalysisResponse: позиция в нотации, исход, лучшие ходы и оценки всех ходов
+//   - error: ErrInvalidPosition, если клетка лежит вне поля или занята дважды
+//     или позицию из нотации нельзя анализировать (см. applyNotation)
+//
+// Особенности:
+//   - Поле 3x3 всегда решается полностью, большие поля — в пределах времени на анализ
+//   - Если линия на поле уже собрана, возвращается итог партии без ходов
+func (service *AnalysisService) Analyze(ctx context.Context, form common.AnalysisRequest) (*common.AnalysisResponse, error) {
+	if form.Position != "" {
+		if err := applyNotation(&form); err != nil {
+			return nil, err
+		}
+	}
+	size := int(form.BorderSize)
+	winLength := int(form.WinLength)
+	if winLength == 0 {
+		winLength = size
+	}
+	board, err := analysisBoard(form.Positions, size)
+	if err != nil {
+		return nil, err
+	}
+	budget := time.Duration(form.TimeBudget) * time.Millisecond
+	if budget == 0 {
+		budget = DEFAULT_TIME_BUDGET * time.Millisecond
+	}
+	deadline := time.Now().Add(budget)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	misere := form.Variant == misereVariant
+	toMove := symbolCell(form.Turn)
+	s := newSolver(size, winLength, misere, service.table, deadline)
+
+	variant := classicVariant
+	if misere {
+		variant = misereVariant
+	}
+	response := &common.AnalysisResponse{
+		Position: EncodePosition(&common.BoardPosition{
+			Width:     uint8(size),
+			Height:    uint8(size),
+			WinLength: uint8(winLength),
+			Positions: form.Positions,
+			Turn:      form.Turn,
+			Variant:   variant,
+		}),
+		BestMoves: []string{},
+		Moves:     []*common.MoveEvaluation{},
+	}
+	if owner := s.winner(board); owner != 0 {
+		// Партия уже окончена: в классике собравший линию выиграл, в поддавках — проиграл
+		response.Exact = true
+		response.Outcome = lossOutcome
+		response.Score = -WIN_SCORE
+		if (owner == toMove) != misere {
+			response.Outcome = winOutcome
+			response.Score = WIN_SCORE
+		}
+		return response, nil
+	}
+	empties := 0
+	for _, cell := range board {
+		if cell == 0 {
+			empties++
+		}
+	}
+	if empties == 0 {
+		response.Exact = true
+		response.Outcome = drawOutcome
+		return response, nil
+	}
+
+	results, exact := s.analyze(board, toMove, empties)
+	response.Exact = exact || isDecisive(results[0].score)
+	response.Depth = s.completed
+	response.Score = results[0].score
+	response.Outcome = scoreOutcome(results[0].score, exact)
+	response.Nodes = s.nodes
+	for _, move := range results {
+		positionID := fmt.Sprintf("%d-%d", move.index/size, move.index%size)
+		if move.score == results[0].score {
+			response.BestMoves = append(response.BestMoves, positionID)
+		}
+		response.Moves = append(response.Moves, &common.MoveEvaluation{
+			PositionID: positionID,
+			Score:      move.score,
+			Outcome:    scoreOutcome(move.score, exact),
+		})
+	}
+	return response, nil
+}
+
+// applyNotation заменяет поле, длину линии, фишки, ходящего и вариант запроса
+// позицией из нотации
+//
+// Возвращает:
+//   - error: ErrInvalidPosition, если нотацию нельзя разобрать или анализатор не умеет
+//     разбирать позицию: поле не квадратное, есть заблокированные клетки, знаки кроме
+//     X и O или вариант не classic и не misere
+func applyNotation(form *common.AnalysisRequest) error {
+	position, err := DecodePosition(form.Position)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPosition, err)
+	}
+	if position.Width != position.Height {
+		return fmt.Errorf("%w: board must be square", ErrInvalidPosition)
+	}
+	if len(position.BlockedCells) > 0 {
+		return fmt.Errorf("%w: blocked cells are not supported", ErrInvalidPosition)
+	}
+	if position.Variant != classicVariant && position.Variant != misereVariant {
+		return fmt.Errorf("%w: variant %s is not supported", ErrInvalidPosition, position.Variant)
+	}
+	if opositeSymbol(position.Turn) == "" {
+		return fmt.Errorf("%w: side to move must be X or O", ErrInvalidPosition)
+	}
+	for _, piece := range position.Positions {
+		if opositeSymbol(piece.Symbol) == "" {
+			return fmt.Errorf("%w: only X and O pieces are supported", ErrInvalidPosition)
+		}
+	}
+	form.BorderSize = position.Width
+	form.WinLength = position.WinLength
+	form.Positions = position.Positions
+	form.Turn = position.Turn
+	form.Variant = position.Variant
+	return nil
+}
+
+// analysisBoard строит поле для поиска из фишек запроса.
+func analysisBoard(positions []common.AnalysisPosition, size int) ([]int8, error) {
+	board := make([]int8, size*size)
+	for _, position := range positions {
+		row, column, ok := parsePositionID(position.ID)
+		if !ok || row < 0 || column < 0 || row >= size || column >= size {
+			return nil, fmt.Errorf("%w: cell %q is out of board", ErrInvalidPosition, position.ID)
+		}
+		if board[row*size+column] != 0 {
+			return nil, fmt.Errorf("%w: cell %q is duplicated", ErrInvalidPosition, position.ID)
+		}
+		board[row*size+column] = symbolCell(position.Symbol)
+	}
+	return board, nil
+}
+
+// symbolCell переводит знак в значение клетки поля для поиска.
+func symbolCell(symbol string) int8 {
+	if symbol == "O" {
+		return 2
+	}
+	return 1
+}
+
+// scoreOutcome переводит оценку в исход партии для ходящего.
+// Нулевая оценка означает ничью, только если перебор дошёл до конца партии.
+func scoreOutcome(score int, exact bool) string {
+	switch {
+	case score >= WIN_SCORE-MAX_PLY-1:
+		return winOutcome
+	case score <= -WIN_SCORE+MAX_PLY+1:
+		return lossOutcome
+	case exact:
+		return drawOutcome
+	}
+	return unknownOutcome
+}
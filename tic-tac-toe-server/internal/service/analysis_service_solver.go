@@ -0,0 +1,426 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Параметры поиска лучшего хода.
+const (
+	// WIN_SCORE задаёт оценку выигранной позиции: выигрыш через n полуходов оценивается как WIN_SCORE-n.
+	WIN_SCORE = 1_000_000
+	// MAX_PLY задаёт наибольшее число полуходов в партии (поле 15x15).
+	MAX_PLY = 225
+	// DEFAULT_TIME_BUDGET задаёт время на анализ позиции по умолчанию в миллисекундах.
+	DEFAULT_TIME_BUDGET = 1000
+	// TRANSPOSITION_TABLE_SIZE задаёт наибольшее число позиций в таблице транспозиций.
+	TRANSPOSITION_TABLE_SIZE = 1 << 20
+)
+
+// errSearchTimeout прерывает поиск, когда истекло время на анализ.
+var errSearchTimeout = errors.New("search timeout")
+
+// Тип оценки, сохранённой в таблице транспозиций.
+const (
+	ttExact = iota // точная оценка
+	ttLower        // оценка не меньше сохранённой (отсечение по beta)
+	ttUpper        // оценка не больше сохранённой (все ходы хуже alpha)
+)
+
+// ttEntry хранит результат поиска из позиции.
+//
+// Поля:
+//   - score: оценка для ходящего
+//   - depth: глубина поиска (не больше числа свободных клеток — тогда оценка доказана)
+//   - flag: тип оценки (ttExact/ttLower/ttUpper)
+//   - best: лучший ход в координатах канонического поля (-1 — неизвестен)
+type ttEntry struct {
+	score int
+	depth int
+	flag  int
+	best  int
+}
+
+// transpositionTable кэширует оценки позиций между запросами анализа.
+// Ключ — каноническое поле: из восьми поворотов и отражений выбирается наименьшее,
+// а фишки записываются относительно ходящего, поэтому симметричные позиции
+// и позиции с переставленными цветами делят одну запись.
+type transpositionTable struct {
+	mu      sync.Mutex
+	entries map[string]ttEntry
+}
+
+// newTranspositionTable создаёт пустую таблицу транспозиций.
+func newTranspositionTable() *transpositionTable {
+	return &transpositionTable{
+		entries: make(map[string]ttEntry),
+	}
+}
+
+// load возвращает сохранённую оценку позиции.
+func (table *transpositionTable) load(key string) (ttEntry, bool) {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	entry, ok := table.entries[key]
+	return entry, ok
+}
+
+// store сохраняет оценку позиции. Заполненная таблица очищается целиком.
+func (table *transpositionTable) store(key string, entry ttEntry) {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	if len(table.entries) >= TRANSPOSITION_TABLE_SIZE {
+		table.entries = make(map[string]ttEntry)
+	}
+	table.entries[key] = entry
+}
+
+// solver ищет лучшие ходы на квадратном поле перебором negamax с альфа-бета отсечениями,
+// итеративным углублением и таблицей транспозиций.
+// Клетки поля: 0 — пусто, 1 — X, 2 — O.
+type solver struct {
+	size        int
+	winLength   int
+	misere      bool
+	windows     [][]int
+	cellWindows [][]int
+	symmetries  [][]int
+	inverse     [][]int
+	order       []int
+	table       *transpositionTable
+	deadline    time.Time
+	completed   int
+	nodes       uint64
+}
+
+// rootMove хранит оценку хода из анализируемой позиции.
+type rootMove struct {
+	index int
+	score int
+}
+
+// newSolver подготавливает линии и симметрии поля
+//
+// Параметры:
+//   - size: размер поля
+//   - winLength: длина выигрышной линии
+//   - misere: собравший линию проигрывает
+//   - table: таблица транспозиций
+//   - deadline: момент, после которого поиск прерывается
+func newSolver(size, winLength int, misere bool, table *transpositionTable, deadline time.Time) *solver {
+	s := &solver{
+		size:        size,
+		winLength:   winLength,
+		misere:      misere,
+		cellWindows: make([][]int, size*size),
+		table:       table,
+		deadline:    deadline,
+	}
+	for row := 0; row < size; row++ {
+		for column := 0; column < size; column++ {
+			for _, direction := range lineDirections {
+				endRow := row + direction[0]*(winLength-1)
+				endColumn := column + direction[1]*(winLength-1)
+				if endRow < 0 || endRow >= size || endColumn < 0 || endColumn >= size {
+					continue
+				}
+				window := make([]int, 0, winLength)
+				for step := 0; step < winLength; step++ {
+					index := (row+direction[0]*step)*size + column + direction[1]*step
+					window = append(window, index)
+					s.cellWindows[index] = append(s.cellWindows[index], len(s.windows))
+				}
+				s.windows = append(s.windows, window)
+			}
+		}
+	}
+	last := size - 1
+	transforms := []func(row, column int) (int, int){
+		func(row, column int) (int, int) { return row, column },
+		func(row, column int) (int, int) { return column, last - row },
+		func(row, column int) (int, int) { return last - row, last - column },
+		func(row, column int) (int, int) { return last - column, row },
+		func(row, column int) (int, int) { return row, last - column },
+		func(row, column int) (int, int) { return last - row, column },
+		func(row, column int) (int, int) { return column, row },
+		func(row, column int) (int, int) { return last - column, last - row },
+	}
+	for _, transform := range transforms {
+		permutation := make([]int, size*size)
+		inverse := make([]int, size*size)
+		for index := range permutation {
+			row, column := transform(index/size, index%size)
+			permutation[index] = row*size + column
+			inverse[row*size+column] = index
+		}
+		s.symmetries = append(s.symmetries, permutation)
+		s.inverse = append(s.inverse, inverse)
+	}
+	// Ходы перебираются от центра к краям: центральные клетки входят в большее число линий
+	center := float64(last) / 2
+	s.order = make([]int, size*size)
+	for index := range s.order {
+		s.order[index] = index
+	}
+	distance := func(index int) float64 {
+		row, column := float64(index/size)-center, float64(index%size)-center
+		return row*row + column*column
+	}
+	sort.SliceStable(s.order, func(i, j int) bool {
+		return distance(s.order[i]) < distance(s.order[j])
+	})
+	return s
+}
+
+// key возвращает ключ канонического поля и номер симметрии, которая к нему приводит.
+func (s *solver) key(board []int8, toMove int8) (string, int) {
+	header := []byte{byte(s.size), byte(s.winLength), 0}
+	if s.misere {
+		header[2] = 1
+	}
+	var best []byte
+	bestSymmetry := 0
+	buffer := make([]byte, len(header)+len(board))
+	copy(buffer, header)
+	for symmetry, permutation := range s.symmetries {
+		for index, cell := range board {
+			value := byte(0)
+			if cell == toMove {
+				value = 1
+			} else if cell != 0 {
+				value = 2
+			}
+			buffer[len(header)+permutation[index]] = value
+		}
+		if best == nil || bytes.Compare(buffer, best) < 0 {
+			best = append(best[:0], buffer...)
+			bestSymmetry = symmetry
+		}
+	}
+	return string(best), bestSymmetry
+}
+
+// wins проверяет, собрала ли фишка в клетке index линию.
+func (s *solver) wins(board []int8, index int) bool {
+	mover := board[index]
+	for _, windowIndex := range s.cellWindows[index] {
+		complete := true
+		for _, cell := range s.windows[windowIndex] {
+			if board[cell] != mover {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return true
+		}
+	}
+	return false
+}
+
+// winner возвращает знак, уже собравший линию на поле (0 — линии нет).
+func (s *solver) winner(board []int8) int8 {
+	for index, cell := range board {
+		if cell != 0 && s.wins(board, index) {
+			return cell
+		}
+	}
+	return 0
+}
+
+// evaluate оценивает позицию без перебора: каждая линия, занятая фишками только одного
+// игрока, приносит ему квадрат числа своих фишек.
+func (s *solver) evaluate(board []int8, toMove int8) int {
+	score := 0
+	for _, window := range s.windows {
+		own, opponent := 0, 0
+		for _, cell := range window {
+			switch board[cell] {
+			case 0:
+			case toMove:
+				own++
+			default:
+				opponent++
+			}
+		}
+		if opponent == 0 {
+			score += own * own
+		} else if own == 0 {
+			score -= opponent * opponent
+		}
+	}
+	if s.misere {
+		return -score
+	}
+	return score
+}
+
+// moves возвращает свободные клетки в порядке перебора: сначала first, затем от центра к краям.
+func (s *solver) moves(board []int8, first int) []int {
+	moves := make([]int, 0, len(board))
+	if first >= 0 && board[first] == 0 {
+		moves = append(moves, first)
+	}
+	for _, index := range s.order {
+		if board[index] == 0 && index != first {
+			moves = append(moves, index)
+		}
+	}
+	return moves
+}
+
+// negamax оценивает позицию для ходящего
+//
+// Параметры:
+//   - board: поле (восстанавливается после перебора)
+//   - toMove: знак ходящего
+//   - depth: оставшаяся глубина в полуходах
+//   - alpha, beta: окно поиска
+//   - last: клетка последнего хода (-1 — неизвестна)
+//   - empties: число свободных клеток
+//
+// Возвращает:
+//   - int: оценка позиции
+//   - error: errSearchTimeout, если истекло время
+func (s *solver) negamax(board []int8, toMove int8, depth, alpha, beta, last, empties int) (int, error) {
+	s.nodes++
+	if s.completed > 0 && s.nodes&1023 == 0 && time.Now().After(s.deadline) {
+		return 0, errSearchTimeout
+	}
+	if last >= 0 && s.wins(board, last) {
+		if s.misere {
+			return WIN_SCORE, nil
+		}
+		return -WIN_SCORE, nil
+	}
+	if empties == 0 {
+		return 0, nil
+	}
+	if depth == 0 {
+		return s.evaluate(board, toMove), nil
+	}
+	depth = min(depth, empties)
+	alphaOrig := alpha
+	key, symmetry := s.key(board, toMove)
+	best := -1
+	if entry, ok := s.table.load(key); ok {
+		if entry.best >= 0 {
+			best = s.inverse[symmetry][entry.best]
+		}
+		if entry.depth >= depth {
+			switch entry.flag {
+			case ttExact:
+				return entry.score, nil
+			case ttLower:
+				alpha = max(alpha, entry.score)
+			case ttUpper:
+				beta = min(beta, entry.score)
+			}
+			if alpha >= beta {
+				return entry.score, nil
+			}
+		}
+	}
+	bestScore := -2 * WIN_SCORE
+	for _, index := range s.moves(board, best) {
+		board[index] = toMove
+		score, err := s.negamax(board, 3-toMove, depth-1, -beta, -alpha, index, empties-1)
+		board[index] = 0
+		if err != nil {
+			return 0, err
+		}
+		score = fromChild(score)
+		if score > bestScore {
+			bestScore, best = score, index
+		}
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			break
+		}
+	}
+	flag := ttExact
+	if bestScore <= alphaOrig {
+		flag = ttUpper
+	} else if bestScore >= beta {
+		flag = ttLower
+	}
+	s.table.store(key, ttEntry{
+		score: bestScore,
+		depth: depth,
+		flag:  flag,
+		best:  s.symmetries[symmetry][best],
+	})
+	return bestScore, nil
+}
+
+// analyze оценивает каждый ход из позиции итеративным углублением
+//
+// Логика:
+//  1. На каждой итерации глубина растёт на полуход, ходы сортируются по оценкам прошлой итерации
+//  2. Первая итерация всегда завершается, остальные прерываются по истечении времени
+//  3. Поиск останавливается, когда глубина достигла числа свободных клеток
+//     или исход каждого хода уже известен
+//
+// Возвращает:
+//   - []rootMove: оценки ходов последней завершённой итерации, лучшие первыми
+//   - bool: оценки доказаны перебором до конца партии
+func (s *solver) analyze(board []int8, toMove int8, empties int) ([]rootMove, bool) {
+	var results []rootMove
+	current := make([]rootMove, 0, empties)
+	for _, index := range s.moves(board, -1) {
+		current = append(current, rootMove{index: index})
+	}
+	for depth := 1; depth <= empties; depth++ {
+		for i := range current {
+			index := current[i].index
+			board[index] = toMove
+			score, err := s.negamax(board, 3-toMove, depth-1, -2*WIN_SCORE, 2*WIN_SCORE, index, empties-1)
+			board[index] = 0
+			if err != nil {
+				return results, false
+			}
+			current[i].score = fromChild(score)
+		}
+		sort.SliceStable(current, func(i, j int) bool {
+			return current[i].score > current[j].score
+		})
+		results = append(results[:0], current...)
+		s.completed = depth
+		if depth == empties {
+			return results, true
+		}
+		allDecisive := true
+		for _, move := range results {
+			if !isDecisive(move.score) {
+				allDecisive = false
+				break
+			}
+		}
+		if allDecisive {
+			return results, true
+		}
+	}
+	return results, true
+}
+
+// fromChild переводит оценку дочерней позиции в оценку для ходящего:
+// меняет знак и удлиняет выигрыш или проигрыш на один полуход.
+func fromChild(score int) int {
+	score = -score
+	if score > WIN_SCORE-MAX_PLY-1 {
+		return score - 1
+	}
+	if score < -WIN_SCORE+MAX_PLY+1 {
+		return score + 1
+	}
+	return score
+}
+
+// isDecisive проверяет, что оценка означает доказанный выигрыш или проигрыш.
+func isDecisive(score int) bool {
+	return score >= WIN_SCORE-MAX_PLY-1 || score <= -WIN_SCORE+MAX_PLY+1
+}
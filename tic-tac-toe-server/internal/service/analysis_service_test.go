@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      common.AnalysisRequest
+		outcome   string
+		bestMoves []string
+		err       error
+	}{
+		{
+			name:    "empty 3x3 is a draw",
+			form:    common.AnalysisRequest{BorderSize: 3, Turn: "X"},
+			outcome: drawOutcome,
+		},
+		{
+			name: "immediate win",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "X", Positions: []common.AnalysisPosition{
+				{ID: "0-0", Symbol: "X"}, {ID: "0-1", Symbol: "X"},
+				{ID: "1-0", Symbol: "O"}, {ID: "1-1", Symbol: "O"},
+			}},
+			outcome:   winOutcome,
+			bestMoves: []string{"0-2"},
+		},
+		{
+			name: "forced block",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "O", Positions: []common.AnalysisPosition{
+				{ID: "0-0", Symbol: "X"}, {ID: "0-2", Symbol: "X"}, {ID: "1-1", Symbol: "O"},
+			}},
+			outcome:   drawOutcome,
+			bestMoves: []string{"0-1"},
+		},
+		{
+			name: "finished board is lost for the side to move",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "O", Positions: []common.AnalysisPosition{
+				{ID: "0-0", Symbol: "X"}, {ID: "0-1", Symbol: "X"}, {ID: "0-2", Symbol: "X"},
+				{ID: "1-0", Symbol: "O"}, {ID: "1-1", Symbol: "O"},
+			}},
+			outcome: lossOutcome,
+		},
+		{
+			name: "finished misere board is won for the side to move",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "O", Variant: misereVariant, Positions: []common.AnalysisPosition{
+				{ID: "0-0", Symbol: "X"}, {ID: "0-1", Symbol: "X"}, {ID: "0-2", Symbol: "X"},
+				{ID: "1-0", Symbol: "O"}, {ID: "1-1", Symbol: "O"},
+			}},
+			outcome: winOutcome,
+		},
+		{
+			name: "misere avoids completing the line",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "X", Variant: misereVariant, Positions: []common.AnalysisPosition{
+				{ID: "0-0", Symbol: "X"}, {ID: "0-1", Symbol: "X"}, {ID: "1-0", Symbol: "O"},
+				{ID: "1-1", Symbol: "O"}, {ID: "2-2", Symbol: "O"},
+			}},
+		},
+		{
+			name:      "notation position",
+			form:      common.AnalysisRequest{Position: "3x3 3 XX1/OO1/3 X classic"},
+			outcome:   winOutcome,
+			bestMoves: []string{"0-2"},
+		},
+		{
+			name: "cell out of board",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "X", Positions: []common.AnalysisPosition{
+				{ID: "3-0", Symbol: "O"},
+			}},
+			err: ErrInvalidPosition,
+		},
+		{
+			name: "cell taken twice",
+			form: common.AnalysisRequest{BorderSize: 3, Turn: "X", Positions: []common.AnalysisPosition{
+				{ID: "1-1", Symbol: "O"}, {ID: "1-1", Symbol: "X"},
+			}},
+			err: ErrInvalidPosition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := NewAnalysisService().Analyze(context.Background(), tt.form)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Analyze() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if !response.Exact {
+				t.Errorf("Analyze() exact = false, want true")
+			}
+			if tt.outcome != "" && response.Outcome != tt.outcome {
+				t.Errorf("Analyze() outcome = %q, want %q", response.Outcome, tt.outcome)
+			}
+			if tt.bestMoves != nil && !slices.Equal(response.BestMoves, tt.bestMoves) {
+				t.Errorf("Analyze() best moves = %v, want %v", response.BestMoves, tt.bestMoves)
+			}
+			if tt.form.Variant == misereVariant && tt.outcome == "" && slices.Contains(response.BestMoves, "0-2") {
+				t.Errorf("Analyze() best moves = %v, completing the line must not be best", response.BestMoves)
+			}
+		})
+	}
+}
+
+// Таблица транспозиций хранит позиции в каноническом виде, поэтому повторный анализ
+// симметричной позиции тем же сервисом должен вернуть ходы, отображённые обратно на поле запроса.
+func TestAnalyzeSymmetricPositions(t *testing.T) {
+	service := NewAnalysisService()
+	tests := []struct {
+		name      string
+		positions []common.AnalysisPosition
+		bestMoves []string
+	}{
+		{
+			name:      "original",
+			positions: []common.AnalysisPosition{{ID: "0-0", Symbol: "X"}, {ID: "0-2", Symbol: "X"}, {ID: "1-1", Symbol: "O"}},
+			bestMoves: []string{"0-1"},
+		},
+		{
+			name:      "transposed",
+			positions: []common.AnalysisPosition{{ID: "0-0", Symbol: "X"}, {ID: "2-0", Symbol: "X"}, {ID: "1-1", Symbol: "O"}},
+			bestMoves: []string{"1-0"},
+		},
+		{
+			name:      "rotated",
+			positions: []common.AnalysisPosition{{ID: "0-2", Symbol: "X"}, {ID: "2-2", Symbol: "X"}, {ID: "1-1", Symbol: "O"}},
+			bestMoves: []string{"1-2"},
+		},
+		{
+			name:      "mirrored",
+			positions: []common.AnalysisPosition{{ID: "2-0", Symbol: "X"}, {ID: "2-2", Symbol: "X"}, {ID: "1-1", Symbol: "O"}},
+			bestMoves: []string{"2-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.Analyze(context.Background(), common.AnalysisRequest{
+				BorderSize: 3,
+				Turn:       "O",
+				Positions:  tt.positions,
+			})
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if response.Outcome != drawOutcome {
+				t.Errorf("Analyze() outcome = %q, want %q", response.Outcome, drawOutcome)
+			}
+			if !slices.Equal(response.BestMoves, tt.bestMoves) {
+				t.Errorf("Analyze() best moves = %v, want %v", response.BestMoves, tt.bestMoves)
+			}
+		})
+	}
+}
+
+func TestSolverKeySymmetry(t *testing.T) {
+	s := newSolver(3, 3, false, newTranspositionTable(), time.Now().Add(time.Second))
+	base := []int8{
+		1, 0, 0,
+		0, 2, 0,
+		0, 0, 0,
+	}
+	tests := []struct {
+		name  string
+		board []int8
+		same  bool
+	}{
+		{name: "rotated", board: []int8{0, 0, 1, 0, 2, 0, 0, 0, 0}, same: true},
+		{name: "mirrored", board: []int8{0, 0, 0, 0, 2, 0, 1, 0, 0}, same: true},
+		{name: "opposite corner", board: []int8{0, 0, 0, 0, 2, 0, 0, 0, 1}, same: true},
+		{name: "edge instead of corner", board: []int8{0, 1, 0, 0, 2, 0, 0, 0, 0}, same: false},
+		{name: "swapped symbols", board: []int8{2, 0, 0, 0, 1, 0, 0, 0, 0}, same: false},
+	}
+	want, _ := s.key(base, 1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := s.key(tt.board, 1)
+			if (got == want) != tt.same {
+				t.Errorf("key() equal = %v, want %v", got == want, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/config"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// Параметры ежедневной задачи.
+const (
+	// DAILY_LEADERBOARD_SIZE задаёт число строк в таблице быстрейших решений.
+	DAILY_LEADERBOARD_SIZE = 10
+	// DAILY_RETRY_INTERVAL задаёт паузу перед повторным выбором задачи дня после ошибки.
+	DAILY_RETRY_INTERVAL = 5 * time.Minute
+)
+
+// Ошибки ежедневной задачи.
+var (
+	// ErrDailyNotFound возвращается, если задача дня не выбрана и выбрать её не из чего.
+	ErrDailyNotFound = errors.New("daily challenge not found")
+	// ErrDailyNotStarted возвращается при решении задачи, которую пользователь ещё не открывал.
+	ErrDailyNotStarted = errors.New("daily challenge is not started")
+	// ErrDailyAlreadyAttempted возвращается при повторной попытке решить задачу дня.
+	ErrDailyAlreadyAttempted = errors.New("daily challenge is already attempted")
+	// ErrDailyLocked возвращается при замене задачи дня, которую уже начали решать.
+	ErrDailyLocked = errors.New("daily challenge already has attempts")
+	// ErrInvalidDailyDay возвращается при выборе задачи на прошедший день.
+	ErrInvalidDailyDay = errors.New("daily challenge day is in the past")
+)
+
+// DailyService выбирает ежедневную задачу и принимает её решения.
+type DailyService struct {
+	repo     repository.DailyRepository
+	puzzles  *PuzzleService
+	location *time.Location
+}
+
+// NewDailyService создаёт новый экземпляр DailyService.
+// Дни отсчитываются в часовом поясе DAILY_TIMEZONE из конфигурации.
+func NewDailyService(repo repository.DailyRepository, puzzles *PuzzleService) *DailyService {
+	return &DailyService{
+		repo:     repo,
+		puzzles:  puzzles,
+		location: dailyLocation(),
+	}
+}
+
+// dailyLocation возвращает часовой пояс DAILY_TIMEZONE (UTC, если он задан неверно).
+func dailyLocation() *time.Location {
+	location, err := time.LoadLocation(config.ServerConfig.DailyTimezone)
+	if err != nil {
+		slog.Error("[daily]dailyLocation", slog.String("error", err.Error()))
+		return time.UTC
+	}
+	return location
+}
+
+// RunScheduler выбирает задачу дня при запуске и в каждую полночь часового пояса
+// DAILY_TIMEZONE, пока не отменён контекст. Если выбрать задачу не удалось
+// (например, задач ещё нет), попытка повторяется через DAILY_RETRY_INTERVAL.
+func (service *DailyService) RunScheduler(ctx context.Context) {
+	for {
+		wait := service.untilMidnight(time.Now())
+		if _, err := service.ensure(ctx, service.today()); err != nil {
+			slog.Error("[daily]RunScheduler", slog.String("error", err.Error()))
+			wait = min(wait, DAILY_RETRY_INTERVAL)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Get возвращает задачу дня
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//
+// Логика:
+//  1. Если планировщик ещё не выбрал задачу на сегодня, она выбирается сейчас
+//  2. Первое открытие задачи начинает попытку пользователя: от него считается время решения
+//  3. Вместе с задачей возвращаются попытка пользователя и быстрейшие решения дня
+//
+// Возвращает:
+//   - *common.DailyResponse: задача без решений, попытка и таблица решений
+//   - error: ErrDailyNotFound или ошибка запроса
+func (service *DailyService) Get(ctx context.Context) (*common.DailyResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	challenge, err := service.ensure(ctx, service.today())
+	if err != nil {
+		return nil, err
+	}
+	puzzle, err := service.puzzles.repo.FindById(ctx, challenge.PuzzleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.repo.StartAttempt(ctx, challenge.ID, user.ID); err != nil {
+		return nil, err
+	}
+	attempt, err := service.repo.FindAttempt(ctx, challenge.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	leaderboard, err := service.repo.FindLeaderboard(ctx, challenge.ID, DAILY_LEADERBOARD_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	return &common.DailyResponse{
+		Day:         challenge.Day,
+		Puzzle:      puzzle,
+		Attempt:     attempt,
+		Leaderboard: leaderboard,
+	}, nil
+}
+
+// Solve проверяет ходы решения задачи дня
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - form: свои ходы решающего по порядку
+//
+// Логика:
+//  1. Ходы проверяются так же, как в обычных задачах, но рейтинг решения задач не меняется
+//  2. Решение или ошибка завершают попытку: у каждого пользователя одна попытка в день
+//  3. Время решения — от первого открытия задачи до завершения попытки
+//
+// Возвращает:
+//   - *common.PuzzleSolveResponse: состояние решения, ответ соперника и время решения
+//   - error: ErrDailyNotFound, ErrDailyNotStarted, ErrDailyAlreadyAttempted,
+//     ErrInvalidPosition (клетка вне поля или занята) или ошибка запроса
+func (service *DailyService) Solve(ctx context.Context, form common.PuzzleSolveRequest) (*common.PuzzleSolveResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	challenge, err := service.repo.FindByDay(ctx, service.today())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDailyNotFound
+		}
+		return nil, err
+	}
+	attempt, err := service.repo.FindAttempt(ctx, challenge.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDailyNotStarted
+		}
+		return nil, err
+	}
+	if attempt.FinishedAt != nil {
+		return nil, ErrDailyAlreadyAttempted
+	}
+	puzzle, err := service.puzzles.repo.FindById(ctx, challenge.PuzzleID)
+	if err != nil {
+		return nil, err
+	}
+	status, reply, err := service.puzzles.play(ctx, puzzle, form.Moves)
+	if err != nil {
+		return nil, err
+	}
+	response := &common.PuzzleSolveResponse{
+		Status: status,
+		Reply:  reply,
+		Rating: user.PuzzleRating,
+	}
+	if response.Status == continuePuzzleStatus {
+		return response, nil
+	}
+	solveTime, err := service.repo.FinishAttempt(ctx, attempt.ID, response.Status == solvedPuzzleStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDailyAlreadyAttempted
+		}
+		return nil, err
+	}
+	response.SolveTime = &solveTime
+	return response, nil
+}
+
+// Choose назначает задачу дня администратором
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем-администратором
+//   - form: задача и день (по умолчанию сегодня)
+//
+// Возвращает:
+//   - *common.DailyChallenge: сохранённая задача дня
+//   - error: ErrInvalidDailyDay, ErrPuzzleNotFound, ErrDailyLocked (задачу дня уже начали решать)
+//     или ошибка запроса
+func (service *DailyService) Choose(ctx context.Context, form common.DailyChooseRequest) (*common.DailyChallenge, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	day := form.Day
+	if day == "" {
+		day = service.today()
+	}
+	if day < service.today() {
+		return nil, ErrInvalidDailyDay
+	}
+	if _, err := service.puzzles.repo.FindById(ctx, form.PuzzleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPuzzleNotFound
+		}
+		return nil, err
+	}
+	chosen, err := service.repo.SetPuzzle(ctx, &common.DailyChallenge{
+		Day:      day,
+		PuzzleID: form.PuzzleID,
+		ChosenBy: &user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !chosen {
+		return nil, ErrDailyLocked
+	}
+	return service.repo.FindByDay(ctx, day)
+}
+
+// ensure возвращает задачу за день, выбирая её автоматически, если администратор
+// её не назначил.
+func (service *DailyService) ensure(ctx context.Context, day string) (*common.DailyChallenge, error) {
+	challenge, err := service.repo.FindByDay(ctx, day)
+	if err == nil {
+		return challenge, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	puzzleID, err := service.repo.PickPuzzleId(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDailyNotFound
+		}
+		return nil, err
+	}
+	err = service.repo.Create(ctx, &common.DailyChallenge{
+		Day:      day,
+		PuzzleID: puzzleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Задачу за этот день мог одновременно выбрать другой запрос
+	return service.repo.FindByDay(ctx, day)
+}
+
+// today возвращает текущий день в часовом поясе DAILY_TIMEZONE.
+func (service *DailyService) today() string {
+	return dailyDay(service.location)
+}
+
+// dailyDay возвращает текущий день в часовом поясе location в формате "2006-01-02".
+func dailyDay(location *time.Location) string {
+	return time.Now().In(location).Format(time.DateOnly)
+}
+
+// untilMidnight возвращает время до следующей полуночи в часовом поясе DAILY_TIMEZONE.
+func (service *DailyService) untilMidnight(now time.Time) time.Duration {
+	local := now.In(service.location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, service.location)
+	return midnight.Sub(now)
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -49,43 +50,121 @@ var Upgrader = websocket.Upgrader{
 }
 
 // ConnectedUser представляет подключённого пользователя в комнате игры.
+// IsBot отмечает бота, подсаженного к одинокому игроку: у бота нет соединения,
+// а BotRating задаёт силу его игры.
 type ConnectedUser struct {
 	ID          uuid.UUID       `json:"id"`
 	Name        string          `json:"name"`
 	Symbol      string          `json:"symbol"`
 	Connection  *websocket.Conn `json:"connection"`
 	IsConnected bool            `json:"is_connected"`
+	IsBot       bool            `json:"is_bot"`
+	BotRating   float64         `json:"-"`
 }
 
 // SymbolPosition описывает занятую позицию на игровом поле.
+//
+// Поля:
+//   - ID: клетка общего поля в формате "i-j"
+//   - Symbol: поставленный знак (в варианте wild может не совпадать с символом игрока)
+//   - UserID: игрок, сделавший ход
+//   - Board: индекс подполя (0-8) для варианта Ultimate
+//   - Layer: слой куба (0-3) для варианта Qubic, ID при этом имеет формат "l-i-j"
+//   - Move: порядковый номер хода в партии (начиная с 1)
 type SymbolPosition struct {
-	ID     string `json:"id"`
-	Symbol string `json:"symbol"`
+	ID     string     `json:"id"`
+	Symbol string     `json:"symbol"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Board  *int       `json:"board,omitempty"`
+	Layer  *int       `json:"layer,omitempty"`
+	Move   uint64     `json:"move"`
 }
 
 // RoomServer представляет комнату с пользователями и игровым состоянием.
+//
+// Поля, связанные с очередностью ходов:
+//   - FirstMovePolicy: правило выбора игрока, который ходит первым
+//   - ChooserID: игрок, который выбирает символ и ходит первым в текущей партии
+//   - Turn: символ игрока, который должен сделать следующий ход (в варианте wild
+//     символ игрока определяет только очередь, а не ставимый знак)
+//   - TurnOrder: символы игроков в порядке ходов текущей партии
+//   - LastFirstMoverID: игрок, ходивший первым в предыдущей партии
+//   - LastLoserID: проигравший предыдущей партии (nil при ничьей)
+//
+// Capacity ограничивает число игроков в комнате (от 2 до MAX_CAPACITY).
+// Width и Height задают прямоугольное поле (0 — квадратное поле BorderSize x BorderSize),
+// BlockedCells перечисляет клетки "i-j", в которые нельзя ходить.
+// Variant задаёт вариант правил, Rules — его набор правил из реестра (см. RuleSet),
+// VariantOptions — настройки варианта, сохранённые в комнате.
+// Gravity включает режим "гравитации", WinLength задаёт длину выигрышной линии
+// (0 — во всю длину поля).
+// PieceLimit ограничивает число фишек игрока на поле (0 — без ограничения),
+// MoveCount хранит номер последнего сделанного хода, History — все ходы текущей партии
+// (включая снятые с поля фишки) для сохранения партии после её окончания.
+// StartPosition — начальная позиция комнаты (nil — партия начинается с пустого поля):
+// её фишки ставятся на поле перед каждой партией, а первым ходит её ходящий.
+// IsRated отмечает рейтинговую комнату: результаты её партий меняют рейтинг игроков.
+// ActiveBoard для варианта Ultimate содержит подполе, в котором обязан быть сделан
+// следующий ход (nil — любое незавершённое подполе).
+// BotFill и BotAfter задают подсадку бота к одинокому игроку (см. RunBotScheduler):
+// LonelySince хранит, с какого момента игрок ждёт соперника, BotOffered — что бот ему уже предложен.
+// Mu сериализует действия в комнате: команды игроков, ходы и подсадку бота (см. lockRoom).
 type RoomServer struct {
-	ID         uint64            `json:"id"`
-	Users      []*ConnectedUser  `json:"users"`
-	Positions  []*SymbolPosition `json:"symbol_positions"`
-	BorderSize uint64            `json:"border_size"`
-	GameStatus string            `json:"game_status"`
+	ID               uint64                `json:"id"`
+	CreatorID        uuid.UUID             `json:"creator_id"`
+	Capacity         uint64                `json:"capacity"`
+	Users            []*ConnectedUser      `json:"users"`
+	Positions        []*SymbolPosition     `json:"symbol_positions"`
+	BorderSize       uint64                `json:"border_size"`
+	Width            uint64                `json:"width"`
+	Height           uint64                `json:"height"`
+	BlockedCells     []string              `json:"blocked_cells"`
+	GameStatus       string                `json:"game_status"`
+	Variant          string                `json:"variant"`
+	VariantOptions   json.RawMessage       `json:"variant_options,omitempty"`
+	Rules            RuleSet               `json:"-"`
+	Gravity          bool                  `json:"gravity"`
+	WinLength        uint64                `json:"win_length"`
+	PieceLimit       uint64                `json:"piece_limit"`
+	MoveCount        uint64                `json:"move_count"`
+	History          []*SymbolPosition     `json:"-"`
+	StartPosition    *common.BoardPosition `json:"-"`
+	IsRated          bool                  `json:"is_rated"`
+	ActiveBoard      *int                  `json:"active_board"`
+	FirstMovePolicy  string                `json:"first_move_policy"`
+	ChooserID        *uuid.UUID            `json:"chooser_id"`
+	Turn             string                `json:"turn"`
+	TurnOrder        []string              `json:"turn_order"`
+	LastFirstMoverID *uuid.UUID            `json:"-"`
+	LastLoserID      *uuid.UUID            `json:"-"`
+	BotFill          string                `json:"bot_fill"`
+	BotAfter         uint64                `json:"bot_after"`
+	LonelySince      time.Time             `json:"-"`
+	BotOffered       bool                  `json:"-"`
+	Mu               *sync.Mutex           `json:"-"`
 }
 
 // WSServer управляет всеми комнатами и обработкой WebSocket-соединений.
+// GameService сохраняет завершённые партии для последующего разбора,
+// TournamentService продвигает сетку турнира по итогам партий в комнатах матчей.
 type WSServer struct {
-	Rooms        map[uint64]*RoomServer `json:"rooms"`
-	ScoreService *ScoreService
-	Mu           sync.Mutex
+	Rooms             map[uint64]*RoomServer `json:"rooms"`
+	ScoreService      *ScoreService
+	GameService       *GameService
+	TournamentService *TournamentService
+	Mu                sync.Mutex
 }
 
 // GameRequest представляет входящее сообщение от клиента.
+// Variants передаётся при подключении и перечисляет варианты правил,
+// которые клиент умеет отображать помимо двумерных.
 type GameRequest struct {
 	Action     string      `json:"action,omitempty"`
 	Data       interface{} `json:"data,omitempty"`
 	Password   string      `json:"password,omitempty"`
 	BorderSize uint64      `json:"size,omitempty"`
 	Symbol     string      `json:"symbol,omitempty"`
+	Variants   []string    `json:"variants,omitempty"`
 }
 
 // GameReponse представляет ответ сервера клиенту.
@@ -98,23 +177,39 @@ type GameReponse struct {
 }
 
 // NewWsServer создаёт новый экземпляр WSServer.
-func NewWsServer(scoreService *ScoreService) *WSServer {
+func NewWsServer(scoreService *ScoreService, gameService *GameService, tournamentService *TournamentService) *WSServer {
 	return &WSServer{
-		Rooms:        make(map[uint64]*RoomServer),
-		ScoreService: scoreService,
+		Rooms:             make(map[uint64]*RoomServer),
+		ScoreService:      scoreService,
+		GameService:       gameService,
+		TournamentService: tournamentService,
 	}
 }
 
 // GameLoop обрабатывает основной цикл игры для пользователя.
+// Если комнату между партиями занимает бот, он уступает место подключающемуся человеку.
+// Если живую комнату не удалось создать (например, из-за испорченной начальной позиции),
+// клиент получает сообщение о закрытии соединения.
 func (ws *WSServer) GameLoop(
 	currentUser *common.User,
 	room *common.RoomSessionResponse,
 	conn *websocket.Conn,
 ) bool {
+	unlock := ws.lockRoom(room.ID)
+	ws.releaseBotSeat(currentUser.ID, room.ID)
+	unlock()
 	if ws.isRoomFull(currentUser.ID, room.ID, conn) {
 		return true
 	}
-	ws.addUser(currentUser, room, conn)
+	if err := ws.addUser(currentUser, room, conn); err != nil {
+		slog.Error("[wss]addUser", slog.Uint64("room_id", room.ID), slog.String("error", err.Error()))
+		conn.WriteMessage(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "invalid start position"),
+		)
+		conn.Close()
+		return true
+	}
 	_, p, err := conn.ReadMessage()
 	if err != nil {
 		slog.Error("ReadMessage error:", slog.String("error", err.Error()))
@@ -194,6 +289,8 @@ func (ws *WSServer) CloseConnection(roomID uint64, conn *websocket.Conn) {
 }
 
 // proccessCommand обрабатывает действия, отправленные клиентом.
+// После действия игрока ходит бот комнаты, если партия ждёт его хода (см. playBot).
+// Действие и ход бота выполняются под мьютексом комнаты.
 func (ws *WSServer) proccessCommand(
 	currentUser *common.User,
 	room *common.RoomSessionResponse,
@@ -201,9 +298,11 @@ func (ws *WSServer) proccessCommand(
 	message []byte,
 	conn *websocket.Conn,
 ) bool {
+	unlock := ws.lockRoom(room.ID)
+	defer unlock()
 	switch request.Action {
 	case stepAction:
-		ws.handleStep(room, &request)
+		ws.handleStep(currentUser.ID, room, &request)
 	case resetGameAction:
 		ws.handleResetGame(room)
 	case resizeAction:
@@ -240,6 +339,9 @@ func (ws *WSServer) proccessCommand(
 			&request,
 			conn,
 		)
+	case acceptBotAction:
+		ws.handleAcceptBot(currentUser.ID, room)
 	}
+	ws.playBot(room)
 	return false
 }
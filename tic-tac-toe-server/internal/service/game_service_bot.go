@@ -0,0 +1,521 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// Параметры бота, подсаживаемого к одинокому игроку.
+const (
+	// BOT_WAIT_MINUTES задаёт по умолчанию, сколько минут игрок ждёт соперника до подсадки бота.
+	BOT_WAIT_MINUTES = 3
+	// BOT_CHECK_INTERVAL задаёт, как часто проверяются комнаты с одиноким игроком.
+	BOT_CHECK_INTERVAL = 15 * time.Second
+	// BOT_MOVE_TIME_BUDGET задаёт время на поиск хода бота анализатором в миллисекундах.
+	BOT_MOVE_TIME_BUDGET = 200
+	// BOT_ACCURACY_SCALE задаёт, на сколько пунктов рейтинга доля лучших ходов бота меняется на 1.
+	BOT_ACCURACY_SCALE = 1000.0
+	// BOT_MIN_ACCURACY и BOT_MAX_ACCURACY ограничивают долю лучших ходов бота.
+	BOT_MIN_ACCURACY = 0.2
+	BOT_MAX_ACCURACY = 0.95
+)
+
+// Режимы подсадки бота к одинокому игроку.
+const (
+	offBotFill   = "off"
+	offerBotFill = "offer"
+	autoBotFill  = "auto"
+)
+
+// botUserID — идентификатор бота во всех комнатах (в результатах игроков он записывается соперником).
+var botUserID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("tic-tac-toe-game/bot"))
+
+// botCandidate описывает допустимый ход бота.
+//
+// Поля:
+//   - position: клетка, в которой окажется фишка (в режиме гравитации — клетка падения)
+//   - mark: поставленный знак
+//   - data: данные хода в том виде, в котором их присылает клиент
+type botCandidate struct {
+	position string
+	mark     string
+	data     map[string]interface{}
+}
+
+// RunBotScheduler подсаживает ботов к одиноким игрокам до отмены контекста
+//
+// Параметры:
+//   - ctx: контекст, при отмене которого проверка останавливается
+//
+// Особенности:
+//   - Комнаты проверяются каждые BOT_CHECK_INTERVAL (см. fillLonelyRooms)
+func (ws *WSServer) RunBotScheduler(ctx context.Context) {
+	ticker := time.NewTicker(BOT_CHECK_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ws.fillLonelyRooms(ctx, now)
+		}
+	}
+}
+
+// fillLonelyRooms подсаживает ботов в комнаты, где игрок слишком долго ждёт соперника
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - now: время проверки
+//
+// Логика:
+//  1. Для каждой комнаты с одиноким игроком (см. isLonelyRoom) запоминается,
+//     с какого момента он ждёт соперника; в остальных комнатах ожидание сбрасывается
+//  2. Когда ожидание дольше BotAfter минут, в режиме auto бот подсаживается сразу,
+//     а в режиме offer игроку один раз отправляется предложение "bot offer"
+//  3. Проверка, предложение и подсадка выполняются под мьютексом комнаты, как команды
+//     игроков, чтобы не читать состояние комнаты во время хода и бот не сходил
+//     одновременно с обработкой действия человека
+func (ws *WSServer) fillLonelyRooms(ctx context.Context, now time.Time) {
+	ws.Mu.Lock()
+	rooms := make([]*RoomServer, 0, len(ws.Rooms))
+	for _, currentRoom := range ws.Rooms {
+		rooms = append(rooms, currentRoom)
+	}
+	ws.Mu.Unlock()
+	for _, currentRoom := range rooms {
+		currentRoom.Mu.Lock()
+		ws.fillLonelyRoom(ctx, currentRoom, now)
+		currentRoom.Mu.Unlock()
+	}
+}
+
+// fillLonelyRoom проверяет одну комнату и, если игрок ждёт дольше BotAfter минут,
+// предлагает или подсаживает бота (см. fillLonelyRooms)
+//
+// Особенности:
+//   - Вызывается под мьютексом комнаты; игроки комнаты читаются под ws.Mu,
+//     потому что подключение и отключение игроков меняют их под ним
+func (ws *WSServer) fillLonelyRoom(ctx context.Context, currentRoom *RoomServer, now time.Time) {
+	ws.Mu.Lock()
+	if ws.Rooms[currentRoom.ID] != currentRoom || !isLonelyRoom(currentRoom) {
+		currentRoom.LonelySince = time.Time{}
+		currentRoom.BotOffered = false
+		ws.Mu.Unlock()
+		return
+	}
+	ws.Mu.Unlock()
+	if currentRoom.LonelySince.IsZero() {
+		currentRoom.LonelySince = now
+	}
+	if now.Sub(currentRoom.LonelySince) < time.Duration(currentRoom.BotAfter)*time.Minute {
+		return
+	}
+	switch currentRoom.BotFill {
+	case autoBotFill:
+		ws.addBot(ctx, currentRoom)
+	case offerBotFill:
+		if currentRoom.BotOffered {
+			return
+		}
+		currentRoom.BotOffered = true
+		ws.jsonToAll(&common.RoomSessionResponse{ID: currentRoom.ID}, &GameReponse{
+			Action: botOfferAction,
+			Data: map[string]interface{}{
+				"bot_after": currentRoom.BotAfter,
+			},
+		})
+	}
+}
+
+// isLonelyRoom проверяет, что в комнате на двух игроков с включённым ботом
+// между партиями остался один подключённый человек.
+func isLonelyRoom(currentRoom *RoomServer) bool {
+	if currentRoom.BotFill != offerBotFill && currentRoom.BotFill != autoBotFill {
+		return false
+	}
+	if roomCapacity(currentRoom) != DEFAULT_CAPACITY || len(currentRoom.Users) != 1 || currentRoom.GameStatus == inProcessStatus {
+		return false
+	}
+	player := currentRoom.Users[0]
+	return !player.IsBot && player.IsConnected
+}
+
+// handleAcceptBot обрабатывает согласие игрока сыграть с предложенным ботом
+//
+// Параметры:
+//   - currentUserID: ID игрока
+//   - room: игровая комната
+//
+// Особенности:
+//   - Бот подсаживается, только если он был предложен и игрок всё ещё ждёт соперника,
+//     иначе игроку отправляется ошибка
+func (ws *WSServer) handleAcceptBot(currentUserID uuid.UUID, room *common.RoomSessionResponse) {
+	currentRoom := ws.Rooms[room.ID]
+	if currentRoom == nil || !currentRoom.BotOffered || !isLonelyRoom(currentRoom) || currentRoom.Users[0].ID != currentUserID {
+		ws.sendError(currentUserID, room, "bot is not offered")
+		return
+	}
+	ws.addBot(context.Background(), currentRoom)
+}
+
+// addBot подсаживает бота к одинокому игроку
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - currentRoom: комната с одиноким игроком
+//
+// Действия:
+//  1. Берёт рейтинг игрока в пуле варианта и размера поля комнаты (см. ScoreService.PlayerRating),
+//     бот получает тот же рейтинг
+//  2. Если игрок всё ещё ждёт соперника, сажает бота в комнату тем же путём,
+//     что и подключающегося человека (см. seatUser)
+//  3. Сообщает о подключении бота так же, как о подключении человека (см. announceConnection),
+//     и, если нужно, делает ход за бота
+//
+// Особенности:
+//   - Вызывается под мьютексом комнаты (см. lockRoom)
+func (ws *WSServer) addBot(ctx context.Context, currentRoom *RoomServer) {
+	ws.Mu.Lock()
+	if !isLonelyRoom(currentRoom) {
+		ws.Mu.Unlock()
+		return
+	}
+	player := currentRoom.Users[0]
+	ws.Mu.Unlock()
+	rating, err := ws.ScoreService.PlayerRating(ctx, player.ID, currentRoom.Variant, boardSizeLabel(currentRoom))
+	if err != nil {
+		slog.Error(
+			"[wss]addBot",
+			slog.String("error", err.Error()),
+		)
+		rating = GLICKO_DEFAULT_RATING
+	}
+	bot := &ConnectedUser{
+		ID:          botUserID,
+		Name:        fmt.Sprintf("Bot (%.0f)", rating),
+		IsConnected: true,
+		IsBot:       true,
+		BotRating:   rating,
+	}
+	ws.Mu.Lock()
+	if ws.Rooms[currentRoom.ID] != currentRoom || !isLonelyRoom(currentRoom) || !ws.seatUser(currentRoom.ID, bot) {
+		ws.Mu.Unlock()
+		return
+	}
+	currentRoom.LonelySince = time.Time{}
+	currentRoom.BotOffered = false
+	ws.Mu.Unlock()
+	room := &common.RoomSessionResponse{ID: currentRoom.ID}
+	ws.announceConnection(bot.ID, room)
+	ws.playBot(room)
+}
+
+// releaseBotSeat освобождает место бота для подключающегося человека
+//
+// Параметры:
+//   - userID: ID подключающегося пользователя
+//   - roomID: ID комнаты
+//
+// Особенности:
+//   - Бот уходит только между партиями; во время партии комната остаётся заполненной
+//   - Оставшемуся игроку рассылается новый выбор символа и пустое поле, как после выхода соперника
+func (ws *WSServer) releaseBotSeat(userID uuid.UUID, roomID uint64) {
+	currentRoom := ws.Rooms[roomID]
+	if currentRoom == nil || currentRoom.GameStatus == inProcessStatus || ws.isUserInRoom(userID, roomID) {
+		return
+	}
+	bot := roomBot(currentRoom)
+	if bot == nil || len(currentRoom.Users) < roomCapacity(currentRoom) {
+		return
+	}
+	ws.removeUser(bot.ID, roomID)
+	ws.startNewGame(currentRoom)
+	currentRoom.LastFirstMoverID = nil
+	currentRoom.LastLoserID = nil
+	room := &common.RoomSessionResponse{ID: roomID}
+	if chooser := ws.symbolChooser(currentRoom); chooser != nil {
+		ws.jsonToAll(room, chooseSymbolResponse(currentRoom, chooser))
+	}
+	ws.jsonToAll(room, positionsResponse(currentRoom, ""))
+}
+
+// roomBot возвращает бота комнаты или nil, если бота в комнате нет.
+func roomBot(currentRoom *RoomServer) *ConnectedUser {
+	for _, user := range currentRoom.Users {
+		if user.IsBot {
+			return user
+		}
+	}
+	return nil
+}
+
+// humanPlayers возвращает число людей среди игроков комнаты.
+func humanPlayers(users []*ConnectedUser) int {
+	count := 0
+	for _, user := range users {
+		if !user.IsBot {
+			count++
+		}
+	}
+	return count
+}
+
+// playBot делает за бота действие, которого от него ждёт партия
+//
+// Параметры:
+//   - room: игровая комната
+//
+// Действия:
+//  1. Если символ выбирает бот, выбирает случайный символ комнаты
+//  2. Если сейчас ход бота, выбирает ход (см. botMove) и делает его так же,
+//     как ход игрока (см. handleStep)
+func (ws *WSServer) playBot(room *common.RoomSessionResponse) {
+	currentRoom := ws.Rooms[room.ID]
+	if currentRoom == nil {
+		return
+	}
+	bot := roomBot(currentRoom)
+	if bot == nil || len(currentRoom.Users) < roomCapacity(currentRoom) || currentRoom.GameStatus == gameEndStatus {
+		return
+	}
+	if len(currentRoom.TurnOrder) == 0 {
+		if chooser := ws.symbolChooser(currentRoom); chooser.ID != bot.ID {
+			return
+		}
+		symbols := roomSymbols(currentRoom)
+		ws.handleSelectSymbol(bot.ID, room, &GameRequest{
+			Action: selectSymbolAction,
+			Symbol: symbols[rand.IntN(len(symbols))],
+		})
+	}
+	if currentRoom.Turn == "" || currentRoom.Turn != bot.Symbol {
+		return
+	}
+	move := ws.botMove(currentRoom, bot)
+	if move == nil {
+		return
+	}
+	ws.handleStep(bot.ID, room, &GameRequest{
+		Action: stepAction,
+		Data:   move.data,
+	})
+}
+
+// botMove выбирает ход бота
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//   - bot: бот комнаты
+//
+// Возвращает:
+//   - *botCandidate: выбранный ход или nil, если ходить некуда
+//
+// Логика:
+//  1. С вероятностью botAccuracy бот ищет сильный ход, иначе ходит случайно
+//  2. В позициях, которые умеет разбирать анализатор (см. isSolverRoom), бот
+//     выбирает один из лучших ходов анализатора
+//  3. В остальных вариантах бот выигрывает одним ходом, если может, избегает ходов,
+//     которыми проигрывает сам, и закрывает клетку, которой соперник выиграл бы следующим ходом
+func (ws *WSServer) botMove(currentRoom *RoomServer, bot *ConnectedUser) *botCandidate {
+	candidates := botCandidates(currentRoom, bot)
+	if len(candidates) == 0 {
+		return nil
+	}
+	if rand.Float64() >= botAccuracy(bot.BotRating) {
+		return candidates[rand.IntN(len(candidates))]
+	}
+	if isSolverRoom(currentRoom) {
+		if move := ws.solverMove(currentRoom, bot, candidates); move != nil {
+			return move
+		}
+	}
+	return tacticalMove(currentRoom, bot, candidates)
+}
+
+// botAccuracy возвращает долю лучших ходов бота с указанным рейтингом:
+// 0.5 при GLICKO_DEFAULT_RATING, в пределах от BOT_MIN_ACCURACY до BOT_MAX_ACCURACY.
+func botAccuracy(rating float64) float64 {
+	accuracy := 0.5 + (rating-GLICKO_DEFAULT_RATING)/BOT_ACCURACY_SCALE
+	return min(max(accuracy, BOT_MIN_ACCURACY), BOT_MAX_ACCURACY)
+}
+
+// botCandidates перечисляет допустимые ходы бота
+//
+// Особенности:
+//   - Каждая клетка поля проверяется набором правил комнаты так же, как ход игрока,
+//     поэтому бот соблюдает правила любого варианта
+//   - Если бот может ставить не только свой символ (wild), проверяются оба знака
+//   - В режиме гравитации ходы, ведущие в одну клетку падения, считаются одним ходом
+func botCandidates(currentRoom *RoomServer, bot *ConnectedUser) []*botCandidate {
+	rules := roomRuleSet(currentRoom)
+	candidates := make([]*botCandidate, 0)
+	seen := make(map[string]bool)
+	for _, cell := range boardCells(currentRoom) {
+		for _, mark := range []string{bot.Symbol, opositeSymbol(bot.Symbol)} {
+			if mark == "" {
+				continue
+			}
+			data := map[string]interface{}{
+				"id":     cell,
+				"symbol": mark,
+			}
+			move := &Move{
+				UserID:     bot.ID,
+				PositionID: cell,
+				Symbol:     mark,
+				Data:       data,
+			}
+			if rules.LegalMove(currentRoom, move) != nil {
+				continue
+			}
+			key := move.PositionID + "/" + mark
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, &botCandidate{
+				position: move.PositionID,
+				mark:     mark,
+				data:     data,
+			})
+		}
+	}
+	return candidates
+}
+
+// boardCells перечисляет клетки поля комнаты ("l-i-j" для Qubic, "i-j" для остальных вариантов).
+func boardCells(currentRoom *RoomServer) []string {
+	cells := make([]string, 0)
+	if roomRuleSet(currentRoom).Name() == qubicVariant {
+		for layer := range QUBIC_BORDER_SIZE {
+			for row := range QUBIC_BORDER_SIZE {
+				for column := range QUBIC_BORDER_SIZE {
+					cells = append(cells, fmt.Sprintf("%d-%d-%d", layer, row, column))
+				}
+			}
+		}
+		return cells
+	}
+	for row := range boardHeight(currentRoom) {
+		for column := range boardWidth(currentRoom) {
+			cells = append(cells, fmt.Sprintf("%d-%d", row, column))
+		}
+	}
+	return cells
+}
+
+// isSolverRoom проверяет, что позицию комнаты умеет разбирать анализатор:
+// двое игроков на квадратном поле 3-15 без гравитации, лимита фишек
+// и заблокированных клеток по правилам classic или misere.
+func isSolverRoom(currentRoom *RoomServer) bool {
+	name := roomRuleSet(currentRoom).Name()
+	if name != classicVariant && name != misereVariant {
+		return false
+	}
+	size := boardWidth(currentRoom)
+	if size != boardHeight(currentRoom) || size < 3 || size > 15 {
+		return false
+	}
+	return !currentRoom.Gravity && currentRoom.PieceLimit == 0 && len(currentRoom.BlockedCells) == 0 && len(currentRoom.Users) == 2
+}
+
+// solverMove возвращает один из лучших ходов анализатора (nil, если анализ не удался).
+// На поиск отводится BOT_MOVE_TIME_BUDGET.
+func (ws *WSServer) solverMove(currentRoom *RoomServer, bot *ConnectedUser, candidates []*botCandidate) *botCandidate {
+	positions := make([]common.AnalysisPosition, 0, len(currentRoom.Positions))
+	for _, position := range currentRoom.Positions {
+		positions = append(positions, common.AnalysisPosition{
+			ID:     position.ID,
+			Symbol: position.Symbol,
+		})
+	}
+	analysis, err := ws.GameService.analysis.Analyze(context.Background(), common.AnalysisRequest{
+		BorderSize: uint8(boardWidth(currentRoom)),
+		WinLength:  uint8(winLength(currentRoom)),
+		Positions:  positions,
+		Turn:       bot.Symbol,
+		Variant:    roomRuleSet(currentRoom).Name(),
+		TimeBudget: BOT_MOVE_TIME_BUDGET,
+	})
+	if err != nil || len(analysis.BestMoves) == 0 {
+		return nil
+	}
+	best := analysis.BestMoves[rand.IntN(len(analysis.BestMoves))]
+	for _, candidate := range candidates {
+		if candidate.position == best && candidate.mark == bot.Symbol {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// tacticalMove выбирает ход бота на один ход вперёд
+//
+// Логика:
+//  1. Ход, которым бот выигрывает сразу
+//  2. Среди ходов, которыми бот не проигрывает сам (например, собрав линию в misere),
+//     ход в клетку, которой соперник выиграл бы следующим ходом
+//  3. Случайный из этих ходов (или из всех, если безопасных нет)
+func tacticalMove(currentRoom *RoomServer, bot *ConnectedUser, candidates []*botCandidate) *botCandidate {
+	safe := make([]*botCandidate, 0, len(candidates))
+	anyMark := false
+	for _, candidate := range candidates {
+		result := simulateMove(currentRoom, bot, candidate.position, candidate.mark, candidate.data)
+		if result.WinnerSymbol == bot.Symbol {
+			return candidate
+		}
+		if result.WinnerSymbol == "" {
+			safe = append(safe, candidate)
+		}
+		if candidate.mark != bot.Symbol {
+			anyMark = true
+		}
+	}
+	if len(safe) == 0 {
+		safe = candidates
+	}
+	for _, opponent := range currentRoom.Users {
+		if opponent.ID == bot.ID || opponent.Symbol == "" {
+			continue
+		}
+		marks := []string{opponent.Symbol}
+		if anyMark {
+			marks = []string{"X", "O"}
+		}
+		for _, candidate := range safe {
+			for _, mark := range marks {
+				if simulateMove(currentRoom, opponent, candidate.position, mark, candidate.data).WinnerSymbol == opponent.Symbol {
+					return candidate
+				}
+			}
+		}
+	}
+	return safe[rand.IntN(len(safe))]
+}
+
+// simulateMove применяет ход к копии комнаты и возвращает итог партии после него.
+// Состояние самой комнаты не меняется.
+func simulateMove(currentRoom *RoomServer, player *ConnectedUser, positionID, mark string, data map[string]interface{}) GameResult {
+	rules := roomRuleSet(currentRoom)
+	simulated := *currentRoom
+	simulated.Positions = slices.Clone(currentRoom.Positions)
+	simulated.History = slices.Clone(currentRoom.History)
+	rules.ApplyMove(&simulated, &Move{
+		UserID:     player.ID,
+		PositionID: positionID,
+		Symbol:     mark,
+		Data:       data,
+		Player:     player,
+	})
+	return rules.Result(&simulated)
+}
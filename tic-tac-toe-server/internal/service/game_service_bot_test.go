@@ -0,0 +1,216 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testBotRoom возвращает партию, в которой за O играет бот.
+func testBotRoom(variant string, size uint64) (*RoomServer, *ConnectedUser) {
+	room := testRoom(variant, size)
+	bot := room.Users[1]
+	bot.ID = botUserID
+	bot.IsBot = true
+	bot.BotRating = GLICKO_DEFAULT_RATING
+	room.Turn = bot.Symbol
+	return room, bot
+}
+
+func TestTacticalMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		x       []string
+		o       []string
+		want    string
+		// avoid — клетка, в которую бот ходить не должен (если want не задан)
+		avoid string
+	}{
+		{
+			name:    "win beats block",
+			variant: classicVariant,
+			x:       []string{"0-0", "0-1", "2-2"},
+			o:       []string{"1-0", "1-1"},
+			want:    "1-2",
+		},
+		{
+			name:    "block the opponent line",
+			variant: classicVariant,
+			x:       []string{"0-0", "0-1"},
+			o:       []string{"1-1"},
+			want:    "0-2",
+		},
+		{
+			name:    "misere avoids completing its own line",
+			variant: misereVariant,
+			x:       []string{"0-0", "2-2"},
+			o:       []string{"1-0", "1-1"},
+			avoid:   "1-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, bot := testBotRoom(tt.variant, 3)
+			testPlace(room, "X", tt.x...)
+			testPlace(room, "O", tt.o...)
+			candidates := botCandidates(room, bot)
+			// Выбор среди равноценных ходов случаен, поэтому проверяется несколько раз
+			for range 20 {
+				move := tacticalMove(room, bot, candidates)
+				if tt.want != "" && move.position != tt.want {
+					t.Fatalf("tacticalMove() = %s, want %s", move.position, tt.want)
+				}
+				if move.position == tt.avoid {
+					t.Fatalf("tacticalMove() = %s, the move loses", move.position)
+				}
+			}
+		})
+	}
+}
+
+func TestBotCandidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		size    uint64
+		gravity bool
+		blocked []string
+		taken   string
+		want    int
+	}{
+		{name: "free cells", variant: classicVariant, size: 3, blocked: []string{"2-2"}, taken: "0-0", want: 7},
+		{name: "wild plays both marks", variant: wildVariant, size: 3, taken: "0-0", want: 16},
+		{name: "gravity counts a column once", variant: classicVariant, size: 4, gravity: true, taken: "3-0", want: 4},
+		{name: "qubic cube", variant: qubicVariant, size: QUBIC_BORDER_SIZE, taken: "0-0-0", want: 63},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, bot := testBotRoom(tt.variant, tt.size)
+			room.Gravity = tt.gravity
+			room.BlockedCells = tt.blocked
+			testPlace(room, "X", tt.taken)
+			candidates := botCandidates(room, bot)
+			if len(candidates) != tt.want {
+				t.Fatalf("botCandidates() = %d moves, want %d", len(candidates), tt.want)
+			}
+			for _, candidate := range candidates {
+				move := &Move{UserID: bot.ID, PositionID: candidate.position, Symbol: candidate.mark, Data: candidate.data}
+				if err := room.Rules.LegalMove(room, move); err != nil {
+					t.Errorf("botCandidates() move %s is illegal: %v", candidate.position, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBotMove(t *testing.T) {
+	ws := NewWsServer(nil, nil, nil)
+	room, bot := testBotRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+	room.Turn = "X"
+	testMove(t, room, "X", "X", "4-4")
+	move := ws.botMove(room, bot)
+	if move == nil {
+		t.Fatalf("botMove() = nil, want a move")
+	}
+	if row, column, _ := parsePositionID(move.position); ultimateSubBoard(row, column) != 4 {
+		t.Errorf("botMove() = %s, want a move in the active board", move.position)
+	}
+
+	full, fullBot := testBotRoom(classicVariant, 3)
+	testPlace(full, "X", "0-0", "0-2", "1-0", "2-1", "2-2")
+	testPlace(full, "O", "0-1", "1-1", "1-2", "2-0")
+	if move := ws.botMove(full, fullBot); move != nil {
+		t.Errorf("botMove() on a full board = %s, want nil", move.position)
+	}
+}
+
+func TestBotAccuracy(t *testing.T) {
+	tests := []struct {
+		rating float64
+		want   float64
+	}{
+		{rating: GLICKO_DEFAULT_RATING, want: 0.5},
+		{rating: GLICKO_DEFAULT_RATING + 200, want: 0.7},
+		{rating: 3000, want: BOT_MAX_ACCURACY},
+		{rating: 0, want: BOT_MIN_ACCURACY},
+	}
+	for _, tt := range tests {
+		if got := botAccuracy(tt.rating); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("botAccuracy(%v) = %v, want %v", tt.rating, got, tt.want)
+		}
+	}
+}
+
+// Проверка хода на копии комнаты не должна менять поле, очередь и подполе.
+func TestSimulateMove(t *testing.T) {
+	room, bot := testBotRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+	room.Turn = "X"
+	testMove(t, room, "X", "X", "4-4")
+	positions := slices.Clone(room.Positions)
+	activeBoard := *room.ActiveBoard
+	moveCount := room.MoveCount
+	simulateMove(room, bot, "3-3", bot.Symbol, map[string]interface{}{"id": "3-3", "symbol": bot.Symbol})
+	if !slices.Equal(room.Positions, positions) || room.MoveCount != moveCount {
+		t.Errorf("simulateMove() changed the board: %d pieces, move %d", len(room.Positions), room.MoveCount)
+	}
+	if room.Turn != bot.Symbol || room.ActiveBoard == nil || *room.ActiveBoard != activeBoard {
+		t.Errorf("simulateMove() changed the turn or active board: %q, %v", room.Turn, room.ActiveBoard)
+	}
+
+	win, winBot := testBotRoom(classicVariant, 3)
+	testPlace(win, "O", "1-0", "1-1")
+	want := GameResult{Finished: true, WinnerSymbol: "O", LineSymbol: "O"}
+	if got := simulateMove(win, winBot, "1-2", "O", nil); got != want {
+		t.Errorf("simulateMove() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReleaseBotSeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		joining  func(room *RoomServer) uuid.UUID
+		released bool
+	}{
+		{
+			name:     "between games",
+			status:   gameEndStatus,
+			joining:  func(*RoomServer) uuid.UUID { return uuid.New() },
+			released: true,
+		},
+		{
+			name:    "during a game",
+			status:  inProcessStatus,
+			joining: func(*RoomServer) uuid.UUID { return uuid.New() },
+		},
+		{
+			name:    "player reconnects",
+			status:  gameEndStatus,
+			joining: func(room *RoomServer) uuid.UUID { return room.Users[0].ID },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, _ := testBotRoom(classicVariant, 3)
+			testPlace(room, "X", "0-0")
+			room.GameStatus = tt.status
+			ws := NewWsServer(nil, nil, nil)
+			ws.Rooms[room.ID] = room
+			ws.releaseBotSeat(tt.joining(room), room.ID)
+			if released := roomBot(room) == nil; released != tt.released {
+				t.Fatalf("releaseBotSeat() released = %v, want %v", released, tt.released)
+			}
+			if !tt.released {
+				return
+			}
+			if len(room.Users) != 1 || room.GameStatus != chooseSymbolStatus || len(room.Positions) != 0 {
+				t.Errorf("releaseBotSeat() left %d players, status %q, %d pieces", len(room.Users), room.GameStatus, len(room.Positions))
+			}
+			if room.Users[0].Symbol != "" || room.TurnOrder != nil {
+				t.Errorf("releaseBotSeat() kept symbol %q and turn order %v", room.Users[0].Symbol, room.TurnOrder)
+			}
+		})
+	}
+}
@@ -24,6 +24,10 @@ const (
 	closeRoomAction           = "close room"
 	exitRoomAction            = "exit room"
 	newConnectionToRoomAction = "new connection to room"
+	errorAction               = "error"
+	removePositionAction      = "remove position"
+	botOfferAction            = "bot offer"
+	acceptBotAction           = "accept bot"
 )
 
 // game statuses
@@ -32,3 +36,51 @@ const (
 	inProcessStatus    = "in process"
 	gameEndStatus      = "game end"
 )
+
+// Результаты игрока в партии.
+const (
+	winResult     = "win"
+	lossResult    = "loss"
+	drawResult    = "draw"
+	forfeitResult = "forfeit"
+	// timeoutResult — поражение по времени (зарезервирован до появления контроля времени).
+	timeoutResult = "timeout"
+	// abandonedResult — партия брошена без итога, не влияет на рейтинг и статистику.
+	abandonedResult = "abandoned"
+)
+
+// Причины завершения партии.
+const (
+	lineTermination      = "line"
+	boardFullTermination = "board_full"
+	exitTermination      = "exit"
+	// timeoutTermination — у игрока кончилось время (зарезервирована до появления контроля времени).
+	timeoutTermination = "timeout"
+	closeTermination   = "room_closed"
+	resetTermination   = "reset"
+)
+
+// Правила выбора игрока, который выбирает символ и ходит первым.
+const (
+	creatorFirstMovePolicy   = "creator"
+	randomFirstMovePolicy    = "random"
+	alternateFirstMovePolicy = "alternate"
+	loserFirstMovePolicy     = "loser"
+)
+
+// Варианты правил игры.
+const (
+	classicVariant = "classic"
+	misereVariant  = "misere"
+	// ultimateVariant — поле 3x3 из подполей 3x3, клетка хода задаёт подполе соперника.
+	ultimateVariant = "ultimate"
+	// qubicVariant — трёхмерное поле 4x4x4, клетки задаются в формате "l-i-j".
+	// Клиент должен явно заявить поддержку варианта при подключении.
+	qubicVariant = "qubic"
+	// rollingVariant — у каждого игрока на поле не больше PieceLimit фишек,
+	// при превышении лимита самая старая фишка снимается.
+	rollingVariant = "rolling"
+	// wildVariant — на каждом ходу игрок сам выбирает, ставить X или O;
+	// победа за тем, кто своим ходом собрал линию любого символа.
+	wildVariant = "wild"
+)
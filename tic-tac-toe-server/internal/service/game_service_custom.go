@@ -0,0 +1,114 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// variantDirections сопоставляет названия направлений из описания варианта с векторами lineDirections.
+var variantDirections = map[string][2]int{
+	"horizontal":    {0, 1},
+	"vertical":      {1, 0},
+	"diagonal":      {1, 1},
+	"anti_diagonal": {1, -1},
+}
+
+// customRuleSet реализует пользовательский вариант, заданный описанием VariantSpec.
+// Проверка и применение ходов, подсчёт итога и очередь берутся из gridRuleSet,
+// а параметры поля при каждой новой партии переносятся в комнату из описания.
+type customRuleSet struct {
+	gridRuleSet
+	spec common.VariantSpec
+}
+
+// newCustomRuleSet создаёт набор правил по проверенному описанию варианта.
+func newCustomRuleSet(spec common.VariantSpec) *customRuleSet {
+	var directions [][2]int
+	for _, name := range spec.Directions {
+		directions = append(directions, variantDirections[name])
+	}
+	return &customRuleSet{
+		gridRuleSet: gridRuleSet{
+			name:        spec.Name,
+			misere:      spec.Misere,
+			multiplayer: !spec.Misere,
+			directions:  directions,
+		},
+		spec: spec,
+	}
+}
+
+// Settings возвращает свойства варианта: размер поля задан описанием и не меняется в комнате.
+func (rules *customRuleSet) Settings() RuleSetSettings {
+	return RuleSetSettings{
+		BorderSize:  uint64(max(rules.spec.Width, rules.spec.Height)),
+		Multiplayer: rules.multiplayer,
+	}
+}
+
+// InitialBoard очищает поле и переносит в комнату размеры поля, заблокированные клетки,
+// длину линии, гравитацию, лимит фишек и правило первого хода из описания варианта.
+func (rules *customRuleSet) InitialBoard(currentRoom *RoomServer) {
+	clearBoard(currentRoom)
+	currentRoom.Width = uint64(rules.spec.Width)
+	currentRoom.Height = uint64(rules.spec.Height)
+	currentRoom.BorderSize = uint64(max(rules.spec.Width, rules.spec.Height))
+	currentRoom.BlockedCells = rules.spec.BlockedCells
+	currentRoom.WinLength = uint64(rules.spec.WinLength)
+	currentRoom.Gravity = rules.spec.Gravity
+	currentRoom.PieceLimit = uint64(rules.spec.PieceLimit)
+	if rules.spec.FirstMovePolicy != "" {
+		currentRoom.FirstMovePolicy = rules.spec.FirstMovePolicy
+	}
+}
+
+// validateVariantSpec проверяет описание варианта сверх ограничений тегов validate
+//
+// Параметры:
+//   - spec: описание варианта
+//
+// Возвращает:
+//   - error: если идентификатор содержит недопустимые символы или занят встроенным
+//     вариантом, направления повторяются, линия заданной длины не помещается на поле
+//     ни в одном направлении или заблокированные клетки не помещаются на поле
+func validateVariantSpec(spec common.VariantSpec) error {
+	for _, char := range spec.Name {
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' && char != '_' {
+			return errors.New("name may contain only a-z, 0-9, \"-\" and \"_\"")
+		}
+	}
+	if rules, ok := LookupRuleSet(spec.Name); ok {
+		if _, custom := rules.(*customRuleSet); !custom {
+			return fmt.Errorf("name %q is reserved by a built-in variant", spec.Name)
+		}
+	}
+	names := spec.Directions
+	if len(names) == 0 {
+		names = []string{"horizontal", "vertical", "diagonal", "anti_diagonal"}
+	}
+	seen := make(map[string]bool, len(names))
+	fits := false
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("direction %q is duplicated", name)
+		}
+		seen[name] = true
+		reach := min(spec.Width, spec.Height)
+		switch name {
+		case "horizontal":
+			reach = spec.Width
+		case "vertical":
+			reach = spec.Height
+		}
+		if spec.WinLength <= reach {
+			fits = true
+		}
+	}
+	if !fits {
+		return errors.New("a line of win_length does not fit the board in any allowed direction")
+	}
+	return validateBlockedCells(spec.BlockedCells, int(spec.Height), int(spec.Width))
+}
@@ -0,0 +1,60 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// resolveGravityPosition превращает выбранный столбец в клетку, куда упадёт фишка
+//
+// Параметры:
+//   - currentRoom: игровая комната в режиме гравитации
+//   - rawSymbolPosition: данные хода; столбец берётся из поля "column",
+//     а если его нет — из столбца в поле "id" ("i-j", строка игнорируется)
+//
+// Возвращает:
+//   - string: итоговая клетка в формате "i-j" (клетка, на которой остановится падающая сверху фишка)
+//   - error: если столбец не указан, вне поля или уже заполнен
+//
+// Особенности:
+//   - Заблокированная клетка работает как дно: фишка останавливается над ней
+func resolveGravityPosition(currentRoom *RoomServer, rawSymbolPosition map[string]interface{}) (string, error) {
+	column := -1
+	if rawColumn, ok := rawSymbolPosition["column"].(float64); ok {
+		column = int(rawColumn)
+	} else if id, ok := rawSymbolPosition["id"].(string); ok {
+		if _, idColumn, ok := parsePositionID(id); ok {
+			column = idColumn
+		}
+	}
+	if column < 0 || column >= boardWidth(currentRoom) {
+		return "", errors.New("column is out of board")
+	}
+	board := buildRoomBoard(currentRoom)
+	landing := ""
+	for row := 0; row < len(board) && board[row][column] == ""; row++ {
+		landing = fmt.Sprintf("%d-%d", row, column)
+	}
+	if landing == "" {
+		return "", errors.New("column is full")
+	}
+	return landing, nil
+}
+
+// winLength возвращает длину выигрышной линии для комнаты.
+// Если длина не задана, линия должна занимать меньшую сторону поля;
+// если длина больше большей стороны, линия должна занимать большую сторону.
+func winLength(currentRoom *RoomServer) int {
+	shortSide, longSide := boardHeight(currentRoom), boardWidth(currentRoom)
+	if shortSide > longSide {
+		shortSide, longSide = longSide, shortSide
+	}
+	if currentRoom.WinLength == 0 {
+		return shortSide
+	}
+	if int(currentRoom.WinLength) > longSide {
+		return longSide
+	}
+	return int(currentRoom.WinLength)
+}
@@ -0,0 +1,56 @@
+package service
+
+import "testing"
+
+func TestResolveGravityPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		taken   []string
+		blocked []string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty column", data: map[string]interface{}{"column": 1.0}, want: "3-1"},
+		{name: "piece lands on top", data: map[string]interface{}{"column": 1.0}, taken: []string{"3-1", "2-1"}, want: "1-1"},
+		{name: "column from cell id", data: map[string]interface{}{"id": "0-2"}, want: "3-2"},
+		{name: "blocked cell is the floor", data: map[string]interface{}{"column": 3.0}, blocked: []string{"3-3"}, want: "2-3"},
+		{name: "full column", data: map[string]interface{}{"column": 0.0}, taken: []string{"0-0", "1-0", "2-0", "3-0"}, wantErr: true},
+		{name: "column out of board", data: map[string]interface{}{"column": 4.0}, wantErr: true},
+		{name: "no column", data: map[string]interface{}{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(classicVariant, 4)
+			room.Gravity = true
+			room.BlockedCells = tt.blocked
+			testPlace(room, "X", tt.taken...)
+			got, err := resolveGravityPosition(room, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveGravityPosition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveGravityPosition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// В режиме гравитации набор правил заменяет клетку хода клеткой, куда упадёт фишка.
+func TestGravityLegalMove(t *testing.T) {
+	room := testRoom(classicVariant, 4)
+	room.Gravity = true
+	testPlace(room, "O", "3-2")
+	move := &Move{
+		UserID:     room.Users[0].ID,
+		PositionID: "0-2",
+		Symbol:     "X",
+		Data:       map[string]interface{}{"column": 2.0},
+	}
+	if err := room.Rules.LegalMove(room, move); err != nil {
+		t.Fatalf("LegalMove() error = %v", err)
+	}
+	if move.PositionID != "2-2" {
+		t.Errorf("LegalMove() position = %q, want 2-2", move.PositionID)
+	}
+}
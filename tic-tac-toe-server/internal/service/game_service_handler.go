@@ -15,32 +15,55 @@ import (
 // handleStep обрабатывает ход игрока
 //
 // Параметры:
+//   - currentUserID: ID игрока, сделавшего ход
 //   - room: текущая игровая комната
 //   - request: запрос с данными хода
 //
 // Действия:
 //  1. Парсит данные о позиции и символе
-//  2. Обновляет состояние комнаты
-//  3. Рассылает обновленные позиции всем игрокам
-//  4. Устанавливает следующий ход для противоположного символа
-func (ws *WSServer) handleStep(room *common.RoomSessionResponse, request *GameRequest) {
+//  2. Передаёт ход набору правил комнаты на проверку (очередь, знак, клетка)
+//  3. Применяет ход; о снятых с поля фишках рассылает событие "remove position"
+//  4. Рассылает обновленные позиции всем игрокам
+//  5. Определяет итог партии по правилам комнаты и при завершении фиксирует результат
+func (ws *WSServer) handleStep(
+	currentUserID uuid.UUID,
+	room *common.RoomSessionResponse,
+	request *GameRequest,
+) {
 	rawSymbolPosition, ok := request.Data.(map[string]interface{})
-	if ok {
-		currentRoom := ws.Rooms[room.ID]
-		symbolPosition := &SymbolPosition{
-			ID:     rawSymbolPosition["id"].(string),
-			Symbol: rawSymbolPosition["symbol"].(string),
-		}
-		currentRoom.GameStatus = inProcessStatus
-		currentRoom.Positions = append(currentRoom.Positions, symbolPosition)
+	if !ok {
+		return
+	}
+	symbol, ok := rawSymbolPosition["symbol"].(string)
+	if !ok {
+		return
+	}
+	currentRoom := ws.Rooms[room.ID]
+	rules := roomRuleSet(currentRoom)
+	id, _ := rawSymbolPosition["id"].(string)
+	move := &Move{
+		UserID:     currentUserID,
+		PositionID: id,
+		Symbol:     symbol,
+		Data:       rawSymbolPosition,
+	}
+	if err := rules.LegalMove(currentRoom, move); err != nil {
+		ws.sendError(currentUserID, room, err.Error())
+		return
+	}
+	for _, removed := range rules.ApplyMove(currentRoom, move) {
 		ws.jsonToAll(room, &GameReponse{
-			Action: getPositionsAction,
+			Action: removePositionAction,
 			Data: map[string]interface{}{
-				"positions": currentRoom.Positions,
+				"position": removed,
 			},
-			Symbol: opositeSymbol(symbolPosition.Symbol),
+			Symbol: move.Player.Symbol,
 		})
 	}
+	ws.jsonToAll(room, positionsResponse(currentRoom, currentRoom.Turn))
+	if result := rules.Result(currentRoom); result.Finished {
+		ws.finishGame(room, currentRoom, result)
+	}
 }
 
 // handleResetGame сбрасывает состояние игры в комнате
@@ -49,14 +72,21 @@ func (ws *WSServer) handleStep(room *common.RoomSessionResponse, request *GameRe
 //   - room: текущая игровая комната
 //
 // Действия:
-//  1. Очищает все сделанные ходы
-//  2. Уведомляет всех игроков о сбросе
+//  1. Если игра шла, записывает игрокам брошенную партию (см. abandonGame)
+//  2. Очищает все сделанные ходы и символы игроков
+//  3. Уведомляет всех игроков о сбросе
+//  4. Определяет по правилу комнаты, кто выбирает символ и ходит первым
 func (ws *WSServer) handleResetGame(room *common.RoomSessionResponse) {
-	ws.Rooms[room.ID].Positions = make([]*SymbolPosition, 0)
+	currentRoom := ws.Rooms[room.ID]
+	ws.abandonGame(currentRoom, resetTermination)
+	ws.startNewGame(currentRoom)
 	response := &GameReponse{
 		Action: resetGameAction,
 	}
 	ws.jsonToAll(room, response)
+	if chooser := ws.symbolChooser(currentRoom); chooser != nil {
+		ws.jsonToAll(room, chooseSymbolResponse(currentRoom, chooser))
+	}
 }
 
 // handleBorderResize обрабатывает изменение размера игрового поля
@@ -69,6 +99,9 @@ func (ws *WSServer) handleResetGame(room *common.RoomSessionResponse) {
 //
 // Особенности:
 //   - Доступно только создателю комнаты
+//   - Недоступно для вариантов с фиксированным размером поля (Ultimate, Qubic)
+//     и для комнат с начальной позицией
+//   - Делает поле квадратным; заблокированные клетки за пределами нового поля не учитываются
 //   - Рассылает изменение другим игрокам
 func (ws *WSServer) handleBorderResize(
 	currentUserID uuid.UUID,
@@ -76,8 +109,18 @@ func (ws *WSServer) handleBorderResize(
 	request *GameRequest,
 	message []byte,
 ) {
+	if roomRuleSet(ws.Rooms[room.ID]).Settings().BorderSize > 0 {
+		ws.sendError(currentUserID, room, "board size is fixed for this variant")
+		return
+	}
+	if ws.Rooms[room.ID].StartPosition != nil {
+		ws.sendError(currentUserID, room, "board size is fixed by the start position")
+		return
+	}
 	if currentUserID == room.CreatorID {
 		ws.Rooms[room.ID].BorderSize = request.BorderSize
+		ws.Rooms[room.ID].Width = 0
+		ws.Rooms[room.ID].Height = 0
 		ws.broadcastMessageToOther(currentUserID, room, message)
 	}
 }
@@ -90,29 +133,39 @@ func (ws *WSServer) handleBorderResize(
 //   - request: запрос с выбранным символом
 //
 // Действия:
-//  1. Назначает символы игрокам (X/O)
-//  2. Уведомляет другого игрока о выборе
+//  1. Проверяет, что комната заполнена, символ выбирает игрок,
+//     назначенный правилом первого хода, и символ доступен в комнате
+//  2. Назначает символы всем игрокам и очередь ходов (см. assignSymbols)
+//  3. Передаёт первый ход выбранному символу (ходящему начальной позиции, если она задана)
+//  4. Уведомляет остальных игроков об их символах и очереди ходов
 func (ws *WSServer) handleSelectSymbol(
 	currentUserID uuid.UUID,
 	room *common.RoomSessionResponse,
 	request *GameRequest,
 ) {
 	currentRoom := ws.Rooms[room.ID]
-	for id, user := range currentRoom.Users {
-		if user.Symbol != "" {
-			continue
-		}
+	chooser := ws.symbolChooser(currentRoom)
+	if currentRoom.ChooserID == nil || chooser.ID != currentUserID || !isRoomSymbol(currentRoom, request.Symbol) {
+		ws.sendError(currentUserID, room, "you can not choose symbol now")
+		return
+	}
+	currentRoom.Turn = request.Symbol
+	if currentRoom.StartPosition != nil {
+		currentRoom.Turn = currentRoom.StartPosition.Turn
+	}
+	assignSymbols(currentRoom, chooser, request.Symbol)
+	for _, user := range currentRoom.Users {
 		if user.ID == currentUserID {
-			currentRoom.Users[id].Symbol = request.Symbol
-		} else {
-			currentRoom.Users[id].Symbol = opositeSymbol(request.Symbol)
+			continue
 		}
+		ws.jsonToUser(user.ID, room, &GameReponse{
+			Action: selectedSymbolAction,
+			Data: map[string]interface{}{
+				"turn_order": currentRoom.TurnOrder,
+			},
+			Symbol: user.Symbol,
+		})
 	}
-	response := &GameReponse{
-		Action: selectedSymbolAction,
-		Symbol: opositeSymbol(request.Symbol),
-	}
-	ws.jsonToOther(currentUserID, room, response)
 }
 
 // handleNewConnection обрабатывает новое подключение к комнате
@@ -125,9 +178,9 @@ func (ws *WSServer) handleSelectSymbol(
 //
 // Действия:
 //  1. Проверяет пароль для приватных комнат
-//  2. Инициализирует состояние комнаты
-//  3. Рассылает текущее состояние новому игроку
-//  4. Устанавливает символы игрокам
+//  2. Проверяет, что клиент поддерживает вариант правил комнаты,
+//     иначе отправляет ошибку "unsupported variant" и закрывает соединение
+//  3. Сообщает игрокам о новом участнике и состоянии комнаты (см. announceConnection)
 func (ws *WSServer) handleNewConnection(
 	currentUserID uuid.UUID,
 	room *common.RoomSessionResponse,
@@ -145,31 +198,67 @@ func (ws *WSServer) handleNewConnection(
 			ws.CloseConnection(room.ID, conn)
 		}
 	}
+	if !isVariantSupported(roomRuleSet(currentRoom), request.Variants) {
+		ws.jsonToUser(currentUserID, room, &GameReponse{
+			Action: errorAction,
+			Data: map[string]interface{}{
+				"message": "unsupported variant",
+				"variant": currentRoom.Variant,
+			},
+		})
+		conn.WriteMessage(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseUnsupportedData, "unsupported variant"),
+		)
+		ws.removeUser(currentUserID, room.ID)
+		conn.Close()
+		return
+	}
+	ws.announceConnection(currentUserID, room)
+}
+
+// announceConnection сообщает игрокам о новом участнике комнаты
+//
+// Параметры:
+//   - currentUserID: ID подключившегося игрока (человека или бота)
+//   - room: игровая комната
+//
+// Действия:
+//  1. Сообщает размер поля (ширину, высоту и заблокированные клетки), вместимость,
+//     вариант правил, режим гравитации, длину линии и лимит фишек
+//  2. Переводит комнату к выбору символа и сообщает, кто по правилу комнаты
+//     выбирает символ и ходит первым
+//  3. Рассылает текущее состояние
+//  4. Назначает символы игрокам, которые их ещё не получили
+func (ws *WSServer) announceConnection(currentUserID uuid.UUID, room *common.RoomSessionResponse) {
+	currentRoom := ws.Rooms[room.ID]
 	ws.jsonToAll(room, &GameReponse{
 		Action: newConnectionToRoomAction,
 		UserID: &currentUserID,
 	})
 	ws.jsonToAll(room, &GameReponse{
-		Action:      resizeAction,
+		Action: resizeAction,
+		Data: map[string]interface{}{
+			"capacity":      roomCapacity(currentRoom),
+			"width":         boardWidth(currentRoom),
+			"height":        boardHeight(currentRoom),
+			"blocked_cells": currentRoom.BlockedCells,
+			"variant":       currentRoom.Variant,
+			"gravity":       currentRoom.Gravity,
+			"win_length":    winLength(currentRoom),
+			"piece_limit":   currentRoom.PieceLimit,
+		},
 		BoarderSize: currentRoom.BorderSize,
 	})
 	currentRoom.GameStatus = chooseSymbolStatus
-	ws.jsonToAll(room, &GameReponse{
-		Action: chooseSymbolAction,
-		UserID: &currentRoom.Users[0].ID,
-	})
-	currentPlayerStep := currentRoom.Users[0].Symbol
-	if len(currentRoom.Positions) != 0 {
-		currentPlayerStep = opositeSymbol(currentRoom.Positions[len(currentRoom.Positions)-1].Symbol)
+	chooser := ws.symbolChooser(currentRoom)
+	ws.jsonToAll(room, chooseSymbolResponse(currentRoom, chooser))
+	currentPlayerStep := chooser.Symbol
+	if currentRoom.Turn != "" {
+		currentPlayerStep = currentRoom.Turn
 	}
-	ws.jsonToAll(room, &GameReponse{
-		Action: getPositionsAction,
-		Data: map[string]interface{}{
-			"positions": currentRoom.Positions,
-		},
-		Symbol: currentPlayerStep,
-	})
-	ws.setSecondUserSymbol(room.ID)
+	ws.jsonToAll(room, positionsResponse(currentRoom, currentPlayerStep))
+	ws.setMissingSymbols(room.ID)
 }
 
 // handleExitRoom обрабатывает выход игрока из комнаты
@@ -183,9 +272,14 @@ func (ws *WSServer) handleNewConnection(
 //   - bool: true если обработка завершена
 //
 // Действия:
-//  1. Фиксирует результат игры (если игра шла)
-//  2. Уведомляет оставшегося игрока
-//  3. Сбрасывает состояние комнаты
+//  1. Если игра шла, фиксирует выходящему сдачу (forfeit, последнее место);
+//     если в комнате остаётся один игрок, ему засчитывается победа,
+//     иначе оставшимся игрокам записывается брошенная партия (abandoned).
+//     В рейтинговой комнате выход меняет рейтинг выходящего и единственного
+//     оставшегося игрока (см. ScoreService.RecordResult), а в комнате турнирного
+//     матча победа оставшегося игрока решает матч
+//  2. Уведомляет оставшихся игроков
+//  3. Сбрасывает состояние комнаты; если в ней остался только бот, он тоже уходит
 //  4. Закрывает соединение
 func (ws *WSServer) handleExitRoom(
 	currentUser *common.User,
@@ -198,46 +292,83 @@ func (ws *WSServer) handleExitRoom(
 	)
 
 	currentRoom := ws.Rooms[room.ID]
-	var versusPlayer *ConnectedUser
+	remainingPlayers := make([]*ConnectedUser, 0, len(currentRoom.Users))
+	leaverSymbol := ""
 	for _, user := range currentRoom.Users {
 		if currentUser.ID != user.ID {
-			versusPlayer = user
-			break
+			remainingPlayers = append(remainingPlayers, user)
+		} else {
+			leaverSymbol = user.Symbol
 		}
 	}
 
-	if versusPlayer != nil {
+	if len(remainingPlayers) > 0 {
 		if currentRoom.GameStatus == inProcessStatus {
-			ws.ScoreService.scoreRepo.Create(context.Background(), &common.Score{
-				IsWon:    1,
-				UserID:   versusPlayer.ID.String(),
-				Nickname: currentUser.Name,
-			})
-			ws.ScoreService.scoreRepo.Create(context.Background(), &common.Score{
-				IsWon:    0,
-				UserID:   currentUser.ID.String(),
-				Nickname: versusPlayer.Name,
-			})
+			players := uint8(len(currentRoom.Users))
+			placements := map[uuid.UUID]uint8{currentUser.ID: players}
+			for _, user := range remainingPlayers {
+				placements[user.ID] = 1
+			}
+			scores := []*common.Score{{
+				Result:      forfeitResult,
+				Termination: exitTermination,
+				RoomID:      &currentRoom.ID,
+				UserID:      currentUser.ID.String(),
+				Nickname:    playerNames(remainingPlayers),
+				OpponentIDs: playerIDs(remainingPlayers),
+				Variant:     currentRoom.Variant,
+				BoardSize:   boardSizeLabel(currentRoom),
+				Placement:   players,
+				Players:     players,
+				Symbol:      leaverSymbol,
+				Moves:       uint(currentRoom.MoveCount),
+			}}
+			result := winResult
+			if len(remainingPlayers) > 1 {
+				result = abandonedResult
+			}
+			for _, user := range remainingPlayers {
+				versusPlayers := []*ConnectedUser{{ID: currentUser.ID, Name: currentUser.Name}}
+				for _, versus := range remainingPlayers {
+					if versus.ID != user.ID {
+						versusPlayers = append(versusPlayers, versus)
+					}
+				}
+				scores = append(scores, &common.Score{
+					Result:      result,
+					Termination: exitTermination,
+					RoomID:      &currentRoom.ID,
+					UserID:      user.ID.String(),
+					Nickname:    playerNames(versusPlayers),
+					OpponentIDs: playerIDs(versusPlayers),
+					Variant:     currentRoom.Variant,
+					BoardSize:   boardSizeLabel(currentRoom),
+					Placement:   1,
+					Players:     players,
+					Symbol:      user.Symbol,
+					Moves:       uint(currentRoom.MoveCount),
+				})
+			}
+			if err := ws.ScoreService.RecordResult(context.Background(), currentRoom, scores, placements); err != nil {
+				slog.Error(
+					"[wss]handleExitRoom",
+					slog.String("error", err.Error()),
+				)
+			}
+			ws.reportTournamentResult(currentRoom, scores)
 		}
 
-		ws.jsonToOther(currentUser.ID, room, &GameReponse{
-			Action: chooseSymbolAction,
-			UserID: &versusPlayer.ID,
-		})
-		currentRoom.Positions = make([]*SymbolPosition, 0)
-		ws.jsonToOther(currentUser.ID, room, &GameReponse{
-			Action: getPositionsAction,
-			Data: map[string]interface{}{
-				"positions": currentRoom.Positions,
-			},
-		})
-		currentRoom.GameStatus = chooseSymbolStatus
+		ws.jsonToOther(currentUser.ID, room, chooseSymbolResponse(currentRoom, remainingPlayers[0]))
+		ws.startNewGame(currentRoom)
+		ws.jsonToOther(currentUser.ID, room, positionsResponse(currentRoom, ""))
 		ws.Mu.Lock()
 		defer ws.Mu.Unlock()
-		versusPlayer.Symbol = ""
-		currentRoom.Users = []*ConnectedUser{
-			versusPlayer,
+		currentRoom.LastFirstMoverID = nil
+		currentRoom.LastLoserID = nil
+		if humanPlayers(remainingPlayers) == 0 {
+			remainingPlayers = remainingPlayers[:0]
 		}
+		currentRoom.Users = remainingPlayers
 	}
 	conn.WriteMessage(
 		websocket.CloseMessage,
@@ -247,17 +378,17 @@ func (ws *WSServer) handleExitRoom(
 	return true
 }
 
-// handleGameEnd обрабатывает завершение игры
+// handleGameEnd обрабатывает сообщение клиента о завершении игры
 //
 // Параметры:
 //   - currentUserID: ID текущего пользователя
 //   - room: игровая комната
 //   - request: запрос с результатом игры
 //
-// Действия:
-//  1. Сохраняет результат игры
-//  2. Устанавливает статус "игра завершена"
-//  3. Уведомляет о завершении игры
+// Особенности:
+//   - Итог партии вычисляет и сохраняет сервер (см. finishGame),
+//     присланный клиентом результат не записывается
+//   - Если сервер подтвердил завершение партии, уведомляет соперника о возможности рестарта
 func (ws *WSServer) handleGameEnd(
 	currentUserID uuid.UUID,
 	room *common.RoomSessionResponse,
@@ -266,30 +397,11 @@ func (ws *WSServer) handleGameEnd(
 	slog.Info(
 		"Game ended for user:",
 		slog.String("user_id", currentUserID.String()),
+		slog.Any("data", request.Data),
 	)
-	rawGameEndJson, ok := request.Data.(map[string]interface{})
-	if !ok {
-		return
-	}
-	isWon, ok := rawGameEndJson["is_won"].(float64)
-	if !ok {
-		return
-	}
-	userID, ok := rawGameEndJson["user_id"].(string)
-	if !ok {
-		return
-	}
-	nickname, ok := rawGameEndJson["versus_player_nickname"].(string)
-	if !ok {
+	if ws.Rooms[room.ID].GameStatus != gameEndStatus {
 		return
 	}
-	score := &common.Score{
-		IsWon:    isWon,
-		UserID:   userID,
-		Nickname: nickname,
-	}
-	ws.Rooms[room.ID].GameStatus = gameEndStatus
-	ws.ScoreService.scoreRepo.Create(context.Background(), score)
 	ws.jsonToOther(
 		currentUserID,
 		room, &GameReponse{
@@ -297,17 +409,119 @@ func (ws *WSServer) handleGameEnd(
 		})
 }
 
+// finishGame фиксирует итог партии, вычисленный сервером
+//
+// Параметры:
+//   - room: игровая комната
+//   - currentRoom: состояние комнаты на сервере
+//   - result: итог партии
+//
+// Действия:
+//  1. Устанавливает статус "игра завершена" и запоминает проигравшего —
+//     игрока, который ходил следующим после победителя
+//  2. Сохраняет партию с историей ходов и ставит её в очередь на разбор
+//  3. Сохраняет результат каждого игрока (win, loss или draw) с причиной завершения
+//     (line или board_full), комнатой и партией, названием варианта правил, местом,
+//     числом игроков, символом игрока и числом ходов;
+//     в рейтинговой комнате вместе с результатами обновляются рейтинги игроков;
+//     в комнате турнирного матча итог продвигает сетку турнира (см. reportTournamentResult)
+//  4. Рассылает итог партии всем игрокам вместе с идентификатором сохранённой партии
+func (ws *WSServer) finishGame(
+	room *common.RoomSessionResponse,
+	currentRoom *RoomServer,
+	result GameResult,
+) {
+	currentRoom.GameStatus = gameEndStatus
+	currentRoom.Turn = ""
+	currentRoom.LastLoserID = nil
+	if result.WinnerSymbol != "" {
+		if loser := findUserBySymbol(currentRoom, nextTurnSymbol(currentRoom, result.WinnerSymbol)); loser != nil {
+			currentRoom.LastLoserID = &loser.ID
+		}
+	}
+	var gameID *uint64
+	game, err := ws.GameService.Record(context.Background(), currentRoom, result)
+	if err != nil {
+		slog.Error(
+			"[wss]finishGame",
+			slog.String("error", err.Error()),
+		)
+	} else {
+		gameID = &game.ID
+	}
+	termination := boardFullTermination
+	if result.LineSymbol != "" {
+		termination = lineTermination
+	}
+	var winnerID *uuid.UUID
+	scores := make([]*common.Score, 0, len(currentRoom.Users))
+	placements := make(map[uuid.UUID]uint8, len(currentRoom.Users))
+	for _, user := range currentRoom.Users {
+		versusPlayers := make([]*ConnectedUser, 0, len(currentRoom.Users))
+		for _, versus := range currentRoom.Users {
+			if versus.ID != user.ID {
+				versusPlayers = append(versusPlayers, versus)
+			}
+		}
+		userResult := drawResult
+		if result.WinnerSymbol != "" {
+			userResult = lossResult
+			if user.Symbol == result.WinnerSymbol {
+				userResult = winResult
+				winnerID = &user.ID
+			}
+		}
+		placements[user.ID] = placement(user, result)
+		scores = append(scores, &common.Score{
+			Result:      userResult,
+			Termination: termination,
+			RoomID:      &currentRoom.ID,
+			GameID:      gameID,
+			UserID:      user.ID.String(),
+			Nickname:    playerNames(versusPlayers),
+			OpponentIDs: playerIDs(versusPlayers),
+			Variant:     currentRoom.Variant,
+			BoardSize:   boardSizeLabel(currentRoom),
+			Placement:   placements[user.ID],
+			Players:     uint8(len(currentRoom.Users)),
+			Symbol:      user.Symbol,
+			Moves:       uint(currentRoom.MoveCount),
+		})
+	}
+	if err := ws.ScoreService.RecordResult(context.Background(), currentRoom, scores, placements); err != nil {
+		slog.Error(
+			"[wss]finishGame",
+			slog.String("error", err.Error()),
+		)
+	}
+	ws.reportTournamentResult(currentRoom, scores)
+	ws.jsonToAll(room, &GameReponse{
+		Action: gameEndAction,
+		Data: map[string]interface{}{
+			"variant":       currentRoom.Variant,
+			"winner_symbol": result.WinnerSymbol,
+			"line_symbol":   result.LineSymbol,
+			"is_draw":       result.WinnerSymbol == "",
+			"game_id":       gameID,
+		},
+		Symbol: result.WinnerSymbol,
+		UserID: winnerID,
+	})
+}
+
 // handleCloseRoom полностью закрывает комнату
 //
 // Параметры:
 //   - room: комната для закрытия
 //
 // Действия:
-//  1. Закрывает все соединения в комнате
-//  2. Удаляет комнату из списка активных
+//  1. Если игра шла, записывает игрокам брошенную партию (см. abandonGame)
+//  2. Закрывает все соединения в комнате
+//  3. Удаляет комнату из списка активных
 func (ws *WSServer) handleCloseRoom(
 	room *RoomServer,
 ) {
+	ws.abandonGame(room, closeTermination)
 	for _, user := range room.Users {
 		ws.CloseConnection(room.ID, user.Connection)
 	}
@@ -317,6 +531,64 @@ func (ws *WSServer) handleCloseRoom(
 	delete(ws.Rooms, room.ID)
 }
 
+// abandonGame записывает всем игрокам брошенную партию (abandoned), если партия шла
+//
+// Параметры:
+//   - currentRoom: состояние комнаты на сервере
+//   - termination: причина, по которой партия прервана (reset или room_closed)
+//
+// Особенности:
+//   - Брошенная партия не меняет рейтинг и не учитывается в статистике,
+//     но остаётся в истории результатов игроков
+func (ws *WSServer) abandonGame(currentRoom *RoomServer, termination string) {
+	if currentRoom.GameStatus != inProcessStatus {
+		return
+	}
+	scores := make([]*common.Score, 0, len(currentRoom.Users))
+	for _, user := range currentRoom.Users {
+		versusPlayers := make([]*ConnectedUser, 0, len(currentRoom.Users))
+		for _, versus := range currentRoom.Users {
+			if versus.ID != user.ID {
+				versusPlayers = append(versusPlayers, versus)
+			}
+		}
+		scores = append(scores, &common.Score{
+			Result:      abandonedResult,
+			Termination: termination,
+			RoomID:      &currentRoom.ID,
+			UserID:      user.ID.String(),
+			Nickname:    playerNames(versusPlayers),
+			OpponentIDs: playerIDs(versusPlayers),
+			Variant:     currentRoom.Variant,
+			BoardSize:   boardSizeLabel(currentRoom),
+			Placement:   1,
+			Players:     uint8(len(currentRoom.Users)),
+			Symbol:      user.Symbol,
+			Moves:       uint(currentRoom.MoveCount),
+		})
+	}
+	if err := ws.ScoreService.RecordResult(context.Background(), currentRoom, scores, map[uuid.UUID]uint8{}); err != nil {
+		slog.Error(
+			"[wss]abandonGame",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// reportTournamentResult передаёт итог партии турниру, если комната принадлежит турнирному матчу
+// (см. TournamentService.RecordResult).
+func (ws *WSServer) reportTournamentResult(currentRoom *RoomServer, scores []*common.Score) {
+	if ws.TournamentService == nil {
+		return
+	}
+	if err := ws.TournamentService.RecordResult(context.Background(), currentRoom.ID, scores); err != nil {
+		slog.Error(
+			"[wss]reportTournamentResult",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 // jsonToAll рассылает JSON сообщение всем игрокам комнаты
 func (ws *WSServer) jsonToAll(room *common.RoomSessionResponse, response *GameReponse) {
 	raw, err := json.Marshal(response)
@@ -325,6 +597,24 @@ func (ws *WSServer) jsonToAll(room *common.RoomSessionResponse, response *GameRe
 	}
 }
 
+// sendError отправляет игроку сообщение об ошибке (например, об отклонённом ходе)
+func (ws *WSServer) sendError(userID uuid.UUID, room *common.RoomSessionResponse, message string) {
+	ws.jsonToUser(userID, room, &GameReponse{
+		Action: errorAction,
+		Data: map[string]interface{}{
+			"message": message,
+		},
+	})
+}
+
+// jsonToUser отправляет JSON сообщение одному игроку комнаты
+func (ws *WSServer) jsonToUser(userID uuid.UUID, room *common.RoomSessionResponse, response *GameReponse) {
+	raw, err := json.Marshal(response)
+	if err == nil {
+		ws.sendMessageToUser(userID, room, raw)
+	}
+}
+
 // jsonToOther рассылает JSON сообщение другим игрокам комнаты
 func (ws *WSServer) jsonToOther(currentUserID uuid.UUID, room *common.RoomSessionResponse, response interface{}) {
 	raw, err := json.Marshal(response)
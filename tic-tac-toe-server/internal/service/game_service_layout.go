@@ -0,0 +1,118 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// blockedCell обозначает на поле заблокированную клетку: в неё нельзя ходить,
+// и она не входит ни в одну линию.
+const blockedCell = "#"
+
+// boardWidth возвращает ширину поля (число столбцов).
+// Если ширина не задана отдельно, поле квадратное со стороной BorderSize.
+func boardWidth(currentRoom *RoomServer) int {
+	if currentRoom.Width == 0 {
+		return int(currentRoom.BorderSize)
+	}
+	return int(currentRoom.Width)
+}
+
+// boardHeight возвращает высоту поля (число строк).
+// Если высота не задана отдельно, поле квадратное со стороной BorderSize.
+func boardHeight(currentRoom *RoomServer) int {
+	if currentRoom.Height == 0 {
+		return int(currentRoom.BorderSize)
+	}
+	return int(currentRoom.Height)
+}
+
+// boardSizeLabel возвращает размер поля комнаты в формате "<ширина>x<высота>"
+// (так размер поля записывается в результатах партий и пулах рейтинга).
+func boardSizeLabel(currentRoom *RoomServer) string {
+	return fmt.Sprintf("%dx%d", boardWidth(currentRoom), boardHeight(currentRoom))
+}
+
+// buildRoomBoard строит поле комнаты с учётом её размеров и заблокированных клеток.
+func buildRoomBoard(currentRoom *RoomServer) [][]string {
+	height, width := boardHeight(currentRoom), boardWidth(currentRoom)
+	board := buildBoard(currentRoom.Positions, height, width)
+	for _, id := range currentRoom.BlockedCells {
+		row, column, ok := parsePositionID(id)
+		if !ok || row < 0 || column < 0 || row >= height || column >= width {
+			continue
+		}
+		board[row][column] = blockedCell
+	}
+	return board
+}
+
+// isBlockedCell проверяет, заблокирована ли клетка в комнате.
+// Клетки сравниваются по координатам, а не по строке ID.
+func isBlockedCell(currentRoom *RoomServer, positionID string) bool {
+	row, column, ok := parsePositionID(positionID)
+	if !ok {
+		return false
+	}
+	for _, id := range currentRoom.BlockedCells {
+		if blockedRow, blockedColumn, ok := parsePositionID(id); ok && blockedRow == row && blockedColumn == column {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBlockedCells проверяет список заблокированных клеток
+//
+// Параметры:
+//   - cells: клетки в формате "i-j"
+//   - height, width: размеры поля
+//
+// Возвращает:
+//   - error: если клетка записана не в канонической форме (например, "1-01"),
+//     лежит вне поля, повторяется или заблокировано больше трети поля
+func validateBlockedCells(cells []string, height, width int) error {
+	if len(cells) > height*width/3 {
+		return fmt.Errorf("no more than %d cells can be blocked", height*width/3)
+	}
+	seen := make(map[[2]int]bool, len(cells))
+	for _, id := range cells {
+		row, column, ok := parsePositionID(id)
+		if !ok {
+			return fmt.Errorf("blocked cell %q must be written as \"i-j\"", id)
+		}
+		if row < 0 || column < 0 || row >= height || column >= width {
+			return fmt.Errorf("blocked cell %q is out of board", id)
+		}
+		cell := [2]int{row, column}
+		if seen[cell] {
+			return fmt.Errorf("blocked cell %q is duplicated", id)
+		}
+		seen[cell] = true
+	}
+	return nil
+}
+
+// generateBlockedCells случайно выбирает заблокированные клетки по зерну
+//
+// Параметры:
+//   - height, width: размеры поля
+//   - count: сколько клеток заблокировать
+//   - seed: зерно генератора; одно и то же зерно даёт одну и ту же раскладку
+//
+// Возвращает:
+//   - []string: клетки в формате "i-j"
+//   - error: если заблокировать нужно больше трети поля
+func generateBlockedCells(height, width, count int, seed int64) ([]string, error) {
+	if count > height*width/3 {
+		return nil, errors.New("too many blocked cells for this board")
+	}
+	random := rand.New(rand.NewPCG(uint64(seed), uint64(height*width)))
+	cells := make([]string, 0, count)
+	for _, index := range random.Perm(height * width)[:count] {
+		cells = append(cells, fmt.Sprintf("%d-%d", index/width, index%width))
+	}
+	return cells, nil
+}
@@ -0,0 +1,264 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// Нотация позиции записывает двумерное поле одной строкой из пяти полей через пробел:
+//
+//	<ширина>x<высота> <длина линии> <клетки> <ходящий> <вариант>
+//
+// Клетки перечисляются по строкам сверху вниз, строки разделяются "/".
+// В строке X, O, T (triangle) и S (square) — фишки игроков, "#" — заблокированная
+// клетка, число — сколько подряд идёт свободных клеток. Например, позиция на поле 3x3,
+// в которой X занял центр, O — угол, и ходит X:
+//
+//	3x3 3 O2/1X1/3 X classic
+
+// ErrInvalidNotation возвращается, если строку нельзя разобрать как нотацию позиции.
+var ErrInvalidNotation = errors.New("invalid position notation")
+
+// notationSymbols сопоставляет буквы нотации символам игроков.
+var notationSymbols = map[byte]string{
+	'X': "X",
+	'O': "O",
+	'T': "triangle",
+	'S': "square",
+}
+
+// notationLetter возвращает букву нотации для символа игрока (0, если символ неизвестен).
+func notationLetter(symbol string) byte {
+	for letter, notationSymbol := range notationSymbols {
+		if notationSymbol == symbol {
+			return letter
+		}
+	}
+	return 0
+}
+
+// EncodePosition записывает позицию в нотации позиции
+//
+// Параметры:
+//   - position: позиция с размерами поля, фишками, заблокированными клетками, ходящим и вариантом
+//
+// Возвращает:
+//   - string: позиция в нотации (см. DecodePosition)
+//
+// Особенности:
+//   - Фишки и заблокированные клетки вне поля или с неверным форматом пропускаются
+func EncodePosition(position *common.BoardPosition) string {
+	height, width := int(position.Height), int(position.Width)
+	board := make([][]byte, height)
+	for i := range board {
+		board[i] = make([]byte, width)
+	}
+	place := func(id string, letter byte) {
+		row, column, ok := parsePositionID(id)
+		if ok && letter != 0 && row >= 0 && column >= 0 && row < height && column < width {
+			board[row][column] = letter
+		}
+	}
+	for _, id := range position.BlockedCells {
+		place(id, blockedCell[0])
+	}
+	for _, piece := range position.Positions {
+		place(piece.ID, notationLetter(piece.Symbol))
+	}
+	rows := make([]string, 0, height)
+	for _, cells := range board {
+		var row strings.Builder
+		empty := 0
+		for _, cell := range cells {
+			if cell == 0 {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				row.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			row.WriteByte(cell)
+		}
+		if empty > 0 {
+			row.WriteString(strconv.Itoa(empty))
+		}
+		rows = append(rows, row.String())
+	}
+	turn := string(notationLetter(position.Turn))
+	return fmt.Sprintf("%dx%d %d %s %s %s", width, height, position.WinLength, strings.Join(rows, "/"), turn, position.Variant)
+}
+
+// DecodePosition разбирает позицию из нотации позиции
+//
+// Параметры:
+//   - notation: строка вида "3x3 3 O2/1X1/3 X classic"
+//
+// Возвращает:
+//   - *common.BoardPosition: размеры поля, длина линии, фишки, заблокированные клетки,
+//     ходящий и вариант
+//   - error: ErrInvalidNotation с описанием ошибки
+//
+// Особенности:
+//   - Ширина и высота — от 3 до 15, длина линии — от 3 до большей стороны поля
+//   - Число клеток в каждой строке должно совпадать с шириной, число строк — с высотой
+//   - Вариант правил не проверяется по реестру: это делает вызывающий код
+func DecodePosition(notation string) (*common.BoardPosition, error) {
+	fields := strings.Fields(notation)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidNotation, len(fields))
+	}
+	width, height, ok := parseNotationSize(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("%w: board size %q must be <width>x<height> from 3 to 15", ErrInvalidNotation, fields[0])
+	}
+	winLength, err := strconv.Atoi(fields[1])
+	if err != nil || winLength < 3 || winLength > max(width, height) {
+		return nil, fmt.Errorf("%w: win length %q must be from 3 to %d", ErrInvalidNotation, fields[1], max(width, height))
+	}
+	position := &common.BoardPosition{
+		Width:        uint8(width),
+		Height:       uint8(height),
+		WinLength:    uint8(winLength),
+		Positions:    make([]common.AnalysisPosition, 0),
+		BlockedCells: make([]string, 0),
+		Variant:      fields[4],
+	}
+	rows := strings.Split(fields[2], "/")
+	if len(rows) != height {
+		return nil, fmt.Errorf("%w: expected %d rows, got %d", ErrInvalidNotation, height, len(rows))
+	}
+	for row, cells := range rows {
+		column := 0
+		for index := 0; index < len(cells); index++ {
+			char := cells[index]
+			switch {
+			case char >= '1' && char <= '9':
+				end := index + 1
+				for end < len(cells) && cells[end] >= '0' && cells[end] <= '9' {
+					end++
+				}
+				empty, _ := strconv.Atoi(cells[index:end])
+				column += empty
+				index = end - 1
+				continue
+			case char == blockedCell[0]:
+				if column < width {
+					position.BlockedCells = append(position.BlockedCells, fmt.Sprintf("%d-%d", row, column))
+				}
+			case notationSymbols[char] != "":
+				if column < width {
+					position.Positions = append(position.Positions, common.AnalysisPosition{
+						ID:     fmt.Sprintf("%d-%d", row, column),
+						Symbol: notationSymbols[char],
+					})
+				}
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q in row %d", ErrInvalidNotation, char, row+1)
+			}
+			column++
+		}
+		if column != width {
+			return nil, fmt.Errorf("%w: row %d has %d cells, expected %d", ErrInvalidNotation, row+1, column, width)
+		}
+	}
+	if len(fields[3]) != 1 || notationSymbols[fields[3][0]] == "" {
+		return nil, fmt.Errorf("%w: side to move %q must be one of X, O, T, S", ErrInvalidNotation, fields[3])
+	}
+	position.Turn = notationSymbols[fields[3][0]]
+	return position, nil
+}
+
+// parseNotationSize разбирает размер поля "<ширина>x<высота>".
+func parseNotationSize(field string) (int, int, bool) {
+	parts := strings.Split(field, "x")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	width, err := strconv.Atoi(parts[0])
+	if err != nil || width < 3 || width > 15 {
+		return 0, 0, false
+	}
+	height, err := strconv.Atoi(parts[1])
+	if err != nil || height < 3 || height > 15 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// validateStartPosition проверяет, что с позиции можно начать партию в комнате
+//
+// Параметры:
+//   - position: разобранная позиция
+//   - capacity: вместимость комнаты
+//   - gravity: в комнате включён режим "гравитации"
+//
+// Возвращает:
+//   - error: если фишки или ходящий используют символ, которого нет в комнате,
+//     партия в позиции уже окончена (линия собрана или поле заполнено) или
+//     в режиме "гравитации" под фишкой есть свободная клетка
+func validateStartPosition(position *common.BoardPosition, capacity int, gravity bool) error {
+	symbols := playerSymbols[:capacity]
+	isSymbol := func(symbol string) bool {
+		for _, roomSymbol := range symbols {
+			if roomSymbol == symbol {
+				return true
+			}
+		}
+		return false
+	}
+	if !isSymbol(position.Turn) {
+		return fmt.Errorf("side to move %q is not available for %d players", position.Turn, capacity)
+	}
+	height, width := int(position.Height), int(position.Width)
+	board := make([][]string, height)
+	for i := range board {
+		board[i] = make([]string, width)
+	}
+	for _, id := range position.BlockedCells {
+		row, column, _ := parsePositionID(id)
+		board[row][column] = blockedCell
+	}
+	for _, piece := range position.Positions {
+		if !isSymbol(piece.Symbol) {
+			return fmt.Errorf("symbol %q is not available for %d players", piece.Symbol, capacity)
+		}
+		row, column, _ := parsePositionID(piece.ID)
+		board[row][column] = piece.Symbol
+	}
+	if findLine(board, int(position.WinLength)) != "" {
+		return errors.New("game is already over: a line is completed")
+	}
+	if isBoardFull(board) {
+		return errors.New("game is already over: the board is full")
+	}
+	if gravity {
+		for row := 0; row < height-1; row++ {
+			for column := 0; column < width; column++ {
+				if board[row][column] != "" && board[row][column] != blockedCell && board[row+1][column] == "" {
+					return fmt.Errorf("piece %d-%d is floating above an empty cell", row, column)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// placeStartPosition ставит на поле комнаты фишки её начальной позиции.
+// Фишки начальной позиции не принадлежат игрокам и не попадают в историю ходов.
+func placeStartPosition(currentRoom *RoomServer) {
+	if currentRoom.StartPosition == nil {
+		return
+	}
+	for _, piece := range currentRoom.StartPosition.Positions {
+		currentRoom.Positions = append(currentRoom.Positions, &SymbolPosition{
+			ID:     piece.ID,
+			Symbol: piece.Symbol,
+		})
+	}
+}
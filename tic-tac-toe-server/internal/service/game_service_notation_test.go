@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPositionNotationRoundTrip(t *testing.T) {
+	tests := []string{
+		"3x3 3 3/3/3 X classic",
+		"3x3 3 O2/1X1/3 X classic",
+		"3x3 3 XOX/OXO/OXO O misere",
+		"5x4 4 #4/2T2/S3#/5 S obstacles",
+		"15x15 5 15/15/15/15/15/15/15/7X7/15/15/15/15/15/15/15 O gomoku",
+		"4x6 3 4/4/4/4/4/XO2 X gravity",
+	}
+	for _, notation := range tests {
+		t.Run(notation, func(t *testing.T) {
+			position, err := DecodePosition(notation)
+			if err != nil {
+				t.Fatalf("DecodePosition() error = %v", err)
+			}
+			if got := EncodePosition(position); got != notation {
+				t.Errorf("EncodePosition(DecodePosition()) = %q, want %q", got, notation)
+			}
+		})
+	}
+}
+
+func TestDecodePositionCells(t *testing.T) {
+	position, err := DecodePosition("4x3 3 #O2/1X2/3T O classic")
+	if err != nil {
+		t.Fatalf("DecodePosition() error = %v", err)
+	}
+	if position.Width != 4 || position.Height != 3 || position.WinLength != 3 {
+		t.Errorf("DecodePosition() size = %dx%d %d, want 4x3 3", position.Width, position.Height, position.WinLength)
+	}
+	if len(position.BlockedCells) != 1 || position.BlockedCells[0] != "0-0" {
+		t.Errorf("DecodePosition() blocked cells = %v, want [0-0]", position.BlockedCells)
+	}
+	want := map[string]string{"0-1": "O", "1-1": "X", "2-3": "triangle"}
+	if len(position.Positions) != len(want) {
+		t.Fatalf("DecodePosition() positions = %v, want %v", position.Positions, want)
+	}
+	for _, piece := range position.Positions {
+		if want[piece.ID] != piece.Symbol {
+			t.Errorf("DecodePosition() cell %s = %q, want %q", piece.ID, piece.Symbol, want[piece.ID])
+		}
+	}
+	if position.Turn != "O" || position.Variant != classicVariant {
+		t.Errorf("DecodePosition() turn, variant = %q, %q, want O, classic", position.Turn, position.Variant)
+	}
+}
+
+func TestDecodePositionInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+	}{
+		{name: "missing field", notation: "3x3 3 3/3/3 X"},
+		{name: "extra field", notation: "3x3 3 3/3/3 X classic extra"},
+		{name: "board too small", notation: "2x2 3 2/2 X classic"},
+		{name: "board too large", notation: "16x3 3 16/16/16 X classic"},
+		{name: "malformed size", notation: "3by3 3 3/3/3 X classic"},
+		{name: "win length too long", notation: "3x3 4 3/3/3 X classic"},
+		{name: "win length too short", notation: "3x3 2 3/3/3 X classic"},
+		{name: "missing row", notation: "3x3 3 3/3 X classic"},
+		{name: "row too long", notation: "3x3 3 3/4/3 X classic"},
+		{name: "row too short", notation: "3x3 3 3/X1/3 X classic"},
+		{name: "unknown symbol", notation: "3x3 3 3/1Z1/3 X classic"},
+		{name: "unknown side to move", notation: "3x3 3 3/3/3 Z classic"},
+		{name: "blocked side to move", notation: "3x3 3 3/3/3 # classic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodePosition(tt.notation); !errors.Is(err, ErrInvalidNotation) {
+				t.Errorf("DecodePosition(%q) error = %v, want %v", tt.notation, err, ErrInvalidNotation)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Параметры комнат на несколько игроков.
+const (
+	// DEFAULT_CAPACITY задаёт вместимость комнаты по умолчанию.
+	DEFAULT_CAPACITY = 2
+	// MAX_CAPACITY задаёт наибольшее число игроков в комнате.
+	MAX_CAPACITY = 4
+	// MULTIPLAYER_WIN_LENGTH задаёт длину линии по умолчанию для комнат больше чем на двух игроков.
+	MULTIPLAYER_WIN_LENGTH = 3
+)
+
+// playerSymbols перечисляет символы игроков в порядке их раздачи.
+// Комната на N игроков использует первые N символов.
+var playerSymbols = []string{"X", "O", "triangle", "square"}
+
+// roomCapacity возвращает вместимость комнаты (DEFAULT_CAPACITY, если она не задана).
+func roomCapacity(currentRoom *RoomServer) int {
+	if currentRoom.Capacity < DEFAULT_CAPACITY {
+		return DEFAULT_CAPACITY
+	}
+	if currentRoom.Capacity > MAX_CAPACITY {
+		return MAX_CAPACITY
+	}
+	return int(currentRoom.Capacity)
+}
+
+// roomSymbols возвращает символы, доступные игрокам комнаты.
+func roomSymbols(currentRoom *RoomServer) []string {
+	return playerSymbols[:roomCapacity(currentRoom)]
+}
+
+// isRoomSymbol проверяет, что символ можно выбрать в этой комнате.
+func isRoomSymbol(currentRoom *RoomServer, symbol string) bool {
+	for _, roomSymbol := range roomSymbols(currentRoom) {
+		if roomSymbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultBorderSize возвращает начальный размер поля: для каждого игрока
+// сверх двух поле увеличивается на одну клетку.
+func defaultBorderSize(capacity uint64) uint64 {
+	if capacity <= DEFAULT_CAPACITY {
+		return DEFAULT_BORDER_SIZE
+	}
+	return DEFAULT_BORDER_SIZE + capacity - DEFAULT_CAPACITY
+}
+
+// assignSymbols раздаёт символы игрокам и задаёт очередь ходов
+//
+// Параметры:
+//   - currentRoom: заполненная игровая комната
+//   - chooser: игрок, который выбрал символ и ходит первым
+//   - symbol: выбранный символ
+//
+// Логика:
+//  1. Очередь начинается с выбравшего игрока и идёт по порядку входа в комнату
+//  2. Остальные игроки получают свободные символы в порядке playerSymbols
+//  3. TurnOrder хранит символы в порядке ходов
+func assignSymbols(currentRoom *RoomServer, chooser *ConnectedUser, symbol string) {
+	start := 0
+	for index, user := range currentRoom.Users {
+		if user.ID == chooser.ID {
+			start = index
+			break
+		}
+	}
+	free := make([]string, 0, len(playerSymbols))
+	for _, roomSymbol := range roomSymbols(currentRoom) {
+		if roomSymbol != symbol {
+			free = append(free, roomSymbol)
+		}
+	}
+	currentRoom.TurnOrder = make([]string, 0, len(currentRoom.Users))
+	for offset := range currentRoom.Users {
+		user := currentRoom.Users[(start+offset)%len(currentRoom.Users)]
+		if offset == 0 {
+			user.Symbol = symbol
+		} else {
+			user.Symbol = free[0]
+			free = free[1:]
+		}
+		currentRoom.TurnOrder = append(currentRoom.TurnOrder, user.Symbol)
+	}
+}
+
+// nextTurnSymbol возвращает символ игрока, который ходит после указанного.
+// Если очередь ещё не задана, используется противоположный символ (X/O).
+func nextTurnSymbol(currentRoom *RoomServer, symbol string) string {
+	for index, turnSymbol := range currentRoom.TurnOrder {
+		if turnSymbol == symbol {
+			return currentRoom.TurnOrder[(index+1)%len(currentRoom.TurnOrder)]
+		}
+	}
+	return opositeSymbol(symbol)
+}
+
+// findUserBySymbol ищет игрока комнаты по его символу.
+func findUserBySymbol(currentRoom *RoomServer, symbol string) *ConnectedUser {
+	for _, user := range currentRoom.Users {
+		if user.Symbol == symbol {
+			return user
+		}
+	}
+	return nil
+}
+
+// placement возвращает место игрока в завершённой партии:
+// победитель занимает первое место, остальные делят второе; при ничьей первое место у всех.
+func placement(user *ConnectedUser, result GameResult) uint8 {
+	if result.WinnerSymbol == "" || user.Symbol == result.WinnerSymbol {
+		return 1
+	}
+	return 2
+}
+
+// playerNames объединяет имена игроков через запятую (для записи соперников в результатах).
+func playerNames(users []*ConnectedUser) string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// playerIDs возвращает идентификаторы игроков (для записи соперников в результатах).
+func playerIDs(users []*ConnectedUser) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAssignSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		chooser int
+		symbol  string
+		// symbols — символы игроков в порядке входа в комнату
+		symbols []string
+		order   []string
+	}{
+		{
+			name:    "two players",
+			players: 2,
+			chooser: 1,
+			symbol:  "X",
+			symbols: []string{"O", "X"},
+			order:   []string{"X", "O"},
+		},
+		{
+			name:    "three players",
+			players: 3,
+			chooser: 1,
+			symbol:  "O",
+			symbols: []string{"triangle", "O", "X"},
+			order:   []string{"O", "X", "triangle"},
+		},
+		{
+			name:    "four players",
+			players: 4,
+			chooser: 0,
+			symbol:  "square",
+			symbols: []string{"square", "X", "O", "triangle"},
+			order:   []string{"square", "X", "O", "triangle"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := &RoomServer{Capacity: uint64(tt.players)}
+			for range tt.players {
+				room.Users = append(room.Users, &ConnectedUser{ID: uuid.New()})
+			}
+			assignSymbols(room, room.Users[tt.chooser], tt.symbol)
+			symbols := make([]string, 0, len(room.Users))
+			for _, user := range room.Users {
+				symbols = append(symbols, user.Symbol)
+			}
+			if !slices.Equal(symbols, tt.symbols) {
+				t.Errorf("assignSymbols() symbols = %v, want %v", symbols, tt.symbols)
+			}
+			if !slices.Equal(room.TurnOrder, tt.order) {
+				t.Errorf("assignSymbols() turn order = %v, want %v", room.TurnOrder, tt.order)
+			}
+			for index, symbol := range tt.order {
+				next := tt.order[(index+1)%len(tt.order)]
+				if got := nextTurnSymbol(room, symbol); got != next {
+					t.Errorf("nextTurnSymbol(%s) = %q, want %q", symbol, got, next)
+				}
+			}
+		})
+	}
+}
+
+// В комнате на троих ход проходит по очереди всех игроков, а чужой ход отклоняется.
+func TestMultiplayerTurnOrder(t *testing.T) {
+	room := testRoom(classicVariant, defaultBorderSize(3))
+	room.Capacity = 3
+	room.WinLength = MULTIPLAYER_WIN_LENGTH
+	room.Users = append(room.Users, &ConnectedUser{ID: uuid.New(), Name: "triangle"})
+	assignSymbols(room, room.Users[0], "X")
+	room.Turn = "X"
+	for _, move := range []struct{ symbol, id, next string }{
+		{"X", "0-0", "O"},
+		{"O", "1-1", "triangle"},
+		{"triangle", "2-2", "X"},
+	} {
+		testMove(t, room, move.symbol, move.symbol, move.id)
+		if room.Turn != move.next {
+			t.Errorf("after %s turn = %q, want %q", move.symbol, room.Turn, move.next)
+		}
+	}
+	move := &Move{UserID: findUserBySymbol(room, "triangle").ID, PositionID: "3-3", Symbol: "triangle"}
+	if err := room.Rules.LegalMove(room, move); err == nil {
+		t.Errorf("LegalMove() out of turn error = nil")
+	}
+	if got := nextTurnSymbol(&RoomServer{}, "O"); got != "X" {
+		t.Errorf("nextTurnSymbol() without turn order = %q, want X", got)
+	}
+}
@@ -0,0 +1,134 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+)
+
+// symbolChooser возвращает игрока, который выбирает символ и ходит первым
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//
+// Возвращает:
+//   - *ConnectedUser: выбирающий игрок или nil если комната пуста
+//
+// Логика:
+//  1. Пока комната не заполнена, выбирает первый вошедший (как и раньше)
+//  2. Когда комната заполнена, игрок определяется правилом FirstMovePolicy
+//     и запоминается до конца партии
+func (ws *WSServer) symbolChooser(currentRoom *RoomServer) *ConnectedUser {
+	if len(currentRoom.Users) == 0 {
+		return nil
+	}
+	if currentRoom.ChooserID == nil && len(currentRoom.Users) >= roomCapacity(currentRoom) {
+		chooser := pickFirstMover(currentRoom)
+		currentRoom.ChooserID = &chooser.ID
+	}
+	if currentRoom.ChooserID != nil {
+		if chooser := findConnectedUser(currentRoom, *currentRoom.ChooserID); chooser != nil {
+			return chooser
+		}
+		currentRoom.ChooserID = nil
+	}
+	return currentRoom.Users[0]
+}
+
+// pickFirstMover выбирает игрока, который ходит первым, по правилу комнаты
+//
+// Параметры:
+//   - currentRoom: заполненная игровая комната
+//
+// Правила:
+//   - creator: создатель комнаты (или первый вошедший, если создателя нет)
+//   - random: случайный игрок
+//   - alternate: следующий по порядку входа игрок после ходившего первым в прошлой партии
+//   - loser: проигравший прошлой партии, при ничьей — как alternate
+func pickFirstMover(currentRoom *RoomServer) *ConnectedUser {
+	switch currentRoom.FirstMovePolicy {
+	case randomFirstMovePolicy:
+		return currentRoom.Users[rand.IntN(len(currentRoom.Users))]
+	case loserFirstMovePolicy:
+		if currentRoom.LastLoserID != nil {
+			if loser := findConnectedUser(currentRoom, *currentRoom.LastLoserID); loser != nil {
+				return loser
+			}
+		}
+		return alternateFirstMover(currentRoom)
+	case alternateFirstMovePolicy:
+		return alternateFirstMover(currentRoom)
+	}
+	return creatorOrFirst(currentRoom)
+}
+
+// alternateFirstMover передаёт первый ход следующему по порядку входа игроку
+// после ходившего первым в прошлой партии.
+// Для первой партии в комнате используется создатель комнаты.
+func alternateFirstMover(currentRoom *RoomServer) *ConnectedUser {
+	if currentRoom.LastFirstMoverID == nil {
+		return creatorOrFirst(currentRoom)
+	}
+	for index, user := range currentRoom.Users {
+		if user.ID == *currentRoom.LastFirstMoverID {
+			return currentRoom.Users[(index+1)%len(currentRoom.Users)]
+		}
+	}
+	return currentRoom.Users[0]
+}
+
+// creatorOrFirst возвращает создателя комнаты или первого вошедшего игрока.
+func creatorOrFirst(currentRoom *RoomServer) *ConnectedUser {
+	if creator := findConnectedUser(currentRoom, currentRoom.CreatorID); creator != nil {
+		return creator
+	}
+	return currentRoom.Users[0]
+}
+
+// findConnectedUser ищет игрока комнаты по идентификатору.
+func findConnectedUser(currentRoom *RoomServer, userID uuid.UUID) *ConnectedUser {
+	for _, user := range currentRoom.Users {
+		if user.ID == userID {
+			return user
+		}
+	}
+	return nil
+}
+
+// startNewGame подготавливает комнату к новой партии
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//
+// Действия:
+//  1. Запоминает, кто ходил первым в завершённой партии
+//  2. Очищает символы игроков и очередь хода, поле готовит набор правил комнаты
+//  3. Сбрасывает выбирающего игрока, чтобы правило применилось заново
+func (ws *WSServer) startNewGame(currentRoom *RoomServer) {
+	if currentRoom.ChooserID != nil {
+		lastFirstMoverID := *currentRoom.ChooserID
+		currentRoom.LastFirstMoverID = &lastFirstMoverID
+	}
+	currentRoom.ChooserID = nil
+	currentRoom.Turn = ""
+	currentRoom.TurnOrder = nil
+	roomRuleSet(currentRoom).InitialBoard(currentRoom)
+	currentRoom.GameStatus = chooseSymbolStatus
+	for _, user := range currentRoom.Users {
+		user.Symbol = ""
+	}
+}
+
+// chooseSymbolResponse формирует сообщение о том, кто выбирает символ и ходит первым.
+// В данных сообщения передаются действующее правило первого хода и доступные символы.
+func chooseSymbolResponse(currentRoom *RoomServer, chooser *ConnectedUser) *GameReponse {
+	return &GameReponse{
+		Action: chooseSymbolAction,
+		Data: map[string]interface{}{
+			"first_move_policy": currentRoom.FirstMovePolicy,
+			"symbols":           roomSymbols(currentRoom),
+		},
+		UserID: &chooser.ID,
+	}
+}
@@ -0,0 +1,203 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QUBIC_BORDER_SIZE задаёт размер куба 4x4x4 для варианта Qubic.
+const QUBIC_BORDER_SIZE = 4
+
+// qubicLines содержит все 76 выигрышных линий куба 4x4x4.
+// Каждая линия задана координатами клеток {слой, строка, столбец}.
+var qubicLines = buildQubicLines(QUBIC_BORDER_SIZE)
+
+// parseQubicPositionID разбирает идентификатор клетки в формате "l-i-j"
+//
+// Параметры:
+//   - id: идентификатор клетки
+//
+// Возвращает:
+//   - [3]int: слой, строка и столбец
+//   - bool: false если формат неверный, запись не каноническая (например, "01-1-1")
+//     или клетка вне куба
+func parseQubicPositionID(id string) ([3]int, bool) {
+	var cell [3]int
+	parts := strings.Split(id, "-")
+	if len(parts) != len(cell) {
+		return cell, false
+	}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 || value >= QUBIC_BORDER_SIZE {
+			return cell, false
+		}
+		cell[i] = value
+	}
+	if fmt.Sprintf("%d-%d-%d", cell[0], cell[1], cell[2]) != id {
+		return cell, false
+	}
+	return cell, true
+}
+
+// buildQubicLines перечисляет все линии длины size в кубе size x size x size
+//
+// Логика:
+//  1. Перебирает 13 направлений (по одному из каждой пары противоположных)
+//  2. Для каждой клетки проверяет, помещается ли линия целиком в куб
+//
+// Для куба 4x4x4 получается 76 линий: 48 прямых, 24 диагонали в плоскостях
+// и 4 пространственные диагонали.
+func buildQubicLines(size int) [][][3]int {
+	lines := make([][][3]int, 0)
+	for dl := -1; dl <= 1; dl++ {
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				if !isCanonicalDirection(dl, dr, dc) {
+					continue
+				}
+				for l := 0; l < size; l++ {
+					for r := 0; r < size; r++ {
+						for c := 0; c < size; c++ {
+							endL, endR, endC := l+dl*(size-1), r+dr*(size-1), c+dc*(size-1)
+							if endL < 0 || endL >= size || endR < 0 || endR >= size || endC < 0 || endC >= size {
+								continue
+							}
+							line := make([][3]int, size)
+							for step := range line {
+								line[step] = [3]int{l + dl*step, r + dr*step, c + dc*step}
+							}
+							lines = append(lines, line)
+						}
+					}
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// isCanonicalDirection отбирает одно направление из каждой пары противоположных:
+// первая ненулевая компонента вектора должна быть положительной.
+func isCanonicalDirection(components ...int) bool {
+	for _, component := range components {
+		if component != 0 {
+			return component > 0
+		}
+	}
+	return false
+}
+
+// evaluateQubicResult вычисляет итог партии в кубе 4x4x4
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//
+// Правила:
+//   - Победа за тем, кто занял все 4 клетки одной из 76 линий
+//   - Если все 64 клетки заняты и линии нет — ничья
+func evaluateQubicResult(currentRoom *RoomServer) GameResult {
+	cube := make(map[[3]int]string, len(currentRoom.Positions))
+	for _, position := range currentRoom.Positions {
+		if cell, ok := parseQubicPositionID(position.ID); ok {
+			cube[cell] = position.Symbol
+		}
+	}
+	for _, line := range qubicLines {
+		symbol := cube[line[0]]
+		if symbol == "" {
+			continue
+		}
+		completed := true
+		for _, cell := range line[1:] {
+			if cube[cell] != symbol {
+				completed = false
+				break
+			}
+		}
+		if completed {
+			return GameResult{
+				Finished:     true,
+				WinnerSymbol: symbol,
+				LineSymbol:   symbol,
+			}
+		}
+	}
+	return GameResult{
+		Finished: len(cube) == QUBIC_BORDER_SIZE*QUBIC_BORDER_SIZE*QUBIC_BORDER_SIZE,
+	}
+}
+
+// validateQubicCell проверяет, что клетка задана тремя координатами внутри куба.
+func validateQubicCell(positionID string) error {
+	if _, ok := parseQubicPositionID(positionID); !ok {
+		return errors.New("cell is out of board")
+	}
+	return nil
+}
+
+// qubicRuleSet реализует вариант Qubic: куб 4x4x4 и 76 выигрышных линий.
+// Клиент должен явно заявить поддержку варианта при подключении.
+type qubicRuleSet struct{}
+
+func init() {
+	RegisterRuleSet(&qubicRuleSet{})
+}
+
+// Name возвращает идентификатор варианта.
+func (rules *qubicRuleSet) Name() string {
+	return qubicVariant
+}
+
+// Settings возвращает свойства варианта: куб фиксированного размера, только два игрока.
+func (rules *qubicRuleSet) Settings() RuleSetSettings {
+	return RuleSetSettings{
+		BorderSize:            QUBIC_BORDER_SIZE,
+		RequiresClientSupport: true,
+	}
+}
+
+// ValidateOptions разрешает только пустые настройки.
+func (rules *qubicRuleSet) ValidateOptions(options json.RawMessage) error {
+	return validateEmptyOptions(options)
+}
+
+// InitialBoard очищает куб.
+func (rules *qubicRuleSet) InitialBoard(currentRoom *RoomServer) {
+	clearBoard(currentRoom)
+}
+
+// LegalMove проверяет ход: свой символ, свободная клетка "l-i-j" внутри куба.
+func (rules *qubicRuleSet) LegalMove(currentRoom *RoomServer, move *Move) error {
+	player, err := validateMark(currentRoom, move.UserID, move.Symbol, false)
+	if err != nil {
+		return err
+	}
+	move.Player = player
+	if err := validateQubicCell(move.PositionID); err != nil {
+		return err
+	}
+	return validateFreeCell(currentRoom, move.PositionID)
+}
+
+// ApplyMove ставит фишку и указывает её слой.
+func (rules *qubicRuleSet) ApplyMove(currentRoom *RoomServer, move *Move) []*SymbolPosition {
+	position := placePosition(currentRoom, rules, move)
+	cell, _ := parseQubicPositionID(move.PositionID)
+	position.Layer = &cell[0]
+	return nil
+}
+
+// Result вычисляет итог партии по 76 линиям куба.
+func (rules *qubicRuleSet) Result(currentRoom *RoomServer) GameResult {
+	return evaluateQubicResult(currentRoom)
+}
+
+// NextTurn передаёт ход сопернику.
+func (rules *qubicRuleSet) NextTurn(currentRoom *RoomServer, symbol string) string {
+	return nextTurnSymbol(currentRoom, symbol)
+}
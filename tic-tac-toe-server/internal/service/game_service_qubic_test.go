@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQubicLines(t *testing.T) {
+	if len(qubicLines) != 76 {
+		t.Fatalf("qubicLines has %d lines, want 76", len(qubicLines))
+	}
+	seen := make(map[[2][3]int]bool, len(qubicLines))
+	for _, line := range qubicLines {
+		if len(line) != QUBIC_BORDER_SIZE {
+			t.Fatalf("line %v has %d cells, want %d", line, len(line), QUBIC_BORDER_SIZE)
+		}
+		ends := [2][3]int{line[0], line[len(line)-1]}
+		if seen[ends] || seen[[2][3]int{ends[1], ends[0]}] {
+			t.Errorf("line %v is listed twice", line)
+		}
+		seen[ends] = true
+	}
+}
+
+func TestQubicResult(t *testing.T) {
+	tests := []struct {
+		name string
+		x    []string
+		want GameResult
+	}{
+		{
+			name: "row in a layer",
+			x:    []string{"2-1-0", "2-1-1", "2-1-2", "2-1-3"},
+			want: GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name: "column through layers",
+			x:    []string{"0-3-1", "1-3-1", "2-3-1", "3-3-1"},
+			want: GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name: "diagonal through layers",
+			x:    []string{"0-0-0", "1-0-1", "2-0-2", "3-0-3"},
+			want: GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name: "space diagonal",
+			x:    []string{"0-0-3", "1-1-2", "2-2-1", "3-3-0"},
+			want: GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name: "bent line",
+			x:    []string{"0-0-0", "1-1-1", "2-2-2", "3-3-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(qubicVariant, QUBIC_BORDER_SIZE)
+			testPlace(room, "X", tt.x...)
+			if got := room.Rules.Result(room); got != tt.want {
+				t.Errorf("Result() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Когда заняты все 64 клетки без линии, партия заканчивается ничьей.
+func TestQubicDraw(t *testing.T) {
+	room := testRoom(qubicVariant, QUBIC_BORDER_SIZE)
+	for layer := range QUBIC_BORDER_SIZE {
+		for row := range QUBIC_BORDER_SIZE {
+			for column := range QUBIC_BORDER_SIZE {
+				room.Positions = append(room.Positions, &SymbolPosition{
+					ID:     fmt.Sprintf("%d-%d-%d", layer, row, column),
+					Symbol: fmt.Sprint(layer*QUBIC_BORDER_SIZE*QUBIC_BORDER_SIZE + row*QUBIC_BORDER_SIZE + column),
+				})
+			}
+		}
+	}
+	if got := room.Rules.Result(room); got != (GameResult{Finished: true}) {
+		t.Errorf("Result() = %+v, want a draw", got)
+	}
+}
+
+func TestQubicLegalMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "free cell", id: "3-3-3"},
+		{name: "taken cell", id: "1-1-1", wantErr: true},
+		{name: "out of cube", id: "0-0-4", wantErr: true},
+		{name: "two coordinates", id: "0-0", wantErr: true},
+		{name: "non-canonical cell", id: "01-0-0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(qubicVariant, QUBIC_BORDER_SIZE)
+			testPlace(room, "O", "1-1-1")
+			move := &Move{UserID: room.Users[0].ID, PositionID: tt.id, Symbol: "X"}
+			if err := room.Rules.LegalMove(room, move); (err != nil) != tt.wantErr {
+				t.Errorf("LegalMove(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQubicApplyMoveLayer(t *testing.T) {
+	room := testRoom(qubicVariant, QUBIC_BORDER_SIZE)
+	testMove(t, room, "X", "X", "2-0-1")
+	position := room.Positions[len(room.Positions)-1]
+	if position.Layer == nil || *position.Layer != 2 {
+		t.Errorf("ApplyMove() layer = %v, want 2", position.Layer)
+	}
+}
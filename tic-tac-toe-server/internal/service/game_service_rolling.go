@@ -0,0 +1,46 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import "github.com/google/uuid"
+
+// DEFAULT_PIECE_LIMIT задаёт лимит фишек игрока для варианта rolling по умолчанию.
+const DEFAULT_PIECE_LIMIT = 3
+
+// removeOldestPosition снимает с поля самую старую фишку игрока, если лимит исчерпан
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//   - userID: игрок, который сейчас ходит
+//
+// Возвращает:
+//   - *SymbolPosition: снятая фишка или nil, если лимит не достигнут
+//
+// Особенности:
+//   - Работает только при PieceLimit > 0
+//   - Порядок фишек определяется номером хода (SymbolPosition.Move)
+func removeOldestPosition(currentRoom *RoomServer, userID uuid.UUID) *SymbolPosition {
+	if currentRoom.PieceLimit == 0 {
+		return nil
+	}
+	var oldest *SymbolPosition
+	oldestIndex := -1
+	count := uint64(0)
+	for index, position := range currentRoom.Positions {
+		if position.UserID == nil || *position.UserID != userID {
+			continue
+		}
+		count++
+		if oldest == nil || position.Move < oldest.Move {
+			oldest = position
+			oldestIndex = index
+		}
+	}
+	if count < currentRoom.PieceLimit || oldest == nil {
+		return nil
+	}
+	currentRoom.Positions = append(
+		currentRoom.Positions[:oldestIndex:oldestIndex],
+		currentRoom.Positions[oldestIndex+1:]...,
+	)
+	return oldest
+}
@@ -0,0 +1,37 @@
+package service
+
+import "testing"
+
+func TestRollingRemovesOldestPiece(t *testing.T) {
+	room := testRoom(rollingVariant, 3)
+	room.PieceLimit = DEFAULT_PIECE_LIMIT
+	for _, move := range []struct{ symbol, id string }{
+		{"X", "0-0"}, {"O", "1-0"},
+		{"X", "0-1"}, {"O", "1-1"},
+		{"X", "2-2"}, {"O", "2-0"},
+	} {
+		if removed := testMove(t, room, move.symbol, move.symbol, move.id); len(removed) != 0 {
+			t.Fatalf("ApplyMove(%s) removed %v before the limit", move.id, removed)
+		}
+	}
+	removed := testMove(t, room, "X", "X", "2-1")
+	if len(removed) != 1 || removed[0].ID != "0-0" {
+		t.Fatalf("ApplyMove() removed %v, want the oldest X piece at 0-0", removed)
+	}
+	if len(room.Positions) != 6 {
+		t.Errorf("ApplyMove() left %d pieces, want 6", len(room.Positions))
+	}
+	if got := room.Rules.Result(room); got.Finished {
+		t.Errorf("Result() = %+v, want the game to go on", got)
+	}
+	// Освободившуюся клетку снова можно занять
+	testMove(t, room, "O", "O", "0-0")
+}
+
+func TestRemoveOldestPositionWithoutLimit(t *testing.T) {
+	room := testRoom(classicVariant, 3)
+	testPlace(room, "X", "0-0", "0-1", "0-2", "1-0")
+	if removed := removeOldestPosition(room, room.Users[0].ID); removed != nil {
+		t.Errorf("removeOldestPosition() = %+v, want nil without a piece limit", removed)
+	}
+}
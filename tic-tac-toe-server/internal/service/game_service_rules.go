@@ -0,0 +1,277 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GameResult описывает итог партии, вычисленный набором правил.
+//
+// Поля:
+//   - Finished: партия завершена
+//   - WinnerSymbol: символ победителя (пустая строка при ничьей)
+//   - LineSymbol: символ, собравший линию (пустая строка если линии нет)
+type GameResult struct {
+	Finished     bool
+	WinnerSymbol string
+	LineSymbol   string
+}
+
+// lineDirections задаёт направления проверки линий: горизонталь, вертикаль и две диагонали.
+var lineDirections = [][2]int{
+	{0, 1},
+	{1, 0},
+	{1, 1},
+	{1, -1},
+}
+
+// parsePositionID разбирает идентификатор клетки в формате "i-j"
+//
+// Параметры:
+//   - id: идентификатор клетки
+//
+// Возвращает:
+//   - int, int: строка и столбец
+//   - bool: false если формат неверный или запись не каноническая
+//     (например, "01-1" или "+1-1"), иначе одна клетка имела бы несколько ID
+func parsePositionID(id string) (int, int, bool) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	row, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	column, err := strconv.Atoi(parts[1])
+	if err != nil || fmt.Sprintf("%d-%d", row, column) != id {
+		return 0, 0, false
+	}
+	return row, column, true
+}
+
+// buildBoard строит двумерное поле из списка занятых позиций
+//
+// Параметры:
+//   - positions: занятые позиции
+//   - height, width: число строк и столбцов поля
+//
+// Особенности:
+//   - Позиции за пределами поля и с неверным форматом пропускаются
+func buildBoard(positions []*SymbolPosition, height, width int) [][]string {
+	board := make([][]string, height)
+	for i := range board {
+		board[i] = make([]string, width)
+	}
+	for _, position := range positions {
+		row, column, ok := parsePositionID(position.ID)
+		if !ok || row < 0 || column < 0 || row >= height || column >= width {
+			continue
+		}
+		board[row][column] = position.Symbol
+	}
+	return board
+}
+
+// findLine ищет на поле линию из winLength одинаковых символов во всех направлениях.
+func findLine(board [][]string, winLength int) string {
+	return findLineInDirections(board, winLength, lineDirections)
+}
+
+// findLineInDirections ищет на поле линию из winLength одинаковых символов
+//
+// Параметры:
+//   - board: игровое поле
+//   - winLength: длина линии
+//   - directions: направления, в которых засчитываются линии
+//
+// Возвращает:
+//   - string: символ, собравший линию, или пустую строку
+//
+// Особенности:
+//   - Заблокированные клетки (blockedCell) прерывают линию
+func findLineInDirections(board [][]string, winLength int, directions [][2]int) string {
+	for row := range board {
+		for column := range board[row] {
+			symbol := board[row][column]
+			if symbol == "" || symbol == blockedCell {
+				continue
+			}
+			for _, direction := range directions {
+				count := 1
+				nextRow, nextColumn := row+direction[0], column+direction[1]
+				for count < winLength &&
+					nextRow >= 0 && nextRow < len(board) &&
+					nextColumn >= 0 && nextColumn < len(board[nextRow]) &&
+					board[nextRow][nextColumn] == symbol {
+					count++
+					nextRow += direction[0]
+					nextColumn += direction[1]
+				}
+				if count >= winLength {
+					return symbol
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isBoardFull проверяет, что на поле не осталось свободных клеток (заблокированные считаются занятыми).
+func isBoardFull(board [][]string) bool {
+	for _, row := range board {
+		for _, cell := range row {
+			if cell == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// gridRuleSet реализует варианты на двумерном поле: classic, misere, rolling и wild.
+// Все они учитывают размеры поля, заблокированные клетки, гравитацию,
+// длину выигрышной линии и лимит фишек комнаты.
+//
+// Поля:
+//   - name: идентификатор варианта
+//   - misere: собравший линию проигрывает
+//   - wild: игрок на каждом ходу сам выбирает знак (X или O)
+//   - multiplayer: вариант доступен для комнат больше чем на двух игроков
+//   - pieceLimit: лимит фишек игрока по умолчанию (0 — без ограничения)
+//   - directions: направления засчитываемых линий (nil — все lineDirections)
+type gridRuleSet struct {
+	name        string
+	misere      bool
+	wild        bool
+	multiplayer bool
+	pieceLimit  uint8
+	directions  [][2]int
+}
+
+func init() {
+	RegisterRuleSet(&gridRuleSet{name: classicVariant, multiplayer: true})
+	RegisterRuleSet(&gridRuleSet{name: misereVariant, misere: true})
+	RegisterRuleSet(&gridRuleSet{name: rollingVariant, multiplayer: true, pieceLimit: DEFAULT_PIECE_LIMIT})
+	RegisterRuleSet(&gridRuleSet{name: wildVariant, wild: true})
+}
+
+// Name возвращает идентификатор варианта.
+func (rules *gridRuleSet) Name() string {
+	return rules.name
+}
+
+// Settings возвращает свойства варианта: поле настраивается, клиенту не нужна особая поддержка.
+func (rules *gridRuleSet) Settings() RuleSetSettings {
+	return RuleSetSettings{
+		Multiplayer:       rules.multiplayer,
+		DefaultPieceLimit: rules.pieceLimit,
+	}
+}
+
+// ValidateOptions разрешает только пустые настройки: параметры поля хранятся в колонках комнаты.
+func (rules *gridRuleSet) ValidateOptions(options json.RawMessage) error {
+	return validateEmptyOptions(options)
+}
+
+// InitialBoard очищает поле комнаты и ставит фишки её начальной позиции.
+func (rules *gridRuleSet) InitialBoard(currentRoom *RoomServer) {
+	clearBoard(currentRoom)
+	placeStartPosition(currentRoom)
+}
+
+// LegalMove проверяет ход
+//
+// Особенности:
+//   - В режиме гравитации клетка хода заменяется клеткой, куда упадёт фишка
+//   - В варианте wild игрок может поставить любой знак (X или O),
+//     в остальных вариантах — только свой символ
+//   - Клетка проверяется на каноническую запись "i-j" до проверки занятости
+func (rules *gridRuleSet) LegalMove(currentRoom *RoomServer, move *Move) error {
+	if currentRoom.Gravity {
+		positionID, err := resolveGravityPosition(currentRoom, move.Data)
+		if err != nil {
+			return err
+		}
+		move.PositionID = positionID
+	}
+	player, err := validateMark(currentRoom, move.UserID, move.Symbol, rules.wild)
+	if err != nil {
+		return err
+	}
+	move.Player = player
+	if _, _, err := validateGridCell(currentRoom, move.PositionID); err != nil {
+		return err
+	}
+	return validateFreeCell(currentRoom, move.PositionID)
+}
+
+// ApplyMove при исчерпанном лимите снимает самую старую фишку игрока и ставит новую.
+func (rules *gridRuleSet) ApplyMove(currentRoom *RoomServer, move *Move) []*SymbolPosition {
+	removed := make([]*SymbolPosition, 0, 1)
+	if oldest := removeOldestPosition(currentRoom, move.Player.ID); oldest != nil {
+		removed = append(removed, oldest)
+	}
+	placePosition(currentRoom, rules, move)
+	return removed
+}
+
+// Result вычисляет итог партии
+//
+// Правила:
+//   - classic: победа за тем, кто собрал линию длины winLength
+//   - misere: собравший линию проигрывает
+//   - wild: победа за игроком, сделавшим ход, которым собрана линия любого знака
+//   - rolling: как classic, но проверка идёт после снятия старой фишки и
+//     постановки новой, поэтому ничья возможна только на заполненном поле
+//   - Поле может быть прямоугольным, заблокированные клетки в линии не входят
+//   - Если поле заполнено и линии нет — ничья
+func (rules *gridRuleSet) Result(currentRoom *RoomServer) GameResult {
+	board := buildRoomBoard(currentRoom)
+	directions := rules.directions
+	if directions == nil {
+		directions = lineDirections
+	}
+	lineSymbol := findLineInDirections(board, winLength(currentRoom), directions)
+	if lineSymbol != "" {
+		winnerSymbol := lineSymbol
+		if rules.misere {
+			winnerSymbol = opositeSymbol(lineSymbol)
+		}
+		if rules.wild {
+			winnerSymbol = lastMoverSymbol(currentRoom)
+		}
+		return GameResult{
+			Finished:     true,
+			WinnerSymbol: winnerSymbol,
+			LineSymbol:   lineSymbol,
+		}
+	}
+	return GameResult{
+		Finished: isBoardFull(board),
+	}
+}
+
+// NextTurn передаёт ход следующему игроку в очереди.
+func (rules *gridRuleSet) NextTurn(currentRoom *RoomServer, symbol string) string {
+	return nextTurnSymbol(currentRoom, symbol)
+}
+
+// lastMoverSymbol возвращает символ игрока, сделавшего последний ход.
+// Используется там, где знак на поле не совпадает с символом игрока (wild).
+func lastMoverSymbol(currentRoom *RoomServer) string {
+	if len(currentRoom.Positions) == 0 {
+		return ""
+	}
+	last := currentRoom.Positions[len(currentRoom.Positions)-1]
+	if last.UserID == nil {
+		return last.Symbol
+	}
+	if player := findConnectedUser(currentRoom, *last.UserID); player != nil {
+		return player.Symbol
+	}
+	return ""
+}
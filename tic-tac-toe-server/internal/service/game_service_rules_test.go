@@ -0,0 +1,222 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testRoom возвращает идущую партию варианта variant на поле size x size
+// для двух игроков: X ходит первым, O — вторым.
+func testRoom(variant string, size uint64) *RoomServer {
+	room := &RoomServer{
+		ID:         1,
+		Capacity:   DEFAULT_CAPACITY,
+		BorderSize: size,
+		Variant:    variant,
+		Rules:      ruleSetFor(variant),
+		Users: []*ConnectedUser{
+			{ID: uuid.New(), Name: "cross", Symbol: "X"},
+			{ID: uuid.New(), Name: "nought", Symbol: "O"},
+		},
+		TurnOrder:  []string{"X", "O"},
+		Turn:       "X",
+		GameStatus: inProcessStatus,
+		Mu:         &sync.Mutex{},
+	}
+	room.Rules.InitialBoard(room)
+	return room
+}
+
+// testPlace ставит фишки игрока с символом symbol в клетки ids, не проверяя очередь ходов.
+func testPlace(room *RoomServer, symbol string, ids ...string) {
+	player := findUserBySymbol(room, symbol)
+	for _, id := range ids {
+		room.MoveCount++
+		room.Positions = append(room.Positions, &SymbolPosition{
+			ID:     id,
+			Symbol: symbol,
+			UserID: &player.ID,
+			Move:   room.MoveCount,
+		})
+	}
+}
+
+// testMove проверяет и применяет ход игрока с символом symbol знаком mark
+// так же, как ход из WebSocket, и возвращает снятые с поля фишки.
+func testMove(t *testing.T, room *RoomServer, symbol, mark, id string) []*SymbolPosition {
+	t.Helper()
+	rules := roomRuleSet(room)
+	move := &Move{
+		UserID:     findUserBySymbol(room, symbol).ID,
+		PositionID: id,
+		Symbol:     mark,
+		Data:       map[string]interface{}{"id": id, "symbol": mark},
+	}
+	if err := rules.LegalMove(room, move); err != nil {
+		t.Fatalf("LegalMove(%s %s at %s) error = %v", symbol, mark, id, err)
+	}
+	return rules.ApplyMove(room, move)
+}
+
+func TestGridRuleSetResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		variant   string
+		width     uint64
+		height    uint64
+		winLength uint64
+		blocked   []string
+		x         []string
+		o         []string
+		want      GameResult
+	}{
+		{
+			name:    "classic row",
+			variant: classicVariant,
+			x:       []string{"0-0", "0-1", "0-2"},
+			o:       []string{"1-0", "1-1"},
+			want:    GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name:    "classic anti-diagonal",
+			variant: classicVariant,
+			x:       []string{"0-2", "1-1", "2-0"},
+			o:       []string{"0-0", "0-1"},
+			want:    GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name:    "game goes on",
+			variant: classicVariant,
+			x:       []string{"0-0"},
+			o:       []string{"1-1"},
+		},
+		{
+			name:    "full board is a draw",
+			variant: classicVariant,
+			x:       []string{"0-0", "0-2", "1-0", "2-1", "2-2"},
+			o:       []string{"0-1", "1-1", "1-2", "2-0"},
+			want:    GameResult{Finished: true},
+		},
+		{
+			name:    "misere line loses",
+			variant: misereVariant,
+			x:       []string{"0-0", "0-1", "0-2"},
+			o:       []string{"1-0", "1-1"},
+			want:    GameResult{Finished: true, WinnerSymbol: "O", LineSymbol: "X"},
+		},
+		{
+			name:      "blocked cell breaks the line",
+			variant:   classicVariant,
+			width:     4,
+			height:    4,
+			winLength: 3,
+			blocked:   []string{"0-1"},
+			x:         []string{"0-0", "0-2", "0-3"},
+			o:         []string{"1-0", "1-1"},
+		},
+		{
+			name:    "blocked cells count as taken",
+			variant: classicVariant,
+			blocked: []string{"1-1"},
+			x:       []string{"0-0", "0-2", "1-2", "2-1"},
+			o:       []string{"0-1", "1-0", "2-0", "2-2"},
+			want:    GameResult{Finished: true},
+		},
+		{
+			name:      "rectangular board",
+			variant:   classicVariant,
+			width:     5,
+			height:    3,
+			winLength: 4,
+			x:         []string{"1-1", "1-2", "1-3", "1-4"},
+			o:         []string{"0-0", "0-1", "2-0"},
+			want:      GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name:    "default line takes the short side",
+			variant: classicVariant,
+			width:   5,
+			height:  3,
+			x:       []string{"0-0", "0-1", "0-2"},
+			o:       []string{"1-0", "1-1"},
+			want:    GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(tt.variant, 3)
+			if tt.width > 0 {
+				room.BorderSize, room.Width, room.Height = tt.width, tt.width, tt.height
+			}
+			room.WinLength = tt.winLength
+			room.BlockedCells = tt.blocked
+			testPlace(room, "X", tt.x...)
+			testPlace(room, "O", tt.o...)
+			if got := room.Rules.Result(room); got != tt.want {
+				t.Errorf("Result() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// В варианте wild линию засчитывают игроку, который её собрал, каким бы знаком она ни была.
+func TestWildResult(t *testing.T) {
+	room := testRoom(wildVariant, 3)
+	testMove(t, room, "X", "O", "0-0")
+	testMove(t, room, "O", "X", "2-2")
+	testMove(t, room, "X", "O", "0-1")
+	testMove(t, room, "O", "X", "2-1")
+	testMove(t, room, "X", "O", "0-2")
+	want := GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "O"}
+	if got := room.Rules.Result(room); got != want {
+		t.Errorf("Result() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGridRuleSetLegalMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		variant  string
+		symbol   string
+		mark     string
+		id       string
+		gameOver bool
+		wantErr  bool
+	}{
+		{name: "free cell", variant: classicVariant, symbol: "X", mark: "X", id: "0-0"},
+		{name: "foreign mark", variant: classicVariant, symbol: "X", mark: "O", id: "0-0", wantErr: true},
+		{name: "not your turn", variant: classicVariant, symbol: "O", mark: "O", id: "0-0", wantErr: true},
+		{name: "taken cell", variant: classicVariant, symbol: "X", mark: "X", id: "1-1", wantErr: true},
+		{name: "blocked cell", variant: classicVariant, symbol: "X", mark: "X", id: "0-2", wantErr: true},
+		{name: "out of board", variant: classicVariant, symbol: "X", mark: "X", id: "3-0", wantErr: true},
+		{name: "non-canonical cell", variant: classicVariant, symbol: "X", mark: "X", id: "01-1", wantErr: true},
+		{name: "game is over", variant: classicVariant, symbol: "X", mark: "X", id: "0-0", gameOver: true, wantErr: true},
+		{name: "wild takes any mark", variant: wildVariant, symbol: "X", mark: "O", id: "0-0"},
+		{name: "wild keeps the turn order", variant: wildVariant, symbol: "O", mark: "X", id: "0-0", wantErr: true},
+		{name: "wild rejects other marks", variant: wildVariant, symbol: "X", mark: "triangle", id: "0-0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(tt.variant, 3)
+			room.BlockedCells = []string{"0-2"}
+			testPlace(room, "O", "1-1")
+			if tt.gameOver {
+				room.GameStatus = gameEndStatus
+			}
+			move := &Move{
+				UserID:     findUserBySymbol(room, tt.symbol).ID,
+				PositionID: tt.id,
+				Symbol:     tt.mark,
+			}
+			err := room.Rules.LegalMove(room, move)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LegalMove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (move.Player == nil || move.Player.Symbol != tt.symbol) {
+				t.Errorf("LegalMove() player = %+v, want the %s player", move.Player, tt.symbol)
+			}
+		})
+	}
+}
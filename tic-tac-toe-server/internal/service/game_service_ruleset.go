@@ -0,0 +1,173 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownVariant возвращается, если для варианта правил не зарегистрирован набор правил.
+var ErrUnknownVariant = errors.New("unknown variant")
+
+// Move описывает ход игрока, который проверяет и применяет набор правил.
+//
+// Поля:
+//   - UserID: игрок, сделавший ход
+//   - PositionID: клетка хода (формат зависит от варианта, например "i-j" или "l-i-j")
+//   - Symbol: поставленный знак
+//   - Data: исходные данные хода от клиента (например, "column" в режиме гравитации)
+//   - Player: игрок комнаты, заполняется набором правил при проверке хода
+type Move struct {
+	UserID     uuid.UUID
+	PositionID string
+	Symbol     string
+	Data       map[string]interface{}
+	Player     *ConnectedUser
+}
+
+// RuleSetSettings описывает свойства набора правил, важные при создании комнаты.
+//
+// Поля:
+//   - BorderSize: фиксированный размер поля (0 — размер настраивается)
+//   - Multiplayer: можно ли играть больше чем вдвоём
+//   - RequiresClientSupport: клиент должен явно заявить поддержку варианта при подключении
+//   - DefaultPieceLimit: лимит фишек игрока по умолчанию (0 — без ограничения)
+type RuleSetSettings struct {
+	BorderSize            uint64
+	Multiplayer           bool
+	RequiresClientSupport bool
+	DefaultPieceLimit     uint8
+}
+
+// RuleSet описывает вариант правил игры.
+// Обработчики WebSocket не знают о конкретных вариантах и делегируют им
+// подготовку поля, проверку и применение ходов, подсчёт итога и очередь ходов.
+type RuleSet interface {
+	// Name возвращает идентификатор варианта, под которым набор правил хранится в комнатах
+	Name() string
+	// Settings возвращает свойства набора правил
+	Settings() RuleSetSettings
+	// ValidateOptions проверяет настройки варианта, сохранённые в комнате
+	ValidateOptions(options json.RawMessage) error
+	// InitialBoard подготавливает поле комнаты к новой партии
+	InitialBoard(currentRoom *RoomServer)
+	// LegalMove проверяет ход и при необходимости уточняет его клетку (например, в режиме гравитации)
+	LegalMove(currentRoom *RoomServer, move *Move) error
+	// ApplyMove ставит фишку и возвращает снятые с поля фишки
+	ApplyMove(currentRoom *RoomServer, move *Move) []*SymbolPosition
+	// Result вычисляет итог партии по текущему полю
+	Result(currentRoom *RoomServer) GameResult
+	// NextTurn возвращает символ игрока, который ходит после указанного
+	NextTurn(currentRoom *RoomServer, symbol string) string
+}
+
+// stateReporter реализуют наборы правил, которым нужно передавать клиенту
+// дополнительное состояние поля вместе с позициями.
+type stateReporter interface {
+	StateData(currentRoom *RoomServer) map[string]interface{}
+}
+
+// ruleSets хранит зарегистрированные наборы правил по имени варианта.
+var ruleSets = struct {
+	sync.RWMutex
+	byName map[string]RuleSet
+}{
+	byName: make(map[string]RuleSet),
+}
+
+// RegisterRuleSet регистрирует набор правил под его именем.
+// Повторная регистрация с тем же именем заменяет прежний набор.
+func RegisterRuleSet(rules RuleSet) {
+	ruleSets.Lock()
+	defer ruleSets.Unlock()
+	ruleSets.byName[rules.Name()] = rules
+}
+
+// LookupRuleSet возвращает набор правил по имени варианта.
+func LookupRuleSet(variant string) (RuleSet, bool) {
+	ruleSets.RLock()
+	defer ruleSets.RUnlock()
+	rules, ok := ruleSets.byName[variant]
+	return rules, ok
+}
+
+// RuleSetNames возвращает имена зарегистрированных вариантов в алфавитном порядке.
+func RuleSetNames() []string {
+	ruleSets.RLock()
+	defer ruleSets.RUnlock()
+	names := make([]string, 0, len(ruleSets.byName))
+	for name := range ruleSets.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ruleSetFor возвращает набор правил варианта (classic, если вариант не зарегистрирован).
+func ruleSetFor(variant string) RuleSet {
+	if rules, ok := LookupRuleSet(variant); ok {
+		return rules
+	}
+	rules, _ := LookupRuleSet(classicVariant)
+	return rules
+}
+
+// roomRuleSet возвращает набор правил комнаты.
+func roomRuleSet(currentRoom *RoomServer) RuleSet {
+	if currentRoom.Rules != nil {
+		return currentRoom.Rules
+	}
+	return ruleSetFor(currentRoom.Variant)
+}
+
+// validateEmptyOptions разрешает только пустые настройки варианта.
+// Используется наборами правил, у которых нет собственных настроек.
+func validateEmptyOptions(options json.RawMessage) error {
+	if len(options) == 0 {
+		return nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(options, &values); err != nil {
+		return errors.New("variant options must be a JSON object")
+	}
+	if len(values) > 0 {
+		return errors.New("variant does not accept options")
+	}
+	return nil
+}
+
+// clearBoard очищает поле, счётчик и историю ходов комнаты.
+func clearBoard(currentRoom *RoomServer) {
+	currentRoom.ActiveBoard = nil
+	currentRoom.MoveCount = 0
+	currentRoom.Positions = make([]*SymbolPosition, 0)
+	currentRoom.History = nil
+}
+
+// placePosition ставит фишку хода на поле и передаёт ход следующему игроку
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//   - rules: набор правил, определяющий очередь ходов
+//   - move: проверенный ход
+//
+// Возвращает:
+//   - *SymbolPosition: поставленная фишка (наборы правил могут дополнить её поля)
+func placePosition(currentRoom *RoomServer, rules RuleSet, move *Move) *SymbolPosition {
+	currentRoom.MoveCount++
+	position := &SymbolPosition{
+		ID:     move.PositionID,
+		Symbol: move.Symbol,
+		UserID: &move.Player.ID,
+		Move:   currentRoom.MoveCount,
+	}
+	currentRoom.GameStatus = inProcessStatus
+	currentRoom.Positions = append(currentRoom.Positions, position)
+	currentRoom.History = append(currentRoom.History, position)
+	currentRoom.Turn = rules.NextTurn(currentRoom, move.Player.Symbol)
+	return position
+}
@@ -0,0 +1,206 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Параметры варианта Ultimate: поле 3x3 из подполей 3x3.
+const (
+	// ULTIMATE_SUB_BOARD_SIZE задаёт размер одного подполя.
+	ULTIMATE_SUB_BOARD_SIZE = 3
+	// ULTIMATE_BORDER_SIZE задаёт размер всего поля в клетках.
+	ULTIMATE_BORDER_SIZE = ULTIMATE_SUB_BOARD_SIZE * ULTIMATE_SUB_BOARD_SIZE
+)
+
+// drawBoardResult обозначает подполе, завершившееся ничьей.
+const drawBoardResult = "-"
+
+// ultimateSubBoard возвращает индекс подполя (0-8) для клетки общего поля.
+func ultimateSubBoard(row, column int) int {
+	return (row/ULTIMATE_SUB_BOARD_SIZE)*ULTIMATE_SUB_BOARD_SIZE + column/ULTIMATE_SUB_BOARD_SIZE
+}
+
+// ultimateLocalCell возвращает индекс клетки (0-8) внутри её подполя.
+// Этот индекс определяет подполе, в котором должен ходить соперник.
+func ultimateLocalCell(row, column int) int {
+	return (row%ULTIMATE_SUB_BOARD_SIZE)*ULTIMATE_SUB_BOARD_SIZE + column%ULTIMATE_SUB_BOARD_SIZE
+}
+
+// ultimateBoardResults вычисляет состояние каждого подполя
+//
+// Параметры:
+//   - board: общее поле 9x9
+//
+// Возвращает:
+//   - []string: для каждого подполя символ победителя, drawBoardResult при ничьей
+//     или пустую строку, если игра в подполе продолжается
+func ultimateBoardResults(board [][]string) []string {
+	results := make([]string, ULTIMATE_BORDER_SIZE)
+	for index := range results {
+		subBoard := make([][]string, ULTIMATE_SUB_BOARD_SIZE)
+		for row := range subBoard {
+			globalRow := (index/ULTIMATE_SUB_BOARD_SIZE)*ULTIMATE_SUB_BOARD_SIZE + row
+			globalColumn := (index % ULTIMATE_SUB_BOARD_SIZE) * ULTIMATE_SUB_BOARD_SIZE
+			subBoard[row] = board[globalRow][globalColumn : globalColumn+ULTIMATE_SUB_BOARD_SIZE]
+		}
+		if lineSymbol := findLine(subBoard, ULTIMATE_SUB_BOARD_SIZE); lineSymbol != "" {
+			results[index] = lineSymbol
+		} else if isBoardFull(subBoard) {
+			results[index] = drawBoardResult
+		}
+	}
+	return results
+}
+
+// evaluateUltimateResult вычисляет итог партии по мета-полю
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//
+// Правила:
+//   - Выигранные подполя образуют мета-поле 3x3
+//   - Победа за тем, кто собрал линию на мета-поле
+//   - Если все подполя завершены и линии нет — ничья
+func evaluateUltimateResult(currentRoom *RoomServer) GameResult {
+	results := ultimateBoardResults(buildBoard(currentRoom.Positions, ULTIMATE_BORDER_SIZE, ULTIMATE_BORDER_SIZE))
+	metaBoard := make([][]string, ULTIMATE_SUB_BOARD_SIZE)
+	finished := true
+	for row := range metaBoard {
+		metaBoard[row] = make([]string, ULTIMATE_SUB_BOARD_SIZE)
+		for column := range metaBoard[row] {
+			result := results[row*ULTIMATE_SUB_BOARD_SIZE+column]
+			if result == "" {
+				finished = false
+			}
+			if result != drawBoardResult {
+				metaBoard[row][column] = result
+			}
+		}
+	}
+	if lineSymbol := findLine(metaBoard, ULTIMATE_SUB_BOARD_SIZE); lineSymbol != "" {
+		return GameResult{
+			Finished:     true,
+			WinnerSymbol: lineSymbol,
+			LineSymbol:   lineSymbol,
+		}
+	}
+	return GameResult{
+		Finished: finished,
+	}
+}
+
+// validateUltimateStep проверяет ход по правилам Ultimate
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//   - row, column: клетка общего поля
+//
+// Возвращает:
+//   - error: ход вне активного подполя или в уже завершённое подполе
+func validateUltimateStep(currentRoom *RoomServer, row, column int) error {
+	subBoard := ultimateSubBoard(row, column)
+	if currentRoom.ActiveBoard != nil && *currentRoom.ActiveBoard != subBoard {
+		return errors.New("you must play in the active board")
+	}
+	results := ultimateBoardResults(buildBoard(currentRoom.Positions, ULTIMATE_BORDER_SIZE, ULTIMATE_BORDER_SIZE))
+	if results[subBoard] != "" {
+		return errors.New("this board is already finished")
+	}
+	return nil
+}
+
+// nextUltimateBoard определяет подполе для следующего хода
+//
+// Параметры:
+//   - currentRoom: игровая комната (ход уже применён)
+//   - row, column: клетка, в которую сделан ход
+//
+// Возвращает:
+//   - *int: индекс подполя или nil, если соперник может ходить в любое незавершённое подполе
+func nextUltimateBoard(currentRoom *RoomServer, row, column int) *int {
+	next := ultimateLocalCell(row, column)
+	results := ultimateBoardResults(buildBoard(currentRoom.Positions, ULTIMATE_BORDER_SIZE, ULTIMATE_BORDER_SIZE))
+	if results[next] != "" {
+		return nil
+	}
+	return &next
+}
+
+// ultimateRuleSet реализует вариант Ultimate: поле 9x9 из подполей 3x3,
+// клетка хода задаёт подполе, в котором должен ходить соперник.
+type ultimateRuleSet struct{}
+
+func init() {
+	RegisterRuleSet(&ultimateRuleSet{})
+}
+
+// Name возвращает идентификатор варианта.
+func (rules *ultimateRuleSet) Name() string {
+	return ultimateVariant
+}
+
+// Settings возвращает свойства варианта: поле фиксированного размера, только два игрока.
+func (rules *ultimateRuleSet) Settings() RuleSetSettings {
+	return RuleSetSettings{
+		BorderSize: ULTIMATE_BORDER_SIZE,
+	}
+}
+
+// ValidateOptions разрешает только пустые настройки.
+func (rules *ultimateRuleSet) ValidateOptions(options json.RawMessage) error {
+	return validateEmptyOptions(options)
+}
+
+// InitialBoard очищает поле и снимает ограничение на активное подполе.
+func (rules *ultimateRuleSet) InitialBoard(currentRoom *RoomServer) {
+	clearBoard(currentRoom)
+}
+
+// LegalMove проверяет ход: свой символ, свободная клетка, активное и незавершённое подполе.
+func (rules *ultimateRuleSet) LegalMove(currentRoom *RoomServer, move *Move) error {
+	player, err := validateMark(currentRoom, move.UserID, move.Symbol, false)
+	if err != nil {
+		return err
+	}
+	move.Player = player
+	row, column, err := validateGridCell(currentRoom, move.PositionID)
+	if err != nil {
+		return err
+	}
+	if err := validateFreeCell(currentRoom, move.PositionID); err != nil {
+		return err
+	}
+	return validateUltimateStep(currentRoom, row, column)
+}
+
+// ApplyMove ставит фишку, запоминает её подполе и определяет подполе для следующего хода.
+func (rules *ultimateRuleSet) ApplyMove(currentRoom *RoomServer, move *Move) []*SymbolPosition {
+	position := placePosition(currentRoom, rules, move)
+	row, column, _ := parsePositionID(move.PositionID)
+	subBoard := ultimateSubBoard(row, column)
+	position.Board = &subBoard
+	currentRoom.ActiveBoard = nextUltimateBoard(currentRoom, row, column)
+	return nil
+}
+
+// Result вычисляет итог партии по мета-полю.
+func (rules *ultimateRuleSet) Result(currentRoom *RoomServer) GameResult {
+	return evaluateUltimateResult(currentRoom)
+}
+
+// NextTurn передаёт ход сопернику.
+func (rules *ultimateRuleSet) NextTurn(currentRoom *RoomServer, symbol string) string {
+	return nextTurnSymbol(currentRoom, symbol)
+}
+
+// StateData передаёт активное подполе (active_board, null — любое) и состояние подполей (board_results).
+func (rules *ultimateRuleSet) StateData(currentRoom *RoomServer) map[string]interface{} {
+	return map[string]interface{}{
+		"active_board": currentRoom.ActiveBoard,
+		"board_results": ultimateBoardResults(
+			buildBoard(currentRoom.Positions, ULTIMATE_BORDER_SIZE, ULTIMATE_BORDER_SIZE),
+		),
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import "testing"
+
+func TestUltimateActiveBoard(t *testing.T) {
+	room := testRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+	testMove(t, room, "X", "X", "0-0")
+	if room.ActiveBoard == nil || *room.ActiveBoard != 0 {
+		t.Fatalf("ApplyMove() active board = %v, want 0", room.ActiveBoard)
+	}
+	move := &Move{UserID: room.Users[1].ID, PositionID: "4-4", Symbol: "O"}
+	if err := room.Rules.LegalMove(room, move); err == nil {
+		t.Errorf("LegalMove() outside the active board error = nil")
+	}
+	testMove(t, room, "O", "O", "1-1")
+	if room.ActiveBoard == nil || *room.ActiveBoard != 4 {
+		t.Errorf("ApplyMove() active board = %v, want 4", room.ActiveBoard)
+	}
+}
+
+// Ход, отправляющий соперника в завершённое подполе, открывает ему все подполя,
+// а ходить в завершённое подполе нельзя.
+func TestUltimateFinishedBoard(t *testing.T) {
+	room := testRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+	testPlace(room, "X", "0-0", "0-1", "0-2", "6-6")
+	testPlace(room, "O", "3-3", "4-4", "7-7")
+	room.Turn = "O"
+	testMove(t, room, "O", "O", "3-0")
+	if room.ActiveBoard != nil {
+		t.Errorf("ApplyMove() active board = %d, want any board", *room.ActiveBoard)
+	}
+	move := &Move{UserID: room.Users[0].ID, PositionID: "1-1", Symbol: "X"}
+	if err := room.Rules.LegalMove(room, move); err == nil {
+		t.Errorf("LegalMove() into a finished board error = nil")
+	}
+}
+
+func TestUltimateResult(t *testing.T) {
+	tests := []struct {
+		name string
+		x    []string
+		o    []string
+		want GameResult
+	}{
+		{
+			name: "meta diagonal",
+			x:    []string{"0-0", "0-1", "0-2", "3-3", "3-4", "3-5", "6-6", "6-7", "6-8"},
+			o:    []string{"1-0", "1-1", "4-3", "4-4", "7-6", "7-7"},
+			want: GameResult{Finished: true, WinnerSymbol: "X", LineSymbol: "X"},
+		},
+		{
+			name: "two boards are not a line",
+			x:    []string{"0-0", "0-1", "0-2", "3-3", "3-4", "3-5"},
+			o:    []string{"1-0", "1-1", "4-3", "4-4"},
+		},
+		{
+			name: "drawn board does not count for anyone",
+			x:    []string{"0-0", "0-2", "1-0", "2-1", "2-2", "3-3", "3-4", "3-5"},
+			o:    []string{"0-1", "1-1", "1-2", "2-0", "6-6", "6-7", "6-8"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := testRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+			testPlace(room, "X", tt.x...)
+			testPlace(room, "O", tt.o...)
+			if got := room.Rules.Result(room); got != tt.want {
+				t.Errorf("Result() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUltimateBoardResults(t *testing.T) {
+	room := testRoom(ultimateVariant, ULTIMATE_BORDER_SIZE)
+	testPlace(room, "X", "0-0", "0-2", "1-0", "2-1", "2-2", "3-3", "4-4", "5-5")
+	testPlace(room, "O", "0-1", "1-1", "1-2", "2-0")
+	results := ultimateBoardResults(buildBoard(room.Positions, ULTIMATE_BORDER_SIZE, ULTIMATE_BORDER_SIZE))
+	if results[0] != drawBoardResult || results[4] != "X" || results[8] != "" {
+		t.Errorf("ultimateBoardResults() = %q, want draw in 0, X in 4, play in 8", results)
+	}
+}
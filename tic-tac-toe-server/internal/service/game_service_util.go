@@ -3,8 +3,11 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"log/slog"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -37,19 +40,110 @@ func opositeSymbol(mainSymbol string) string {
 // createRoom создает новую игровую комнату если она не существует
 //
 // Параметры:
-//   - roomID: ID создаваемой комнаты
+//   - room: данные создаваемой комнаты
 //
 // Действия:
 //   - Инициализирует комнату с дефолтными значениями если ее не существует
-func (ws *WSServer) createRoom(roomID uint64) {
-	if ws.Rooms[roomID] == nil {
-		ws.Rooms[roomID] = &RoomServer{
-			ID:         roomID,
-			Users:      make([]*ConnectedUser, 0),
-			Positions:  make([]*SymbolPosition, 0),
-			BorderSize: DEFAULT_BORDER_SIZE,
+//   - Переносит создателя, вместимость, размеры поля, заблокированные клетки,
+//     правило первого хода, вариант правил, режим гравитации, длину выигрышной
+//     линии, лимит фишек и подсадку бота из настроек комнаты
+//   - Находит набор правил варианта (classic, если вариант не зарегистрирован)
+//   - Для вариантов с фиксированным полем устанавливает их размер, для комнат
+//     больше чем на двух игроков увеличивает начальный размер поля
+//   - Разбирает начальную позицию комнаты, если она задана
+//   - Подготавливает поле набором правил (пользовательские варианты переносят
+//     в комнату параметры из своего описания, двумерные ставят фишки начальной позиции)
+//
+// Возвращает:
+//   - error: ErrInvalidStartPosition, если начальную позицию не удалось разобрать;
+//     живая комната тогда не создаётся
+func (ws *WSServer) createRoom(room *common.RoomSessionResponse) error {
+	if ws.Rooms[room.ID] == nil {
+		var startPosition *common.BoardPosition
+		if room.StartPosition != "" {
+			position, err := DecodePosition(room.StartPosition)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidStartPosition, err)
+			}
+			startPosition = position
+		}
+		capacity := uint64(room.Capacity)
+		if capacity < DEFAULT_CAPACITY {
+			capacity = DEFAULT_CAPACITY
+		}
+		borderSize := defaultBorderSize(capacity)
+		if room.Width > 0 && room.Height > 0 {
+			borderSize = uint64(max(room.Width, room.Height))
 		}
+		rules := ruleSetFor(room.Variant)
+		if size := rules.Settings().BorderSize; size > 0 {
+			borderSize = size
+		}
+		ws.Rooms[room.ID] = &RoomServer{
+			ID:              room.ID,
+			CreatorID:       room.CreatorID,
+			Capacity:        capacity,
+			Users:           make([]*ConnectedUser, 0),
+			Positions:       make([]*SymbolPosition, 0),
+			BorderSize:      borderSize,
+			Width:           uint64(room.Width),
+			Height:          uint64(room.Height),
+			BlockedCells:    room.BlockedCells,
+			FirstMovePolicy: room.FirstMovePolicy,
+			Variant:         room.Variant,
+			VariantOptions:  room.VariantOptions,
+			Rules:           rules,
+			Gravity:         room.Gravity,
+			WinLength:       uint64(room.WinLength),
+			PieceLimit:      uint64(room.PieceLimit),
+			IsRated:         room.IsRated,
+			BotFill:         room.BotFill,
+			BotAfter:        uint64(room.BotAfter),
+			StartPosition:   startPosition,
+			Mu:              &sync.Mutex{},
+		}
+		rules.InitialBoard(ws.Rooms[room.ID])
 	}
+	return nil
+}
+
+// lockRoom захватывает мьютекс комнаты и возвращает функцию его освобождения
+//
+// Особенности:
+//   - Если комнаты ещё нет, ничего не захватывает
+//   - Мьютекс комнаты берётся до ws.Mu и никогда — при удерживаемом ws.Mu
+func (ws *WSServer) lockRoom(roomID uint64) func() {
+	ws.Mu.Lock()
+	currentRoom := ws.Rooms[roomID]
+	ws.Mu.Unlock()
+	if currentRoom == nil {
+		return func() {}
+	}
+	currentRoom.Mu.Lock()
+	return currentRoom.Mu.Unlock
+}
+
+// roomState возвращает число игроков, состояние партии и число ходов живой комнаты
+//
+// Возвращает:
+//   - int, string, uint64: игроки в комнате, состояние партии и номер последнего хода
+//   - bool: false, если комнаты нет
+//
+// Особенности:
+//   - Состояние читается под мьютексом комнаты (см. lockRoom), игроки — ещё и под ws.Mu
+func (ws *WSServer) roomState(roomID uint64) (int, string, uint64, bool) {
+	ws.Mu.Lock()
+	currentRoom := ws.Rooms[roomID]
+	ws.Mu.Unlock()
+	if currentRoom == nil {
+		return 0, "", 0, false
+	}
+	currentRoom.Mu.Lock()
+	defer currentRoom.Mu.Unlock()
+	ws.Mu.Lock()
+	players := len(currentRoom.Users)
+	ws.Mu.Unlock()
+	return players, currentRoom.GameStatus, currentRoom.MoveCount, true
 }
 
 // addUser добавляет пользователя в комнату
@@ -59,26 +153,53 @@ func (ws *WSServer) createRoom(roomID uint64) {
 //   - room: целевая комната
 //   - conn: WebSocket соединение
 //
+// Возвращает:
+//   - error: ошибка создания живой комнаты (см. createRoom)
+//
 // Особенности:
 //   - Использует мьютекс для потокобезопасности
-//   - Не добавляет пользователя если он уже в комнате
-func (ws *WSServer) addUser(currentUser *common.User, room *common.RoomSessionResponse, conn *websocket.Conn) {
+//   - Сажает пользователя в комнату через seatUser
+func (ws *WSServer) addUser(currentUser *common.User, room *common.RoomSessionResponse, conn *websocket.Conn) error {
 	ws.Mu.Lock()
 	defer ws.Mu.Unlock()
-	ws.createRoom(room.ID)
-
-	if !ws.isUserInRoom(currentUser.ID, room.ID) {
-		ws.Rooms[room.ID].Users = append(
-			ws.Rooms[room.ID].Users,
-			&ConnectedUser{
-				ID:          currentUser.ID,
-				Name:        currentUser.Name,
-				Symbol:      "",
-				Connection:  conn,
-				IsConnected: true,
-			},
-		)
+	if err := ws.createRoom(room); err != nil {
+		return err
+	}
+	ws.seatUser(room.ID, &ConnectedUser{
+		ID:          currentUser.ID,
+		Name:        currentUser.Name,
+		Symbol:      "",
+		Connection:  conn,
+		IsConnected: true,
+	})
+	return nil
+}
+
+// seatUser сажает игрока в комнату — общий путь входа людей и бота
+//
+// Параметры:
+//   - roomID: ID комнаты
+//   - user: подключающийся игрок
+//
+// Возвращает:
+//   - bool: true, если игрок в комнате (уже был в ней или занял свободное место),
+//     false, если комнаты нет или в ней уже Capacity игроков
+//
+// Особенности:
+//   - Вызывается под ws.Mu
+func (ws *WSServer) seatUser(roomID uint64, user *ConnectedUser) bool {
+	currentRoom := ws.Rooms[roomID]
+	if currentRoom == nil {
+		return false
+	}
+	if ws.isUserInRoom(user.ID, roomID) {
+		return true
 	}
+	if len(currentRoom.Users) >= roomCapacity(currentRoom) {
+		return false
+	}
+	currentRoom.Users = append(currentRoom.Users, user)
+	return true
 }
 
 // isUserInRoom проверяет наличие пользователя в комнате
@@ -110,12 +231,13 @@ func (ws *WSServer) isUserInRoom(userID uuid.UUID, roomID uint64) bool {
 //   - conn: WebSocket соединение для отправки ошибки
 //
 // Возвращает:
-//   - bool: true если комната заполнена (2 игрока) и пользователь не является участником
+//   - bool: true если в комнате уже Capacity игроков и пользователь не является участником
 //
 // Дополнительно:
 //   - Отправляет сообщение об ошибке если комната заполнена
 func (ws *WSServer) isRoomFull(userID uuid.UUID, roomID uint64, conn *websocket.Conn) bool {
-	if ws.Rooms[roomID] != nil && len(ws.Rooms[roomID].Users) == 2 && !ws.isUserInRoom(userID, roomID) {
+	currentRoom := ws.Rooms[roomID]
+	if currentRoom != nil && len(currentRoom.Users) >= roomCapacity(currentRoom) && !ws.isUserInRoom(userID, roomID) {
 		err := conn.WriteMessage(
 			websocket.CloseMessage,
 			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "room is full"),
@@ -131,53 +253,172 @@ func (ws *WSServer) isRoomFull(userID uuid.UUID, roomID uint64, conn *websocket.
 	return false
 }
 
-// setSecondUserSymbol устанавливает символ второму игроку
+// validateMark проверяет, что игрок может сейчас поставить указанный знак
 //
 // Параметры:
-//   - roomId: ID комнаты
+//   - currentRoom: игровая комната
+//   - userID: ID игрока, сделавшего ход
+//   - symbol: знак, которым сделан ход
+//   - anyMark: игрок может поставить любой знак (X или O), а не только свой символ
 //
-// Логика:
-//  1. Если первый игрок выбрал символ, второму назначается противоположный
-//  2. Отправляет уведомление второму игроку о назначенном символе
-func (ws *WSServer) setSecondUserSymbol(roomId uint64) {
-	currentRoom, exists := ws.Rooms[roomId]
+// Возвращает:
+//   - *ConnectedUser: игрок, сделавший ход
+//   - error: причину, по которой ход отклонён, или nil
+//
+// Особенности:
+//   - Очередь проверяется по символу игрока, а не по знаку хода
+func validateMark(currentRoom *RoomServer, userID uuid.UUID, symbol string, anyMark bool) (*ConnectedUser, error) {
+	if currentRoom.GameStatus == gameEndStatus {
+		return nil, errors.New("game is over")
+	}
+	player := findConnectedUser(currentRoom, userID)
+	if player == nil || player.Symbol == "" {
+		return nil, errors.New("it is not your symbol")
+	}
+	if anyMark {
+		if opositeSymbol(symbol) == "" {
+			return nil, errors.New("mark must be X or O")
+		}
+	} else if player.Symbol != symbol {
+		return nil, errors.New("it is not your symbol")
+	}
+	if currentRoom.Turn != "" && currentRoom.Turn != player.Symbol {
+		return nil, errors.New("it is not your turn")
+	}
+	return player, nil
+}
+
+// validateFreeCell проверяет, что клетка ещё не занята.
+// ID клетки должен быть уже проверен на каноническую запись, иначе сравнение строк
+// пропустит ход в занятую клетку.
+func validateFreeCell(currentRoom *RoomServer, positionID string) error {
+	for _, position := range currentRoom.Positions {
+		if position.ID == positionID {
+			return errors.New("cell is already taken")
+		}
+	}
+	return nil
+}
+
+// validateGridCell проверяет, что клетка "i-j" лежит на двумерном поле и не заблокирована
+//
+// Возвращает:
+//   - int, int: строка и столбец клетки
+//   - error: если клетка вне поля или заблокирована
+func validateGridCell(currentRoom *RoomServer, positionID string) (int, int, error) {
+	row, column, ok := parsePositionID(positionID)
+	if !ok || row < 0 || column < 0 || row >= boardHeight(currentRoom) || column >= boardWidth(currentRoom) {
+		return 0, 0, errors.New("cell is out of board")
+	}
+	if isBlockedCell(currentRoom, positionID) {
+		return 0, 0, errors.New("cell is blocked")
+	}
+	return row, column, nil
+}
+
+// isVariantSupported проверяет, может ли клиент отобразить вариант правил
+//
+// Параметры:
+//   - rules: набор правил комнаты
+//   - clientVariants: варианты, заявленные клиентом при подключении
+//
+// Особенности:
+//   - Двумерные варианты поддерживаются всеми клиентами
+//   - Варианты с RequiresClientSupport (например, Qubic) требуют явного объявления,
+//     иначе старый клиент нарисует сломанное поле
+func isVariantSupported(rules RuleSet, clientVariants []string) bool {
+	if !rules.Settings().RequiresClientSupport {
+		return true
+	}
+	for _, clientVariant := range clientVariants {
+		if clientVariant == rules.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// removeUser удаляет игрока из комнаты.
+func (ws *WSServer) removeUser(userID uuid.UUID, roomID uint64) {
+	ws.Mu.Lock()
+	defer ws.Mu.Unlock()
+	currentRoom, exists := ws.Rooms[roomID]
 	if !exists {
 		return
 	}
-	if len(currentRoom.Users) == 2 {
-		var prev *ConnectedUser
-		for _, user := range currentRoom.Users {
-			if user.Symbol != "" {
-				if prev != nil {
-					prev.Symbol = opositeSymbol(user.Symbol)
-				}
-				break
-			}
-			prev = user
+	users := make([]*ConnectedUser, 0, len(currentRoom.Users))
+	for _, user := range currentRoom.Users {
+		if user.ID != userID {
+			users = append(users, user)
 		}
 	}
-	firstPlayerSymbol := ""
-	secondarySymbol := ""
+	currentRoom.Users = users
+}
+
+// positionsResponse формирует сообщение с текущими позициями на поле
+//
+// Параметры:
+//   - currentRoom: игровая комната
+//   - turn: символ, которым должен быть сделан следующий ход
+//
+// Особенности:
+//   - Если набор правил комнаты передаёт дополнительное состояние поля
+//     (например, подполя Ultimate), оно добавляется к данным сообщения
+func positionsResponse(currentRoom *RoomServer, turn string) *GameReponse {
+	data := map[string]interface{}{
+		"positions": currentRoom.Positions,
+	}
+	if reporter, ok := roomRuleSet(currentRoom).(stateReporter); ok {
+		for key, value := range reporter.StateData(currentRoom) {
+			data[key] = value
+		}
+	}
+	return &GameReponse{
+		Action: getPositionsAction,
+		Data:   data,
+		Symbol: turn,
+	}
+}
+
+// setMissingSymbols назначает символы игрокам, которые их ещё не получили
+//
+// Параметры:
+//   - roomId: ID комнаты
+//
+// Логика:
+//  1. Если символы в партии уже розданы, игрок без символа получает
+//     первый свободный символ комнаты и встаёт в конец очереди ходов
+//  2. Отправляет уведомление игроку о назначенном символе
+func (ws *WSServer) setMissingSymbols(roomId uint64) {
+	currentRoom, exists := ws.Rooms[roomId]
+	if !exists || len(currentRoom.TurnOrder) == 0 {
+		return
+	}
 	for _, user := range currentRoom.Users {
 		if user.Symbol != "" {
-			firstPlayerSymbol = user.Symbol
+			continue
 		}
-		if user.Symbol == "" {
-			secondarySymbol = opositeSymbol(firstPlayerSymbol)
-			if secondarySymbol != "" {
-				user.Symbol = secondarySymbol
-				resp := &GameReponse{
-					Action: syncSymbolAction,
-					Symbol: secondarySymbol,
-				}
-				raw, err := json.Marshal(resp)
-				if err == nil && user.Connection != nil {
-					user.Connection.WriteMessage(
-						websocket.TextMessage,
-						raw,
-					)
-				}
+		for _, symbol := range roomSymbols(currentRoom) {
+			if findUserBySymbol(currentRoom, symbol) != nil {
+				continue
 			}
+			user.Symbol = symbol
+			currentRoom.TurnOrder = append(currentRoom.TurnOrder, symbol)
+			break
+		}
+		if user.Symbol == "" {
+			continue
+		}
+		resp := &GameReponse{
+			Action: syncSymbolAction,
+			Symbol: user.Symbol,
+		}
+		raw, err := json.Marshal(resp)
+		if err == nil && user.Connection != nil {
+			user.Connection.WriteMessage(
+				websocket.TextMessage,
+				raw,
+			)
 		}
 	}
 }
@@ -218,6 +459,40 @@ func (ws *WSServer) broadcastMessageToOther(
 	}
 }
 
+// sendMessageToUser отправляет сообщение одному игроку комнаты
+//
+// Параметры:
+//   - userID: ID игрока, которому нужно отправить сообщение
+//   - room: целевая комната
+//   - raw: сырое сообщение для отправки
+//
+// Особенности:
+//   - Использует мьютекс для потокобезопасности
+//   - Логирует ошибки отправки
+func (ws *WSServer) sendMessageToUser(
+	userID uuid.UUID,
+	room *common.RoomSessionResponse,
+	raw []byte,
+) {
+	ws.Mu.Lock()
+	defer ws.Mu.Unlock()
+	roomData, ok := ws.Rooms[room.ID]
+	if !ok || roomData == nil {
+		log.Printf("sendMessageToUser: room %d not found", room.ID)
+		return
+	}
+	user := findConnectedUser(roomData, userID)
+	if user == nil || user.Connection == nil {
+		return
+	}
+	if err := user.Connection.WriteMessage(
+		websocket.TextMessage,
+		raw,
+	); err != nil {
+		log.Println("WriteMessage error:", err)
+	}
+}
+
 // broadcastMessageToAll рассылает сообщение всем игрокам комнаты
 //
 // Параметры:
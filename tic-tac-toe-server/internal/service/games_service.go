@@ -0,0 +1,408 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// Параметры разбора партий.
+const (
+	// ANALYSIS_MOVE_TIME_BUDGET задаёт время на анализ одной позиции партии в миллисекундах.
+	ANALYSIS_MOVE_TIME_BUDGET = 200
+	// ANALYSIS_QUEUE_SIZE задаёт размер очереди партий, ожидающих разбора.
+	ANALYSIS_QUEUE_SIZE = 256
+	// ANALYSIS_RESCAN_INTERVAL задаёт, как часто ищутся партии, не попавшие в очередь.
+	ANALYSIS_RESCAN_INTERVAL = time.Minute
+	// INACCURACY_MARGIN задаёт, на сколько оценка хода может уступать лучшей, чтобы ход считался хорошим.
+	INACCURACY_MARGIN = 4
+)
+
+// Состояния разбора партии.
+const (
+	pendingAnalysisStatus     = "pending"
+	doneAnalysisStatus        = "done"
+	unsupportedAnalysisStatus = "unsupported"
+	failedAnalysisStatus      = "failed"
+)
+
+// Оценки ходов в разборе партии.
+const (
+	bestMoveLabel       = "best"
+	goodMoveLabel       = "good"
+	inaccuracyMoveLabel = "inaccuracy"
+	blunderMoveLabel    = "blunder"
+	missedWinMoveLabel  = "missed_win"
+)
+
+// moveLabelPoints задаёт вклад хода каждой оценки в точность игрока.
+var moveLabelPoints = map[string]float64{
+	bestMoveLabel:       1,
+	goodMoveLabel:       0.9,
+	inaccuracyMoveLabel: 0.6,
+	missedWinMoveLabel:  0.2,
+	blunderMoveLabel:    0,
+}
+
+// Ошибки получения партии.
+var (
+	// ErrGameNotFound возвращается, если партия не найдена.
+	ErrGameNotFound = errors.New("game not found")
+	// ErrGameAccessDenied возвращается, если пользователь не участвовал в партии.
+	ErrGameAccessDenied = errors.New("you did not play in this game")
+)
+
+// GameService сохраняет завершённые партии и разбирает их в фоне.
+type GameService struct {
+	repo     repository.GameRepository
+	analysis *AnalysisService
+	puzzles  *PuzzleService
+	queue    chan uint64
+}
+
+// NewGameService создаёт новый экземпляр GameService.
+// Разбор партий начинается после запуска RunAnalysisWorker,
+// из разобранных партий сервис задач отбирает задачи.
+func NewGameService(repo repository.GameRepository, analysis *AnalysisService, puzzles *PuzzleService) *GameService {
+	return &GameService{
+		repo:     repo,
+		analysis: analysis,
+		puzzles:  puzzles,
+		queue:    make(chan uint64, ANALYSIS_QUEUE_SIZE),
+	}
+}
+
+// Record сохраняет завершённую партию комнаты и ставит её в очередь на разбор
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - currentRoom: комната с историей ходов партии
+//   - result: итог партии
+//
+// Возвращает:
+//   - *common.Game: сохранённая партия
+//   - error: ошибка сохранения
+//
+// Особенности:
+//   - Партии, которые анализатор не поддерживает, сохраняются с состоянием unsupported
+//   - Если очередь заполнена, партия останется в состоянии pending и будет найдена при следующем поиске
+func (service *GameService) Record(ctx context.Context, currentRoom *RoomServer, result GameResult) (*common.Game, error) {
+	game := &common.Game{
+		RoomID:       currentRoom.ID,
+		Variant:      currentRoom.Variant,
+		Width:        uint8(boardWidth(currentRoom)),
+		Height:       uint8(boardHeight(currentRoom)),
+		WinLength:    uint8(winLength(currentRoom)),
+		Gravity:      currentRoom.Gravity,
+		PieceLimit:   uint8(currentRoom.PieceLimit),
+		BlockedCells: currentRoom.BlockedCells,
+		Players:      make([]common.GamePlayer, 0, len(currentRoom.Users)),
+		Moves:        make([]common.GameMove, 0, len(currentRoom.History)),
+		WinnerSymbol: result.WinnerSymbol,
+	}
+	if game.BlockedCells == nil {
+		game.BlockedCells = []string{}
+	}
+	if currentRoom.StartPosition != nil {
+		game.StartPosition = EncodePosition(currentRoom.StartPosition)
+	}
+	for _, user := range currentRoom.Users {
+		game.Players = append(game.Players, common.GamePlayer{
+			UserID: user.ID,
+			Name:   user.Name,
+			Symbol: user.Symbol,
+		})
+	}
+	for _, position := range currentRoom.History {
+		move := common.GameMove{
+			Move:       position.Move,
+			PositionID: position.ID,
+			Symbol:     position.Symbol,
+		}
+		if position.UserID != nil {
+			move.UserID = *position.UserID
+		}
+		game.Moves = append(game.Moves, move)
+	}
+	game.AnalysisStatus = unsupportedAnalysisStatus
+	if isAnalyzable(game) {
+		game.AnalysisStatus = pendingAnalysisStatus
+	}
+	id, err := service.repo.Create(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	game.ID = id
+	if game.AnalysisStatus == pendingAnalysisStatus {
+		service.enqueue(id)
+	}
+	return game, nil
+}
+
+// GetAnalysis возвращает разбор партии её участнику
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - id: идентификатор партии
+//
+// Возвращает:
+//   - *common.GameAnalysisResponse: состояние разбора и сам разбор, если он готов
+//   - error: ErrGameNotFound, ErrGameAccessDenied или ошибка запроса
+func (service *GameService) GetAnalysis(ctx context.Context, id uint64) (*common.GameAnalysisResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	game, err := service.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, err
+	}
+	isPlayer := false
+	for _, player := range game.Players {
+		if player.UserID == user.ID {
+			isPlayer = true
+			break
+		}
+	}
+	if !isPlayer {
+		return nil, ErrGameAccessDenied
+	}
+	return &common.GameAnalysisResponse{
+		GameID:        game.ID,
+		Variant:       game.Variant,
+		StartPosition: game.StartPosition,
+		Players:       game.Players,
+		WinnerSymbol:  game.WinnerSymbol,
+		Status:        game.AnalysisStatus,
+		Analysis:      game.Analysis,
+	}, nil
+}
+
+// RunAnalysisWorker разбирает партии из очереди, пока не отменён контекст.
+// При запуске и раз в ANALYSIS_RESCAN_INTERVAL в очередь добавляются партии,
+// оставшиеся в состоянии pending (например, после перезапуска сервера).
+func (service *GameService) RunAnalysisWorker(ctx context.Context) {
+	ticker := time.NewTicker(ANALYSIS_RESCAN_INTERVAL)
+	defer ticker.Stop()
+	service.enqueuePending(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-service.queue:
+			service.analyzeGame(ctx, id)
+		case <-ticker.C:
+			service.enqueuePending(ctx)
+		}
+	}
+}
+
+// enqueue добавляет партию в очередь разбора, не блокируясь при заполненной очереди.
+func (service *GameService) enqueue(id uint64) {
+	select {
+	case service.queue <- id:
+	default:
+	}
+}
+
+// enqueuePending добавляет в очередь партии, ожидающие разбора.
+func (service *GameService) enqueuePending(ctx context.Context) {
+	ids, err := service.repo.FindIdsByAnalysisStatus(ctx, pendingAnalysisStatus, ANALYSIS_QUEUE_SIZE)
+	if err != nil {
+		slog.Error("[games]enqueuePending", slog.String("error", err.Error()))
+		return
+	}
+	for _, id := range ids {
+		service.enqueue(id)
+	}
+}
+
+// analyzeGame разбирает партию, сохраняет результат и отбирает из разбора задачи.
+// Партия, уже разобранная ранее (например, попавшая в очередь дважды), пропускается.
+func (service *GameService) analyzeGame(ctx context.Context, id uint64) {
+	game, err := service.repo.FindById(ctx, id)
+	if err != nil {
+		slog.Error("[games]analyzeGame", slog.Uint64("game_id", id), slog.String("error", err.Error()))
+		return
+	}
+	if game.AnalysisStatus != pendingAnalysisStatus {
+		return
+	}
+	status := doneAnalysisStatus
+	analysis, err := service.AnalyzeGame(ctx, game)
+	if err != nil {
+		slog.Error("[games]analyzeGame", slog.Uint64("game_id", id), slog.String("error", err.Error()))
+		status = failedAnalysisStatus
+	}
+	if err := service.repo.SaveAnalysis(ctx, id, status, analysis); err != nil {
+		slog.Error("[games]analyzeGame", slog.Uint64("game_id", id), slog.String("error", err.Error()))
+		return
+	}
+	if status == doneAnalysisStatus && service.puzzles != nil {
+		service.puzzles.MineGame(ctx, game, analysis)
+	}
+}
+
+// AnalyzeGame разбирает партию ход за ходом
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - game: партия, поддерживаемая анализатором
+//
+// Логика:
+//  1. Ходы ставятся на поле после фишек начальной позиции партии (если она задана)
+//  2. Каждая позиция перед ходом оценивается анализатором (ANALYSIS_MOVE_TIME_BUDGET на позицию)
+//  3. Сделанный ход сравнивается с лучшим и получает оценку (см. moveLabel)
+//  4. Точность игрока — средний вклад его ходов (moveLabelPoints) в процентах
+//
+// Возвращает:
+//   - *common.GameAnalysis: разбор партии
+//   - error: если ход партии невозможен в позиции
+func (service *GameService) AnalyzeGame(ctx context.Context, game *common.Game) (*common.GameAnalysis, error) {
+	variant := classicVariant
+	if game.Variant == misereVariant {
+		variant = misereVariant
+	}
+	analysis := &common.GameAnalysis{
+		Moves:    make([]*common.MoveAnalysis, 0, len(game.Moves)),
+		Accuracy: make(map[string]float64),
+		Exact:    true,
+	}
+	points := make(map[string]float64)
+	counts := make(map[string]int)
+	positions, err := startPositions(game)
+	if err != nil {
+		return nil, err
+	}
+	for _, move := range game.Moves {
+		evaluation, err := service.analysis.Analyze(ctx, common.AnalysisRequest{
+			BorderSize: game.Width,
+			WinLength:  game.WinLength,
+			Positions:  positions,
+			Turn:       move.Symbol,
+			Variant:    variant,
+			TimeBudget: ANALYSIS_MOVE_TIME_BUDGET,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var played *common.MoveEvaluation
+		for _, candidate := range evaluation.Moves {
+			if candidate.PositionID == move.PositionID {
+				played = candidate
+				break
+			}
+		}
+		if played == nil {
+			return nil, fmt.Errorf("move %d to %q is not possible", move.Move, move.PositionID)
+		}
+		label := moveLabel(evaluation, played)
+		analysis.Moves = append(analysis.Moves, &common.MoveAnalysis{
+			Move:       move.Move,
+			PositionID: move.PositionID,
+			Symbol:     move.Symbol,
+			Label:      label,
+			Score:      played.Score,
+			BestScore:  evaluation.Score,
+			BestMoves:  evaluation.BestMoves,
+			Outcome:    evaluation.Outcome,
+		})
+		analysis.Exact = analysis.Exact && evaluation.Exact
+		points[move.Symbol] += moveLabelPoints[label]
+		counts[move.Symbol]++
+		positions = append(positions, common.AnalysisPosition{ID: move.PositionID, Symbol: move.Symbol})
+	}
+	for symbol, count := range counts {
+		analysis.Accuracy[symbol] = math.Round(points[symbol]/float64(count)*1000) / 10
+	}
+	return analysis, nil
+}
+
+// isAnalyzable проверяет, что анализатор умеет разбирать партию:
+// двое игроков X и O на квадратном поле без гравитации, лимита фишек
+// и заблокированных клеток по правилам classic или misere
+// (в начальной позиции тоже только фишки X и O).
+func isAnalyzable(game *common.Game) bool {
+	if game.Variant != classicVariant && game.Variant != misereVariant {
+		return false
+	}
+	if game.Width != game.Height || game.Width < 3 || game.Width > 15 {
+		return false
+	}
+	if game.Gravity || game.PieceLimit > 0 || len(game.BlockedCells) > 0 || len(game.Players) != 2 {
+		return false
+	}
+	for _, move := range game.Moves {
+		if move.Symbol != "X" && move.Symbol != "O" {
+			return false
+		}
+	}
+	pieces, err := startPositions(game)
+	if err != nil {
+		return false
+	}
+	for _, piece := range pieces {
+		if piece.Symbol != "X" && piece.Symbol != "O" {
+			return false
+		}
+	}
+	return len(game.Moves) > 0
+}
+
+// startPositions возвращает фишки начальной позиции партии (пустой слайс, если партия
+// началась с пустого поля).
+func startPositions(game *common.Game) ([]common.AnalysisPosition, error) {
+	if game.StartPosition == "" {
+		return make([]common.AnalysisPosition, 0), nil
+	}
+	position, err := DecodePosition(game.StartPosition)
+	if err != nil {
+		return nil, err
+	}
+	return position.Positions, nil
+}
+
+// moveLabel оценивает сделанный ход по сравнению с лучшим
+//
+// Логика:
+//   - best: оценка хода совпадает с лучшей
+//   - missed_win: из выигранной позиции ход ведёт не к выигрышу
+//   - blunder: ход ухудшает исход (ничья или неизвестный исход — в проигрыш)
+//   - good: исход тот же, а оценка уступает лучшей не больше чем на INACCURACY_MARGIN
+//     (или исход уже решён, и ход лишь затягивает выигрыш или проигрыш)
+//   - inaccuracy: остальные ходы
+func moveLabel(evaluation *common.AnalysisResponse, played *common.MoveEvaluation) string {
+	switch {
+	case played.Score == evaluation.Score:
+		return bestMoveLabel
+	case evaluation.Outcome == winOutcome && played.Outcome != winOutcome:
+		return missedWinMoveLabel
+	case outcomeRank(played.Outcome) < outcomeRank(evaluation.Outcome):
+		return blunderMoveLabel
+	case isDecisive(evaluation.Score) || evaluation.Score-played.Score <= INACCURACY_MARGIN:
+		return goodMoveLabel
+	}
+	return inaccuracyMoveLabel
+}
+
+// outcomeRank упорядочивает исходы от худшего к лучшему; неизвестный исход приравнивается к ничьей.
+func outcomeRank(outcome string) int {
+	switch outcome {
+	case winOutcome:
+		return 2
+	case lossOutcome:
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,94 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// LEADERBOARD_PAGE_SIZE задаёт число строк на странице таблицы лидеров по умолчанию.
+const LEADERBOARD_PAGE_SIZE = 20
+
+// Периоды и сортировки таблицы лидеров.
+const (
+	allTimePeriod     = "all"
+	ratingLeaderboard = "rating"
+	winsLeaderboard   = "wins"
+)
+
+// ErrInvalidLeaderboardFilter возвращается при неверном размере поля или пуле рейтинга.
+var ErrInvalidLeaderboardFilter = errors.New("invalid leaderboard filter")
+
+// LeaderboardService строит таблицы лидеров по рейтингу и по победам.
+type LeaderboardService struct {
+	repo repository.LeaderboardRepository
+}
+
+// NewLeaderboardService создаёт новый экземпляр LeaderboardService.
+func NewLeaderboardService(repo repository.LeaderboardRepository) *LeaderboardService {
+	return &LeaderboardService{
+		repo: repo,
+	}
+}
+
+// Get возвращает страницу таблицы лидеров
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - form: фильтры по варианту, размеру поля и периоду, сортировка и страница
+//
+// Логика:
+//  1. Таблица по рейтингу строится по пулу рейтинга: вариант и размер поля задаются
+//     вместе (пул варианта и размера) или не задаются (общий рейтинг)
+//  2. Таблица по победам фильтруется по варианту и размеру поля независимо
+//  3. Вместе со страницей возвращается строка текущего пользователя с его местом
+//
+// Возвращает:
+//   - *common.LeaderboardResponse: страница, число игроков и строка текущего пользователя
+//   - error: ErrInvalidLeaderboardFilter или ошибка запроса
+func (service *LeaderboardService) Get(ctx context.Context, form common.LeaderboardRequest) (*common.LeaderboardResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	if form.Period == "" {
+		form.Period = allTimePeriod
+	}
+	if form.Sort == "" {
+		form.Sort = ratingLeaderboard
+	}
+	if form.Page == 0 {
+		form.Page = 1
+	}
+	if form.PerPage == 0 {
+		form.PerPage = LEADERBOARD_PAGE_SIZE
+	}
+	if form.BoardSize != "" {
+		if _, _, ok := parseNotationSize(form.BoardSize); !ok {
+			return nil, fmt.Errorf("%w: board size %q must be <width>x<height> from 3 to 15", ErrInvalidLeaderboardFilter, form.BoardSize)
+		}
+	}
+	if form.Sort == ratingLeaderboard && (form.Variant == "") != (form.BoardSize == "") {
+		return nil, fmt.Errorf("%w: rating pools need both variant and board size or neither", ErrInvalidLeaderboardFilter)
+	}
+	entries, total, err := service.repo.FindPage(ctx, &form)
+	if err != nil {
+		return nil, err
+	}
+	current, err := service.repo.FindEntry(ctx, &form, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return &common.LeaderboardResponse{
+		Entries: entries,
+		Page:    form.Page,
+		PerPage: form.PerPage,
+		Total:   total,
+		Current: current,
+	}, nil
+}
@@ -0,0 +1,448 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// Параметры подбора соперников.
+const (
+	// MATCHMAKING_INTERVAL задаёт, как часто подбираются пары из очереди.
+	MATCHMAKING_INTERVAL = 2 * time.Second
+	// MATCHMAKING_BASE_WINDOW задаёт допустимую разницу рейтингов сразу после постановки в очередь.
+	MATCHMAKING_BASE_WINDOW = 100.0
+	// MATCHMAKING_WINDOW_STEP задаёт, на сколько расширяется допустимая разница рейтингов
+	// за каждые MATCHMAKING_WINDOW_INTERVAL ожидания.
+	MATCHMAKING_WINDOW_STEP = 50.0
+	// MATCHMAKING_WINDOW_INTERVAL задаёт, через сколько ожидания расширяется допустимая разница рейтингов.
+	MATCHMAKING_WINDOW_INTERVAL = 10 * time.Second
+	// MATCHMAKING_MAX_WINDOW задаёт наибольшую допустимую разницу рейтингов.
+	MATCHMAKING_MAX_WINDOW = 1000.0
+	// MATCHMAKING_MATCH_TTL задаёт, сколько найденная пара хранится в очереди,
+	// чтобы игроки успели узнать комнату.
+	MATCHMAKING_MATCH_TTL = 5 * time.Minute
+	// MATCHMAKING_MAX_TIME_CONTROL задаёт наибольшее число минут и секунд добавки в контроле времени.
+	MATCHMAKING_MAX_TIME_CONTROL = 180
+)
+
+// Состояния игрока в очереди поиска соперника.
+const (
+	waitingMatchStatus = "waiting"
+	matchedMatchStatus = "matched"
+)
+
+// Ошибки очереди поиска соперника.
+var (
+	// ErrInvalidMatchmaking возвращается при неверных условиях партии.
+	ErrInvalidMatchmaking = errors.New("invalid matchmaking preferences")
+	// ErrNotInMatchmaking возвращается, если пользователь не стоит в очереди.
+	ErrNotInMatchmaking = errors.New("user is not in the matchmaking queue")
+)
+
+// matchTicket описывает игрока в очереди поиска соперника.
+type matchTicket struct {
+	user        *common.User
+	variant     string
+	boardSize   string
+	timeControl string
+	isRated     bool
+	rating      float64
+	joinedAt    time.Time
+	roomID      *uint64
+	password    string
+	opponent    *common.User
+	matchedAt   time.Time
+}
+
+// matchPair описывает найденную пару и условия её партии.
+type matchPair struct {
+	ticket    *matchTicket
+	opponent  *matchTicket
+	variant   string
+	boardSize string
+}
+
+// matchmakingQueue хранит игроков, ищущих соперника, по идентификатору пользователя.
+type matchmakingQueue struct {
+	mu      sync.Mutex
+	tickets map[uuid.UUID]*matchTicket
+}
+
+// MatchmakingService подбирает соперников по рейтингу и создаёт для пар комнаты.
+type MatchmakingService struct {
+	rooms      *RoomService
+	ratingRepo repository.RatingRepository
+	queue      *matchmakingQueue
+}
+
+// NewMatchmakingService создаёт новый экземпляр MatchmakingService.
+// Пары подбираются после запуска RunMatcher.
+func NewMatchmakingService(rooms *RoomService, ratingRepo repository.RatingRepository) *MatchmakingService {
+	return &MatchmakingService{
+		rooms:      rooms,
+		ratingRepo: ratingRepo,
+		queue: &matchmakingQueue{
+			tickets: make(map[uuid.UUID]*matchTicket),
+		},
+	}
+}
+
+// Join ставит текущего пользователя в очередь поиска соперника
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - form: вариант правил, размер поля, контроль времени и рейтинговость партии (пустые — любые)
+//
+// Логика:
+//  1. Вариант должен быть зарегистрирован, размер поля задаётся только
+//     для вариантов с настраиваемым полем
+//  2. Соперник подбирается по рейтингу пула варианта и размера поля,
+//     если оба заданы, иначе по общему рейтингу
+//  3. Контроль времени должен иметь вид "<минуты>+<добавка в секундах>" (см. validTimeControl);
+//     он только разводит игроков по очередям — комната создаётся без часов
+//  4. Повторная постановка в очередь заменяет прежние условия и начинает ожидание заново
+//
+// Возвращает:
+//   - *common.MatchmakingResponse: состояние игрока в очереди
+//   - error: ErrInvalidMatchmaking или ошибка запроса
+func (service *MatchmakingService) Join(ctx context.Context, form common.MatchmakingRequest) (*common.MatchmakingResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	if form.Variant != "" {
+		rules, ok := LookupRuleSet(form.Variant)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown variant %s", ErrInvalidMatchmaking, form.Variant)
+		}
+		if form.BoardSize != "" && rules.Settings().BorderSize > 0 {
+			return nil, fmt.Errorf("%w: variant %s has a fixed board size", ErrInvalidMatchmaking, form.Variant)
+		}
+	}
+	if form.BoardSize != "" {
+		if _, _, ok := parseNotationSize(form.BoardSize); !ok {
+			return nil, fmt.Errorf("%w: board size %q must be <width>x<height> from 3 to 15", ErrInvalidMatchmaking, form.BoardSize)
+		}
+	}
+	if form.TimeControl != "" && !validTimeControl(form.TimeControl) {
+		return nil, fmt.Errorf("%w: time control %q must be <minutes>+<increment> from 1+0 to %d+%d", ErrInvalidMatchmaking, form.TimeControl, MATCHMAKING_MAX_TIME_CONTROL, MATCHMAKING_MAX_TIME_CONTROL)
+	}
+	ticket := &matchTicket{
+		user:        user,
+		variant:     form.Variant,
+		boardSize:   form.BoardSize,
+		timeControl: form.TimeControl,
+		isRated:     form.IsRated != nil && *form.IsRated,
+		rating:      GLICKO_DEFAULT_RATING,
+		joinedAt:    time.Now(),
+	}
+	variant, boardSize := "", ""
+	if form.Variant != "" && form.BoardSize != "" {
+		variant, boardSize = form.Variant, form.BoardSize
+	}
+	ratings, err := service.ratingRepo.FindByUsers(ctx, []uuid.UUID{user.ID}, variant, boardSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(ratings) > 0 {
+		ticket.rating = ratings[0].Rating
+	}
+	service.queue.mu.Lock()
+	defer service.queue.mu.Unlock()
+	service.queue.tickets[user.ID] = ticket
+	return matchmakingResponse(ticket, ticket.joinedAt), nil
+}
+
+// Get возвращает состояние текущего пользователя в очереди
+//
+// Возвращает:
+//   - *common.MatchmakingResponse: ожидание с текущей допустимой разницей рейтингов
+//     или созданная комната с паролем и соперником
+//   - error: ErrNotInMatchmaking, если пользователь не стоит в очереди
+//
+// Особенности:
+//   - Клиент опрашивает состояние, пока не получит комнату, и подключается
+//     к ней по обычному пути входа в комнату с полученным паролем
+func (service *MatchmakingService) Get(ctx context.Context) (*common.MatchmakingResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	service.queue.mu.Lock()
+	defer service.queue.mu.Unlock()
+	ticket, ok := service.queue.tickets[user.ID]
+	if !ok {
+		return nil, ErrNotInMatchmaking
+	}
+	return matchmakingResponse(ticket, time.Now()), nil
+}
+
+// Leave убирает текущего пользователя из очереди.
+// Возвращает ErrNotInMatchmaking, если пользователь не стоит в очереди.
+func (service *MatchmakingService) Leave(ctx context.Context) error {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return errors.New("userId is not correct")
+	}
+	service.queue.mu.Lock()
+	defer service.queue.mu.Unlock()
+	if _, ok := service.queue.tickets[user.ID]; !ok {
+		return ErrNotInMatchmaking
+	}
+	delete(service.queue.tickets, user.ID)
+	return nil
+}
+
+// RunMatcher подбирает пары из очереди каждые MATCHMAKING_INTERVAL, пока не отменён контекст.
+func (service *MatchmakingService) RunMatcher(ctx context.Context) {
+	ticker := time.NewTicker(MATCHMAKING_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			service.match(ctx, now)
+		}
+	}
+}
+
+// match подбирает пары из ожидающих игроков
+//
+// Логика:
+//  1. Найденные пары старше MATCHMAKING_MATCH_TTL убираются из очереди
+//  2. Ожидающие игроки перебираются от дольше ждущих; каждому подбирается
+//     подходящий по условиям соперник с ближайшим рейтингом
+//  3. Разница рейтингов должна помещаться в допустимую разницу обоих игроков
+//     (см. matchWindow)
+//  4. Для пар создаются закрытые комнаты (см. createMatchRoom) уже без блокировки очереди,
+//     чтобы создание комнат не задерживало Join, Get и Leave; если создать комнату не удалось,
+//     игроки остаются в очереди
+//  5. Комната сообщается паре, только если оба игрока всё ещё ждут с теми же условиями
+//     (не вышли из очереди и не встали в неё заново), иначе оставшийся игрок ждёт дальше
+func (service *MatchmakingService) match(ctx context.Context, now time.Time) {
+	pairs := service.pair(now)
+	for _, pair := range pairs {
+		roomID, password, err := service.createMatchRoom(ctx, pair)
+		if err != nil {
+			slog.Error("[matchmaking]match", slog.String("error", err.Error()))
+			continue
+		}
+		service.queue.mu.Lock()
+		if service.queue.tickets[pair.ticket.user.ID] == pair.ticket && service.queue.tickets[pair.opponent.user.ID] == pair.opponent {
+			for _, players := range [][2]*matchTicket{{pair.ticket, pair.opponent}, {pair.opponent, pair.ticket}} {
+				players[0].roomID = &roomID
+				players[0].password = password
+				players[0].opponent = players[1].user
+				players[0].matchedAt = now
+			}
+		}
+		service.queue.mu.Unlock()
+	}
+}
+
+// pair убирает устаревшие пары из очереди и подбирает новые пары (см. match).
+func (service *MatchmakingService) pair(now time.Time) []matchPair {
+	service.queue.mu.Lock()
+	defer service.queue.mu.Unlock()
+	pairs := make([]matchPair, 0)
+	paired := make(map[uuid.UUID]bool)
+	waiting := make([]*matchTicket, 0, len(service.queue.tickets))
+	for userID, ticket := range service.queue.tickets {
+		if ticket.roomID != nil {
+			if now.Sub(ticket.matchedAt) > MATCHMAKING_MATCH_TTL {
+				delete(service.queue.tickets, userID)
+			}
+			continue
+		}
+		waiting = append(waiting, ticket)
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		if !waiting[i].joinedAt.Equal(waiting[j].joinedAt) {
+			return waiting[i].joinedAt.Before(waiting[j].joinedAt)
+		}
+		return waiting[i].user.ID.String() < waiting[j].user.ID.String()
+	})
+	for i, ticket := range waiting {
+		if paired[ticket.user.ID] {
+			continue
+		}
+		var opponent *matchTicket
+		var variant, boardSize string
+		bestDifference := math.Inf(1)
+		for _, candidate := range waiting[i+1:] {
+			if paired[candidate.user.ID] {
+				continue
+			}
+			candidateVariant, candidateBoardSize, ok := matchConditions(ticket, candidate)
+			if !ok {
+				continue
+			}
+			difference := math.Abs(ticket.rating - candidate.rating)
+			if difference > matchWindow(ticket, now) || difference > matchWindow(candidate, now) {
+				continue
+			}
+			if difference < bestDifference {
+				opponent, bestDifference = candidate, difference
+				variant, boardSize = candidateVariant, candidateBoardSize
+			}
+		}
+		if opponent == nil {
+			continue
+		}
+		paired[ticket.user.ID], paired[opponent.user.ID] = true, true
+		pairs = append(pairs, matchPair{
+			ticket:    ticket,
+			opponent:  opponent,
+			variant:   variant,
+			boardSize: boardSize,
+		})
+	}
+	return pairs
+}
+
+// createMatchRoom создаёт комнату для найденной пары
+//
+// Особенности:
+//   - Комната закрытая, пароль генерируется случайно и сообщается только игрокам пары
+//   - Создателем комнаты становится игрок, который ждал дольше; кто ходит первым,
+//     выбирается случайно
+//   - Комната рейтинговая, если игроки искали рейтинговую партию
+//
+// Возвращает:
+//   - uint64: идентификатор комнаты
+//   - string: пароль комнаты
+//   - error: ошибка создания комнаты
+func (service *MatchmakingService) createMatchRoom(ctx context.Context, pair matchPair) (uint64, string, error) {
+	isPrivate := true
+	password := uuid.NewString()
+	isRated := pair.ticket.isRated
+	name := []rune(fmt.Sprintf("%s vs %s", pair.ticket.user.Name, pair.opponent.user.Name))
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	form := common.RoomRequest{
+		Name:            string(name),
+		IsPrivate:       &isPrivate,
+		Password:        &password,
+		FirstMovePolicy: randomFirstMovePolicy,
+		Variant:         pair.variant,
+		IsRated:         &isRated,
+	}
+	if width, height, ok := parseNotationSize(pair.boardSize); ok {
+		form.Width, form.Height = uint8(width), uint8(height)
+	}
+	roomID, err := service.rooms.create(ctx, pair.ticket.user.ID, form)
+	if err != nil {
+		return 0, "", err
+	}
+	return roomID, password, nil
+}
+
+// matchConditions проверяет, совпадают ли условия партии двух игроков
+//
+// Возвращает:
+//   - string: вариант правил комнаты (classic, если обоим подходит любой)
+//   - string: размер поля комнаты (пустой — поле по умолчанию)
+//   - bool: false, если условия несовместимы
+//
+// Особенности:
+//   - Контроль времени сравнивается так же, как вариант и размер поля, но в комнату
+//     не переносится: часов в комнатах пока нет
+func matchConditions(ticket, candidate *matchTicket) (string, string, bool) {
+	if ticket.isRated != candidate.isRated {
+		return "", "", false
+	}
+	if _, ok := matchPreference(ticket.timeControl, candidate.timeControl); !ok {
+		return "", "", false
+	}
+	variant, ok := matchPreference(ticket.variant, candidate.variant)
+	if !ok {
+		return "", "", false
+	}
+	boardSize, ok := matchPreference(ticket.boardSize, candidate.boardSize)
+	if !ok {
+		return "", "", false
+	}
+	if variant == "" {
+		variant = classicVariant
+	}
+	rules, ok := LookupRuleSet(variant)
+	if !ok || (boardSize != "" && rules.Settings().BorderSize > 0) {
+		return "", "", false
+	}
+	return variant, boardSize, true
+}
+
+// matchPreference объединяет условие двух игроков: пустое условие подходит к любому.
+func matchPreference(first, second string) (string, bool) {
+	if first == "" {
+		return second, true
+	}
+	if second == "" || second == first {
+		return first, true
+	}
+	return "", false
+}
+
+// validTimeControl проверяет контроль времени "<минуты>+<добавка в секундах>":
+// минут от 1, добавки от 0, и того и другого не больше MATCHMAKING_MAX_TIME_CONTROL.
+func validTimeControl(timeControl string) bool {
+	minutes, increment, ok := strings.Cut(timeControl, "+")
+	if !ok {
+		return false
+	}
+	base, err := strconv.Atoi(minutes)
+	if err != nil || base < 1 || base > MATCHMAKING_MAX_TIME_CONTROL || strconv.Itoa(base) != minutes {
+		return false
+	}
+	extra, err := strconv.Atoi(increment)
+	return err == nil && extra >= 0 && extra <= MATCHMAKING_MAX_TIME_CONTROL && strconv.Itoa(extra) == increment
+}
+
+// matchWindow возвращает допустимую разницу рейтингов игрока: MATCHMAKING_BASE_WINDOW,
+// расширенная на MATCHMAKING_WINDOW_STEP за каждые MATCHMAKING_WINDOW_INTERVAL ожидания,
+// но не больше MATCHMAKING_MAX_WINDOW.
+func matchWindow(ticket *matchTicket, now time.Time) float64 {
+	steps := math.Floor(float64(now.Sub(ticket.joinedAt)) / float64(MATCHMAKING_WINDOW_INTERVAL))
+	return min(MATCHMAKING_BASE_WINDOW+MATCHMAKING_WINDOW_STEP*max(steps, 0), MATCHMAKING_MAX_WINDOW)
+}
+
+// matchmakingResponse возвращает состояние игрока в очереди на момент now.
+func matchmakingResponse(ticket *matchTicket, now time.Time) *common.MatchmakingResponse {
+	response := &common.MatchmakingResponse{
+		Status:       waitingMatchStatus,
+		Variant:      ticket.variant,
+		BoardSize:    ticket.boardSize,
+		TimeControl:  ticket.timeControl,
+		IsRated:      ticket.isRated,
+		Rating:       ticket.rating,
+		RatingWindow: matchWindow(ticket, now),
+		JoinedAt:     ticket.joinedAt,
+	}
+	if ticket.roomID != nil {
+		response.Status = matchedMatchStatus
+		response.RatingWindow = matchWindow(ticket, ticket.matchedAt)
+		response.RoomID = ticket.roomID
+		response.Password = ticket.password
+		response.Opponent = &common.UserResponse{
+			ID:   ticket.opponent.ID,
+			Name: ticket.opponent.Name,
+		}
+	}
+	return response
+}
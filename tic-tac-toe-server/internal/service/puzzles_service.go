@@ -0,0 +1,283 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// Параметры задач "выигрыш за N ходов".
+const (
+	// MAX_PUZZLE_WIN_IN задаёт наибольшее число своих ходов до выигрыша в задаче.
+	MAX_PUZZLE_WIN_IN = 3
+	// MAX_PUZZLES_PER_GAME задаёт, сколько задач можно взять из одной партии.
+	MAX_PUZZLES_PER_GAME = 2
+	// PUZZLE_TIME_BUDGET задаёт время на проверку хода решения в миллисекундах.
+	PUZZLE_TIME_BUDGET = 2000
+	// PUZZLE_RATING_K задаёт наибольшее изменение рейтинга решения задач за попытку.
+	PUZZLE_RATING_K = 32
+)
+
+// Состояния решения задачи.
+const (
+	solvedPuzzleStatus   = "solved"
+	continuePuzzleStatus = "continue"
+	failedPuzzleStatus   = "failed"
+)
+
+var (
+	// ErrPuzzleNotFound возвращается, если задача не найдена или подходящих задач нет.
+	ErrPuzzleNotFound = errors.New("puzzle not found")
+	// ErrPuzzleIsDaily возвращается при решении задачи дня как обычной задачи,
+	// пока пользователь не завершил попытку задачи дня.
+	ErrPuzzleIsDaily = errors.New("puzzle is today's daily challenge")
+)
+
+// PuzzleService создаёт задачи из разобранных партий и проверяет их решения.
+// daily и location нужны, чтобы задачу дня нельзя было решить как обычную
+// и узнать решение до своей попытки.
+type PuzzleService struct {
+	repo     repository.PuzzleRepository
+	daily    repository.DailyRepository
+	analysis *AnalysisService
+	location *time.Location
+}
+
+// NewPuzzleService создаёт новый экземпляр PuzzleService.
+func NewPuzzleService(repo repository.PuzzleRepository, daily repository.DailyRepository, analysis *AnalysisService) *PuzzleService {
+	return &PuzzleService{
+		repo:     repo,
+		daily:    daily,
+		analysis: analysis,
+		location: dailyLocation(),
+	}
+}
+
+// MineGame сохраняет задачи из разобранной партии по правилам classic
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - game: партия
+//   - analysis: разбор партии
+//
+// Логика:
+//  1. Кандидаты — позиции перед ходом (вместе с фишками начальной позиции партии), в которых ходивший выигрывал по перебору
+//     не больше чем за MAX_PUZZLE_WIN_IN своих ходов
+//  2. Из партии берутся MAX_PUZZLES_PER_GAME самых длинных комбинаций
+//  3. Сложность растёт с числом ходов до выигрыша и размером поля, падает с числом
+//     выигрывающих ходов; позиция, в которой игрок упустил выигрыш, считается сложнее
+func (service *PuzzleService) MineGame(ctx context.Context, game *common.Game, analysis *common.GameAnalysis) {
+	if game.Variant != classicVariant {
+		return
+	}
+	start, err := startPositions(game)
+	if err != nil {
+		slog.Error("[puzzles]MineGame", slog.Uint64("game_id", game.ID), slog.String("error", err.Error()))
+		return
+	}
+	var candidates []*common.Puzzle
+	for index, move := range analysis.Moves {
+		if move.Outcome != winOutcome || !isDecisive(move.BestScore) {
+			continue
+		}
+		winIn := (WIN_SCORE - move.BestScore + 1) / 2
+		if winIn > MAX_PUZZLE_WIN_IN {
+			continue
+		}
+		positions := make([]common.AnalysisPosition, 0, len(start)+index)
+		positions = append(positions, start...)
+		for _, previous := range game.Moves[:index] {
+			positions = append(positions, common.AnalysisPosition{ID: previous.PositionID, Symbol: previous.Symbol})
+		}
+		puzzle := &common.Puzzle{
+			GameID:     game.ID,
+			Variant:    game.Variant,
+			BorderSize: game.Width,
+			WinLength:  game.WinLength,
+			Positions:  positions,
+			Turn:       move.Symbol,
+			WinIn:      uint8(winIn),
+			Solutions:  move.BestMoves,
+		}
+		puzzle.Key = puzzleKey(puzzle)
+		puzzle.Rating = puzzleRating(puzzle, move.Label == missedWinMoveLabel)
+		candidates = append(candidates, puzzle)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].WinIn > candidates[j].WinIn
+	})
+	for _, puzzle := range candidates[:min(len(candidates), MAX_PUZZLES_PER_GAME)] {
+		if err := service.repo.Create(ctx, puzzle); err != nil {
+			slog.Error("[puzzles]MineGame", slog.Uint64("game_id", game.ID), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Next возвращает текущему пользователю задачу, которую он ещё не решал,
+// со сложностью ближе всего к его рейтингу решения задач. Задача дня не предлагается.
+func (service *PuzzleService) Next(ctx context.Context) (*common.Puzzle, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	puzzle, err := service.repo.FindNextForUser(ctx, user.ID, user.PuzzleRating, dailyDay(service.location))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPuzzleNotFound
+		}
+		return nil, err
+	}
+	return puzzle, nil
+}
+
+// Solve проверяет ходы решения задачи
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - id: идентификатор задачи
+//   - form: свои ходы решающего по порядку
+//
+// Логика:
+//  1. Ходы решающего и ответы соперника ставятся по очереди (см. play)
+//  2. Решение или ошибка в первой попытке меняют рейтинг решения задач пользователя
+//     (по формуле Эло против сложности задачи)
+//
+// Особенности:
+//   - Сегодняшнюю задачу дня нельзя решать как обычную, пока пользователь не завершил
+//     попытку задачи дня, иначе ответы соперника раскрыли бы решение
+//
+// Возвращает:
+//   - *common.PuzzleSolveResponse: состояние решения, ответ соперника и рейтинг
+//   - error: ErrPuzzleNotFound, ErrPuzzleIsDaily, ErrInvalidPosition (клетка вне поля или занята)
+//     или ошибка запроса
+func (service *PuzzleService) Solve(ctx context.Context, id uint64, form common.PuzzleSolveRequest) (*common.PuzzleSolveResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	puzzle, err := service.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPuzzleNotFound
+		}
+		return nil, err
+	}
+	pending, err := service.daily.HasUnfinishedDaily(ctx, puzzle.ID, user.ID, dailyDay(service.location))
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrPuzzleIsDaily
+	}
+	status, reply, err := service.play(ctx, puzzle, form.Moves)
+	if err != nil {
+		return nil, err
+	}
+	response := &common.PuzzleSolveResponse{
+		Status: status,
+		Reply:  reply,
+		Rating: user.PuzzleRating,
+	}
+	if response.Status == continuePuzzleStatus {
+		return response, nil
+	}
+	score := 0.0
+	if response.Status == solvedPuzzleStatus {
+		score = 1
+	}
+	expected := 1 / (1 + math.Pow(10, float64(puzzle.Rating-user.PuzzleRating)/400))
+	rating := user.PuzzleRating + int(math.Round(PUZZLE_RATING_K*(score-expected)))
+	rated, err := service.repo.RecordAttempt(ctx, user.ID, puzzle.ID, score == 1, user.PuzzleRating, rating)
+	if err != nil {
+		return nil, err
+	}
+	if rated {
+		response.Rated = true
+		response.Rating = rating
+	}
+	return response, nil
+}
+
+// play ставит ходы решающего и ответы соперника
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - puzzle: задача
+//   - moves: свои ходы решающего по порядку
+//
+// Логика:
+//  1. Ответ соперника — ход, дольше всего откладывающий проигрыш
+//  2. Ход верен, если после него выигрыш остаётся доказанным за оставшиеся ходы
+//  3. Если ход собрал линию — задача решена, если ходы кончились — нужен следующий ход
+//
+// Возвращает:
+//   - string: состояние решения (solved, continue или failed)
+//   - string: ответ соперника на последний ход (для continue)
+//   - error: ErrInvalidPosition (клетка вне поля или занята) или ошибка анализа
+func (service *PuzzleService) play(ctx context.Context, puzzle *common.Puzzle, moves []string) (string, string, error) {
+	opponent := opositeSymbol(puzzle.Turn)
+	positions := append([]common.AnalysisPosition{}, puzzle.Positions...)
+	reply := ""
+	for index, positionID := range moves {
+		positions = append(positions, common.AnalysisPosition{ID: positionID, Symbol: puzzle.Turn})
+		// Позиция после хода оценивается для соперника: он должен проигрывать
+		// не позже, чем решающий сделает оставшиеся ходы
+		evaluation, err := service.analysis.Analyze(ctx, common.AnalysisRequest{
+			BorderSize: puzzle.BorderSize,
+			WinLength:  puzzle.WinLength,
+			Positions:  positions,
+			Turn:       opponent,
+			Variant:    puzzle.Variant,
+			TimeBudget: PUZZLE_TIME_BUDGET,
+		})
+		if err != nil {
+			return "", "", err
+		}
+		if len(evaluation.Moves) == 0 && evaluation.Outcome == lossOutcome {
+			return solvedPuzzleStatus, "", nil
+		}
+		remaining := int(puzzle.WinIn) - index - 1
+		if evaluation.Outcome != lossOutcome || -evaluation.Score < WIN_SCORE-2*remaining {
+			return failedPuzzleStatus, "", nil
+		}
+		reply = evaluation.BestMoves[0]
+		positions = append(positions, common.AnalysisPosition{ID: reply, Symbol: opponent})
+	}
+	return continuePuzzleStatus, reply, nil
+}
+
+// puzzleKey строит ключ позиции задачи: одинаковые позиции из разных партий дают один ключ.
+func puzzleKey(puzzle *common.Puzzle) string {
+	cells := make([]string, 0, len(puzzle.Positions))
+	for _, position := range puzzle.Positions {
+		cells = append(cells, position.ID+":"+position.Symbol)
+	}
+	sort.Strings(cells)
+	return fmt.Sprintf("%s|%d|%d|%s|%s", puzzle.Variant, puzzle.BorderSize, puzzle.WinLength, puzzle.Turn, strings.Join(cells, ","))
+}
+
+// puzzleRating оценивает сложность задачи
+//
+// Параметры:
+//   - puzzle: задача
+//   - missed: игрок в партии упустил выигрыш в этой позиции
+//
+// Возвращает:
+//   - int: сложность от 400 до 2500 (1000 — выигрыш в один ход на поле 3x3 с единственным решением)
+func puzzleRating(puzzle *common.Puzzle, missed bool) int {
+	rating := 1000 + 200*(int(puzzle.WinIn)-1) + 50*(int(puzzle.BorderSize)-3) - 25*(len(puzzle.Solutions)-1)
+	if missed {
+		rating += 100
+	}
+	return min(max(rating, 400), 2500)
+}
@@ -3,9 +3,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
 
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/config"
@@ -20,6 +24,18 @@ type RoomService struct {
 
 const op = "room_serivce"
 
+// Ошибки создания комнаты, вызванные её настройками.
+var (
+	// ErrInvalidRoomLayout возвращается, если заблокированные клетки не помещаются на поле комнаты.
+	ErrInvalidRoomLayout = errors.New("invalid room layout")
+	// ErrInvalidVariantOptions возвращается, если набор правил отклонил настройки варианта.
+	ErrInvalidVariantOptions = errors.New("invalid variant options")
+	// ErrInvalidStartPosition возвращается, если с начальной позиции нельзя начать партию в комнате.
+	ErrInvalidStartPosition = errors.New("invalid start position")
+	// ErrInvalidBotFill возвращается, если бота просят подсаживать в комнату больше чем на двух игроков.
+	ErrInvalidBotFill = errors.New("invalid bot fill")
+)
+
 // NewRoomService создаёт новый экземпляр RoomService с указанным репозиторием.
 func NewRoomService(repoRoom repository.RoomRepository) *RoomService {
 	return &RoomService{
@@ -28,6 +44,7 @@ func NewRoomService(repoRoom repository.RoomRepository) *RoomService {
 }
 
 // GetAll возвращает список всех доступных комнат, в которых есть свободные места.
+// Бот не занимает место: он уступает его человеку между партиями.
 func (service *RoomService) GetAll(ctx context.Context, ws *WSServer) []*common.RoomResponse {
 	rooms, err := service.repo.FindAll(ctx)
 	var roomsResponse []*common.RoomResponse
@@ -39,15 +56,28 @@ func (service *RoomService) GetAll(ctx context.Context, ws *WSServer) []*common.
 		playerIn := 0
 		roomInfo := ws.Rooms[room.ID]
 		if roomInfo != nil {
-			playerIn = len(roomInfo.Users)
+			playerIn = humanPlayers(roomInfo.Users)
 		}
-		if playerIn != 2 {
+		if playerIn < int(room.Capacity) {
 			roomsResponse = append(roomsResponse, &common.RoomResponse{
-				ID:        room.ID,
-				Name:      room.Name,
-				Capacity:  room.Capacity,
-				IsPrivate: &room.IsPrivate,
-				PlayerIn:  playerIn,
+				ID:              room.ID,
+				Name:            room.Name,
+				Capacity:        room.Capacity,
+				IsPrivate:       &room.IsPrivate,
+				PlayerIn:        playerIn,
+				FirstMovePolicy: room.FirstMovePolicy,
+				Variant:         room.Variant,
+				VariantOptions:  room.VariantOptions,
+				Gravity:         room.Gravity,
+				WinLength:       room.WinLength,
+				PieceLimit:      room.PieceLimit,
+				Width:           room.Width,
+				Height:          room.Height,
+				BlockedCells:    room.BlockedCells,
+				StartPosition:   room.StartPosition,
+				IsRated:         room.IsRated,
+				BotFill:         room.BotFill,
+				BotAfter:        room.BotAfter,
 			})
 		}
 	}
@@ -71,7 +101,7 @@ func isUserInRoom(currentUser *common.User, users []*ConnectedUser) bool {
 	return false
 }
 
-// GetAllMy возвращает комнаты, созданные или занятые текущим пользователем.
+// GetAllMy возвращает комнаты, созданные или занятые текущим пользователем (боты в числе игроков не учитываются).
 func (service *RoomService) GetAllMy(ctx context.Context, ws *WSServer) []*common.RoomResponse {
 	currentUser, ok := ctx.Value(common.USER).(*common.User)
 	if !ok {
@@ -86,15 +116,28 @@ func (service *RoomService) GetAllMy(ctx context.Context, ws *WSServer) []*commo
 		playerIn := 0
 		roomInfo := ws.Rooms[room.ID]
 		if roomInfo != nil {
-			playerIn = len(roomInfo.Users)
+			playerIn = humanPlayers(roomInfo.Users)
 		}
 		if currentUser.ID == room.CreatorID || (roomInfo != nil && isUserInRoom(currentUser, roomInfo.Users)) {
 			roomsResponse = append(roomsResponse, &common.RoomResponse{
-				ID:        room.ID,
-				Name:      room.Name,
-				Capacity:  room.Capacity,
-				IsPrivate: &room.IsPrivate,
-				PlayerIn:  playerIn,
+				ID:              room.ID,
+				Name:            room.Name,
+				Capacity:        room.Capacity,
+				IsPrivate:       &room.IsPrivate,
+				PlayerIn:        playerIn,
+				FirstMovePolicy: room.FirstMovePolicy,
+				Variant:         room.Variant,
+				VariantOptions:  room.VariantOptions,
+				Gravity:         room.Gravity,
+				WinLength:       room.WinLength,
+				PieceLimit:      room.PieceLimit,
+				Width:           room.Width,
+				Height:          room.Height,
+				BlockedCells:    room.BlockedCells,
+				StartPosition:   room.StartPosition,
+				IsRated:         room.IsRated,
+				BotFill:         room.BotFill,
+				BotAfter:        room.BotAfter,
 			})
 		}
 	}
@@ -127,27 +170,83 @@ func (service *RoomService) GetById(ctx context.Context, id uint64, ws *WSServer
 				ID:     user.ID,
 				Name:   user.Name,
 				Symbol: user.Symbol,
+				IsBot:  user.IsBot,
 			})
 		}
 	}
 	resp := &common.RoomSessionResponse{
-		ID:        room.ID,
-		Name:      room.Name,
-		CreatorID: room.CreatorID,
-		Password:  room.Password,
-		IsPrivate: &room.IsPrivate,
-		Capacity:  room.Capacity,
-		Users:     users,
+		ID:              room.ID,
+		Name:            room.Name,
+		CreatorID:       room.CreatorID,
+		Password:        room.Password,
+		IsPrivate:       &room.IsPrivate,
+		Capacity:        room.Capacity,
+		FirstMovePolicy: room.FirstMovePolicy,
+		Variant:         room.Variant,
+		VariantOptions:  room.VariantOptions,
+		Gravity:         room.Gravity,
+		WinLength:       room.WinLength,
+		PieceLimit:      room.PieceLimit,
+		Width:           room.Width,
+		Height:          room.Height,
+		BlockedCells:    room.BlockedCells,
+		StartPosition:   room.StartPosition,
+		IsRated:         room.IsRated,
+		BotFill:         room.BotFill,
+		BotAfter:        room.BotAfter,
+		Users:           users,
 	}
 	return resp, nil
 }
 
-// Create создаёт новую игровую комнату. Если установлен пароль, он хэшируется с помощью bcrypt.
+// Create создаёт новую игровую комнату от имени текущего пользователя
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем (создателем комнаты)
+//   - form: название, пароль, вариант правил и настройки поля комнаты
+//
+// Логика:
+//  1. Пароль, если установлен, хэшируется с помощью bcrypt
+//  2. Правило первого хода по умолчанию — creatorFirstMovePolicy, вариант — classicVariant;
+//     вариант должен быть зарегистрирован в реестре наборов правил, его настройки
+//     проверяет сам набор правил
+//  3. Начальная позиция в нотации (см. DecodePosition) задаёт вариант, размеры поля,
+//     длину линии и заблокированные клетки и сохраняется в записи нотации
+//  4. Гравитация, длина линии, ширина, высота и заблокированные клетки сохраняются только
+//     для вариантов с настраиваемым двумерным полем (см. roomLayout), лимит фишек — только
+//     для вариантов с лимитом по умолчанию (rolling)
+//  5. Время ожидания бота по умолчанию BOT_WAIT_MINUTES
+//
+// Возвращает:
+//   - error: ErrUnknownVariant, ErrInvalidVariantOptions, ErrInvalidStartPosition,
+//     ErrInvalidBotFill, ErrInvalidRoomLayout или ошибка сохранения
+//
+// Особенности:
+//   - Комнаты больше чем на двух игроков доступны только для вариантов с поддержкой
+//     нескольких игроков, длина линии в них по умолчанию MULTIPLAYER_WIN_LENGTH
+//   - Начальная позиция доступна только двумерным вариантам без лимита фишек
+//   - Рейтинговые комнаты не могут начинаться с начальной позиции: результаты в них
+//     меняют рейтинг игроков (см. ScoreService.RecordResult)
+//   - Подсадка бота (offer или auto) доступна только комнатам на двух игроков
 func (service *RoomService) Create(ctx context.Context, form common.RoomRequest) error {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return errors.New("userId is not correct")
+	}
+	_, err := service.create(ctx, user.ID, form)
+	return err
+}
+
+// create проверяет настройки и сохраняет комнату от имени создателя (см. Create)
+//
+// Возвращает:
+//   - uint64: идентификатор созданной комнаты
+//   - error: ошибка настроек комнаты или сохранения
+func (service *RoomService) create(ctx context.Context, creatorID uuid.UUID, form common.RoomRequest) (uint64, error) {
 	if *form.Password != "" {
 		password, err := bcrypt.GenerateFromPassword([]byte(*form.Password), config.ServerConfig.BcryptPower)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		hashedPassword := string(password)
 		form.Password = &hashedPassword
@@ -155,19 +254,176 @@ func (service *RoomService) Create(ctx context.Context, form common.RoomRequest)
 	if *form.Password == "" {
 		*form.IsPrivate = false
 	}
-	user, ok := ctx.Value(common.USER).(*common.User)
+	if form.FirstMovePolicy == "" {
+		form.FirstMovePolicy = creatorFirstMovePolicy
+	}
+	isRated := form.IsRated != nil && *form.IsRated
+	var startPosition *common.BoardPosition
+	if form.StartPosition != "" {
+		if isRated {
+			return 0, fmt.Errorf("%w: rated rooms must start from an empty board", ErrInvalidStartPosition)
+		}
+		position, err := DecodePosition(form.StartPosition)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidStartPosition, err)
+		}
+		if form.Variant == "" {
+			form.Variant = position.Variant
+		}
+		if form.Variant != position.Variant {
+			return 0, fmt.Errorf("%w: position variant %s does not match room variant %s", ErrInvalidStartPosition, position.Variant, form.Variant)
+		}
+		startPosition = position
+	}
+	if form.Variant == "" {
+		form.Variant = classicVariant
+	}
+	rules, ok := LookupRuleSet(form.Variant)
 	if !ok {
-		return errors.New("userId is not correct")
+		return 0, fmt.Errorf("%w: %s", ErrUnknownVariant, form.Variant)
+	}
+	if err := rules.ValidateOptions(form.VariantOptions); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidVariantOptions, err)
+	}
+	if len(form.VariantOptions) == 0 {
+		form.VariantOptions = json.RawMessage("{}")
+	}
+	settings := rules.Settings()
+	gravity := form.Gravity != nil && *form.Gravity
+	if settings.BorderSize > 0 {
+		gravity = false
+		form.WinLength = 0
+		form.Width, form.Height = 0, 0
+		form.BlockedCells, form.BlockedCount = nil, 0
+	}
+	if form.Capacity == 0 || !settings.Multiplayer {
+		form.Capacity = DEFAULT_CAPACITY
+	}
+	if form.Capacity > DEFAULT_CAPACITY && form.WinLength == 0 {
+		form.WinLength = MULTIPLAYER_WIN_LENGTH
+	}
+	if settings.DefaultPieceLimit == 0 {
+		form.PieceLimit = 0
+	} else if form.PieceLimit == 0 {
+		form.PieceLimit = settings.DefaultPieceLimit
+	}
+	if startPosition != nil {
+		if err := applyStartPosition(&form, startPosition, settings, gravity); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidStartPosition, err)
+		}
+	}
+	if form.BotFill == "" || form.BotFill == offBotFill {
+		form.BotFill = offBotFill
+		form.BotAfter = 0
+	} else {
+		if form.Capacity > DEFAULT_CAPACITY {
+			return 0, fmt.Errorf("%w: bot can only fill rooms for two players", ErrInvalidBotFill)
+		}
+		if form.BotAfter == 0 {
+			form.BotAfter = BOT_WAIT_MINUTES
+		}
+	}
+	blockedCells, blockedSeed, err := roomLayout(&form)
+	if err != nil {
+		return 0, err
 	}
 	room := common.Room{
-		CreatorID: user.ID,
-		Name:      form.Name,
-		Password:  *form.Password,
-		IsPrivate: *form.IsPrivate,
+		CreatorID:       creatorID,
+		Name:            form.Name,
+		Password:        *form.Password,
+		IsPrivate:       *form.IsPrivate,
+		Capacity:        form.Capacity,
+		FirstMovePolicy: form.FirstMovePolicy,
+		Variant:         form.Variant,
+		VariantOptions:  form.VariantOptions,
+		Gravity:         gravity,
+		WinLength:       form.WinLength,
+		PieceLimit:      form.PieceLimit,
+		Width:           form.Width,
+		Height:          form.Height,
+		BlockedCells:    blockedCells,
+		BlockedSeed:     blockedSeed,
+		StartPosition:   form.StartPosition,
+		IsRated:         isRated,
+		BotFill:         form.BotFill,
+		BotAfter:        form.BotAfter,
 	}
 	return service.repo.Create(ctx, room)
 }
 
+// applyStartPosition переносит в запрос на создание комнаты параметры начальной позиции
+//
+// Параметры:
+//   - form: запрос на создание комнаты
+//   - position: разобранная начальная позиция
+//   - settings: свойства набора правил варианта
+//   - gravity: в комнате включён режим "гравитации"
+//
+// Возвращает:
+//   - error: если вариант не поддерживает начальные позиции (фиксированное поле
+//     или лимит фишек), задано случайное число заблокированных клеток или с позиции
+//     нельзя начать партию (см. validateStartPosition)
+//
+// Особенности:
+//   - Позиция сохраняется в записи EncodePosition, поэтому одна и та же позиция
+//     всегда записана одинаково
+func applyStartPosition(form *common.RoomRequest, position *common.BoardPosition, settings RuleSetSettings, gravity bool) error {
+	if settings.BorderSize > 0 || settings.DefaultPieceLimit > 0 {
+		return fmt.Errorf("variant %s does not support start positions", form.Variant)
+	}
+	if form.BlockedCount > 0 {
+		return errors.New("blocked_count can not be combined with a start position")
+	}
+	if err := validateStartPosition(position, int(form.Capacity), gravity); err != nil {
+		return err
+	}
+	form.Width, form.Height = position.Width, position.Height
+	form.WinLength = position.WinLength
+	form.BlockedCells = position.BlockedCells
+	form.StartPosition = EncodePosition(position)
+	return nil
+}
+
+// roomLayout определяет заблокированные клетки новой комнаты
+//
+// Параметры:
+//   - form: запрос на создание комнаты
+//
+// Возвращает:
+//   - []string: заблокированные клетки (пустой слайс, если их нет)
+//   - *int64: зерно случайной раскладки (nil, если клетки заданы вручную)
+//   - error: ErrInvalidRoomLayout, если клетки не помещаются на поле
+//
+// Особенности:
+//   - Если ширина и высота не заданы, клетки проверяются по начальному квадратному полю
+//   - При BlockedCount клетки генерируются из BlockedSeed (или случайного зерна),
+//     поэтому одна и та же комната всегда получает одну и ту же раскладку
+func roomLayout(form *common.RoomRequest) ([]string, *int64, error) {
+	height, width := int(form.Height), int(form.Width)
+	if height == 0 || width == 0 {
+		size := int(defaultBorderSize(uint64(form.Capacity)))
+		height, width = size, size
+	}
+	if form.BlockedCount > 0 {
+		seed := rand.Int64()
+		if form.BlockedSeed != nil {
+			seed = *form.BlockedSeed
+		}
+		cells, err := generateBlockedCells(height, width, int(form.BlockedCount), seed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRoomLayout, err)
+		}
+		return cells, &seed, nil
+	}
+	if err := validateBlockedCells(form.BlockedCells, height, width); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRoomLayout, err)
+	}
+	if form.BlockedCells == nil {
+		return []string{}, nil, nil
+	}
+	return form.BlockedCells, nil, nil
+}
+
 // DeleteById удаляет комнату по её идентификатору.
 func (service *RoomService) DeleteById(ctx context.Context, id uint64) error {
 	return service.repo.DeleteById(ctx, id)
@@ -4,25 +4,32 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
 	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
 )
 
-// ScoreService предоставляет методы для работы со счетами пользователей.
+// ScoreService предоставляет методы для работы со счетами пользователей
+// и их рейтингами в рейтинговых партиях.
 type ScoreService struct {
-	scoreRepo repository.ScoreRepository
-	userRepo  repository.UserRepository
+	scoreRepo  repository.ScoreRepository
+	userRepo   repository.UserRepository
+	ratingRepo repository.RatingRepository
 }
 
 // NewScoreService создаёт новый экземпляр ScoreService.
 func NewScoreService(
 	scoreRepo repository.ScoreRepository,
 	userRepo repository.UserRepository,
+	ratingRepo repository.RatingRepository,
 ) *ScoreService {
 	return &ScoreService{
-		scoreRepo: scoreRepo,
-		userRepo:  userRepo,
+		scoreRepo:  scoreRepo,
+		userRepo:   userRepo,
+		ratingRepo: ratingRepo,
 	}
 }
 
@@ -38,3 +45,190 @@ func (service *ScoreService) GetCurrentUserScores(ctx context.Context) ([]*commo
 	}
 	return service.scoreRepo.FindAllByUser(ctx, user)
 }
+
+// RecordResult сохраняет результаты партии
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - currentRoom: комната, в которой сыграна партия
+//   - scores: результаты игроков, которые нужно сохранить
+//   - placements: места всех игроков партии (в том числе тех, чьи результаты не сохраняются)
+//
+// Возвращает:
+//   - error: ошибка сохранения
+//
+// Особенности:
+//   - Флаг победы (is_won) выводится из результата игрока (см. resultIsWon)
+//   - Результат бота не сохраняется, а партия с ботом всегда товарищеская
+//   - В товарищеской комнате и без мест игроков (брошенная партия) результаты просто сохраняются
+//   - В рейтинговой комнате для каждого игрока с результатом, кроме брошенных партий
+//     (abandoned), вычисляется рейтинг Glicko-2
+//     в пуле варианта и размера поля и в общем пуле: каждый соперник — отдельная встреча,
+//     очки против него определяются местами (выше — 1, то же место — 0.5, ниже — 0)
+//   - Рейтинги читаются и пересчитываются (см. rateScores) внутри транзакции сохранения
+//     результатов с блокировкой игроков, поэтому одновременные партии не теряют изменений
+//   - Результаты, новые рейтинги и история рейтинга сохраняются в одной транзакции
+func (service *ScoreService) RecordResult(
+	ctx context.Context,
+	currentRoom *RoomServer,
+	scores []*common.Score,
+	placements map[uuid.UUID]uint8,
+) error {
+	humanScores := make([]*common.Score, 0, len(scores))
+	for _, score := range scores {
+		if score.UserID != botUserID.String() {
+			humanScores = append(humanScores, score)
+		}
+	}
+	scores = humanScores
+	for _, score := range scores {
+		score.IsWon = resultIsWon(score.Result)
+	}
+	if _, withBot := placements[botUserID]; withBot {
+		placements = nil
+	}
+	if !currentRoom.IsRated || len(placements) == 0 {
+		for _, score := range scores {
+			if err := service.scoreRepo.Create(ctx, score); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	userIDs := make([]uuid.UUID, 0, len(placements))
+	for userID := range placements {
+		userIDs = append(userIDs, userID)
+	}
+	now := time.Now()
+	pools := [][2]string{
+		{currentRoom.Variant, boardSizeLabel(currentRoom)},
+		{"", ""},
+	}
+	for _, score := range scores {
+		score.IsRated = score.Result != abandonedResult
+	}
+	return service.scoreRepo.CreateRated(ctx, scores, userIDs, pools, func(variant, boardSize string, found []*common.Rating) ([]*common.Rating, []*common.RatingChange, error) {
+		return rateScores(scores, userIDs, placements, variant, boardSize, found, now)
+	})
+}
+
+// rateScores вычисляет новые рейтинги игроков в пуле
+//
+// Параметры:
+//   - scores: результаты игроков партии
+//   - userIDs: все игроки партии
+//   - placements: места игроков
+//   - variant, boardSize: пул рейтинга
+//   - found: текущие рейтинги игроков в пуле (игроков без рейтинговых партий в списке нет)
+//   - now: время партии для glickoDecay
+//
+// Возвращает:
+//   - []*common.Rating: новые рейтинги игроков с результатом, кроме брошенных партий
+//   - []*common.RatingChange: записи истории рейтинга
+//   - error: если ID игрока в результате неверный
+func rateScores(
+	scores []*common.Score,
+	userIDs []uuid.UUID,
+	placements map[uuid.UUID]uint8,
+	variant, boardSize string,
+	found []*common.Rating,
+	now time.Time,
+) ([]*common.Rating, []*common.RatingChange, error) {
+	current := make(map[uuid.UUID]*common.Rating, len(userIDs))
+	for _, rating := range found {
+		current[rating.UserID] = rating
+	}
+	for _, userID := range userIDs {
+		if current[userID] == nil {
+			current[userID] = newRating(userID, variant, boardSize)
+		}
+		glickoDecay(current[userID], now)
+	}
+	ratings := make([]*common.Rating, 0, len(scores))
+	changes := make([]*common.RatingChange, 0, len(scores))
+	for _, score := range scores {
+		if score.Result == abandonedResult {
+			continue
+		}
+		userID, err := uuid.Parse(score.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		before := current[userID]
+		if before == nil {
+			continue
+		}
+		opponents := make([]glickoOpponent, 0, len(userIDs)-1)
+		for _, opponentID := range userIDs {
+			if opponentID == userID {
+				continue
+			}
+			result := 0.5
+			if placements[userID] < placements[opponentID] {
+				result = 1
+			} else if placements[userID] > placements[opponentID] {
+				result = 0
+			}
+			opponents = append(opponents, glickoOpponent{rating: current[opponentID], score: result})
+		}
+		rating, rd, volatility := glickoRate(before, opponents)
+		ratings = append(ratings, &common.Rating{
+			UserID:     userID,
+			Variant:    variant,
+			BoardSize:  boardSize,
+			Rating:     rating,
+			RD:         rd,
+			Volatility: volatility,
+			Games:      before.Games + 1,
+		})
+		changes = append(changes, &common.RatingChange{
+			UserID:           userID,
+			Variant:          variant,
+			BoardSize:        boardSize,
+			RatingBefore:     before.Rating,
+			RatingAfter:      rating,
+			RDBefore:         before.RD,
+			RDAfter:          rd,
+			VolatilityBefore: before.Volatility,
+			VolatilityAfter:  volatility,
+		})
+	}
+	return ratings, changes, nil
+}
+
+// PlayerRating возвращает рейтинг игрока для подбора соперника
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - userID: идентификатор игрока
+//   - variant, boardSize: пул варианта правил и размера поля
+//
+// Возвращает:
+//   - float64: рейтинг в пуле варианта и размера поля, если игрок в нём играл,
+//     иначе общий рейтинг или GLICKO_DEFAULT_RATING
+//   - error: ошибка запроса
+func (service *ScoreService) PlayerRating(ctx context.Context, userID uuid.UUID, variant, boardSize string) (float64, error) {
+	for _, pool := range [][2]string{{variant, boardSize}, {"", ""}} {
+		ratings, err := service.ratingRepo.FindByUsers(ctx, []uuid.UUID{userID}, pool[0], pool[1])
+		if err != nil {
+			return 0, err
+		}
+		if len(ratings) > 0 {
+			return ratings[0].Rating, nil
+		}
+	}
+	return GLICKO_DEFAULT_RATING, nil
+}
+
+// resultIsWon возвращает флаг победы для результата игрока:
+// 1 — победа, -1 — ничья, 0 — поражение, сдача, время и брошенная партия.
+func resultIsWon(result string) float64 {
+	switch result {
+	case winResult:
+		return 1
+	case drawResult:
+		return -1
+	default:
+		return 0
+	}
+}
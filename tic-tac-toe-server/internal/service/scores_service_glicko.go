@@ -0,0 +1,131 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// Параметры рейтинга Glicko-2 (см. http://www.glicko.net/glicko/glicko2.pdf).
+const (
+	// GLICKO_DEFAULT_RATING задаёт рейтинг игрока без рейтинговых партий в пуле.
+	GLICKO_DEFAULT_RATING = 1500.0
+	// GLICKO_DEFAULT_RD задаёт отклонение рейтинга нового игрока; больше оно не растёт.
+	GLICKO_DEFAULT_RD = 350.0
+	// GLICKO_DEFAULT_VOLATILITY задаёт волатильность рейтинга нового игрока.
+	GLICKO_DEFAULT_VOLATILITY = 0.06
+	// GLICKO_TAU ограничивает изменение волатильности за одну партию.
+	GLICKO_TAU = 0.5
+	// GLICKO_SCALE переводит рейтинг в шкалу Glicko-2 и обратно.
+	GLICKO_SCALE = 173.7178
+	// GLICKO_EPSILON задаёт точность вычисления новой волатильности.
+	GLICKO_EPSILON = 0.000001
+	// RATING_PERIOD задаёт рейтинговый период: за каждый период без партий
+	// отклонение рейтинга растёт.
+	RATING_PERIOD = 24 * time.Hour
+)
+
+// glickoOpponent — соперник в рейтинговой партии и очки против него
+// (1 — игрок занял место выше, 0.5 — то же место, 0 — ниже).
+type glickoOpponent struct {
+	rating *common.Rating
+	score  float64
+}
+
+// newRating возвращает рейтинг игрока без рейтинговых партий в пуле.
+func newRating(userID uuid.UUID, variant, boardSize string) *common.Rating {
+	return &common.Rating{
+		UserID:     userID,
+		Variant:    variant,
+		BoardSize:  boardSize,
+		Rating:     GLICKO_DEFAULT_RATING,
+		RD:         GLICKO_DEFAULT_RD,
+		Volatility: GLICKO_DEFAULT_VOLATILITY,
+	}
+}
+
+// glickoDecay увеличивает отклонение рейтинга за рейтинговые периоды без партий
+// (не больше GLICKO_DEFAULT_RD).
+func glickoDecay(rating *common.Rating, now time.Time) {
+	if rating.Games == 0 || !now.After(rating.UpdatedAt) {
+		return
+	}
+	periods := float64(now.Sub(rating.UpdatedAt)) / float64(RATING_PERIOD)
+	phi := rating.RD / GLICKO_SCALE
+	phi = math.Sqrt(phi*phi + rating.Volatility*rating.Volatility*periods)
+	rating.RD = math.Min(phi*GLICKO_SCALE, GLICKO_DEFAULT_RD)
+}
+
+// glickoRate вычисляет рейтинг игрока после партии
+//
+// Параметры:
+//   - rating: рейтинг игрока до партии (с учётом glickoDecay)
+//   - opponents: соперники с их рейтингами до партии и очками игрока против каждого
+//
+// Возвращает:
+//   - рейтинг, отклонение рейтинга и волатильность после партии
+//
+// Особенности:
+//   - Партия считается отдельным рейтинговым периодом; в партии на несколько игроков
+//     каждый соперник — отдельная встреча
+func glickoRate(rating *common.Rating, opponents []glickoOpponent) (float64, float64, float64) {
+	mu := (rating.Rating - GLICKO_DEFAULT_RATING) / GLICKO_SCALE
+	phi := rating.RD / GLICKO_SCALE
+	sigma := rating.Volatility
+	if len(opponents) == 0 {
+		return rating.Rating, rating.RD, sigma
+	}
+	var variance, improvement float64
+	for _, opponent := range opponents {
+		opponentMu := (opponent.rating.Rating - GLICKO_DEFAULT_RATING) / GLICKO_SCALE
+		opponentPhi := opponent.rating.RD / GLICKO_SCALE
+		g := 1 / math.Sqrt(1+3*opponentPhi*opponentPhi/(math.Pi*math.Pi))
+		expected := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+		variance += g * g * expected * (1 - expected)
+		improvement += g * (opponent.score - expected)
+	}
+	variance = 1 / variance
+	delta := variance * improvement
+	sigma = glickoVolatility(phi, sigma, variance, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	mu += phi * phi * improvement
+	return mu*GLICKO_SCALE + GLICKO_DEFAULT_RATING, math.Min(phi*GLICKO_SCALE, GLICKO_DEFAULT_RD), sigma
+}
+
+// glickoVolatility вычисляет новую волатильность методом Иллинойса (шаг 5 алгоритма Glicko-2).
+func glickoVolatility(phi, sigma, variance, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + variance + ex
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*d*d) - (x-a)/(GLICKO_TAU*GLICKO_TAU)
+	}
+	lower := a
+	var upper float64
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*GLICKO_TAU) < 0 {
+			k++
+		}
+		upper = a - k*GLICKO_TAU
+	}
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > GLICKO_EPSILON {
+		next := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fNext := f(next)
+		if fNext*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+		upper, fUpper = next, fNext
+	}
+	return math.Exp(lower / 2)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+func TestGlickoRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		rating     *common.Rating
+		opponents  []glickoOpponent
+		want       float64
+		wantRD     float64
+		volatility float64
+	}{
+		{
+			// Пример из описания алгоритма Glicko-2 (http://www.glicko.net/glicko/glicko2.pdf)
+			name:   "glicko-2 paper example",
+			rating: &common.Rating{Rating: 1500, RD: 200, Volatility: 0.06},
+			opponents: []glickoOpponent{
+				{rating: &common.Rating{Rating: 1400, RD: 30}, score: 1},
+				{rating: &common.Rating{Rating: 1550, RD: 100}, score: 0},
+				{rating: &common.Rating{Rating: 1700, RD: 300}, score: 0},
+			},
+			want:       1464.06,
+			wantRD:     151.52,
+			volatility: 0.05999,
+		},
+		{
+			name:       "no opponents",
+			rating:     &common.Rating{Rating: 1620, RD: 80, Volatility: 0.05},
+			want:       1620,
+			wantRD:     80,
+			volatility: 0.05,
+		},
+		{
+			name:   "draw between equal new players",
+			rating: &common.Rating{Rating: GLICKO_DEFAULT_RATING, RD: GLICKO_DEFAULT_RD, Volatility: GLICKO_DEFAULT_VOLATILITY},
+			opponents: []glickoOpponent{
+				{rating: &common.Rating{Rating: GLICKO_DEFAULT_RATING, RD: GLICKO_DEFAULT_RD}, score: 0.5},
+			},
+			want:       GLICKO_DEFAULT_RATING,
+			wantRD:     290.32,
+			volatility: 0.06,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating, rd, volatility := glickoRate(tt.rating, tt.opponents)
+			if math.Abs(rating-tt.want) > 0.01 {
+				t.Errorf("glickoRate() rating = %.4f, want %.2f", rating, tt.want)
+			}
+			if math.Abs(rd-tt.wantRD) > 0.01 {
+				t.Errorf("glickoRate() rd = %.4f, want %.2f", rd, tt.wantRD)
+			}
+			if math.Abs(volatility-tt.volatility) > 0.00001 {
+				t.Errorf("glickoRate() volatility = %.6f, want %.5f", volatility, tt.volatility)
+			}
+		})
+	}
+}
+
+func TestGlickoDecay(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		rating *common.Rating
+		want   float64
+	}{
+		{
+			name:   "one rating period",
+			rating: &common.Rating{RD: 200, Volatility: 0.06, Games: 3, UpdatedAt: now.Add(-RATING_PERIOD)},
+			want:   200.27,
+		},
+		{
+			name:   "capped at default rd",
+			rating: &common.Rating{RD: 340, Volatility: 0.06, Games: 3, UpdatedAt: now.Add(-1000 * RATING_PERIOD)},
+			want:   GLICKO_DEFAULT_RD,
+		},
+		{
+			name:   "no rated games",
+			rating: &common.Rating{RD: 200, Volatility: 0.06, UpdatedAt: now.Add(-RATING_PERIOD)},
+			want:   200,
+		},
+		{
+			name:   "updated in the future",
+			rating: &common.Rating{RD: 200, Volatility: 0.06, Games: 3, UpdatedAt: now.Add(time.Hour)},
+			want:   200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glickoDecay(tt.rating, now)
+			if math.Abs(tt.rating.RD-tt.want) > 0.01 {
+				t.Errorf("glickoDecay() rd = %.4f, want %.2f", tt.rating.RD, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,849 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// Параметры турниров.
+const (
+	// TOURNAMENT_DEFAULT_PARTICIPANTS задаёт наибольшее число участников, если оно не указано.
+	TOURNAMENT_DEFAULT_PARTICIPANTS = 32
+	// TOURNAMENT_LIST_LIMIT задаёт, сколько последних турниров возвращает список.
+	TOURNAMENT_LIST_LIMIT = 50
+	// TOURNAMENT_SCHEDULE_INTERVAL задаёт, как часто проверяются старты турниров и кругов по расписанию.
+	TOURNAMENT_SCHEDULE_INTERVAL = 30 * time.Second
+)
+
+// Форматы турниров.
+const (
+	singleEliminationFormat = "single_elimination"
+	swissFormat             = "swiss"
+	roundRobinFormat        = "round_robin"
+)
+
+// Состояния турнира.
+const (
+	registrationTournamentStatus = "registration"
+	runningTournamentStatus      = "running"
+	finishedTournamentStatus     = "finished"
+)
+
+// Состояния матча турнира.
+const (
+	pendingTournamentMatchStatus  = "pending"
+	playingTournamentMatchStatus  = "playing"
+	finishedTournamentMatchStatus = "finished"
+	byeTournamentMatchStatus      = "bye"
+)
+
+// Ошибки турниров.
+var (
+	// ErrTournamentNotFound возвращается, если турнир не найден.
+	ErrTournamentNotFound = errors.New("tournament not found")
+	// ErrInvalidTournament возвращается при неверных настройках турнира.
+	ErrInvalidTournament = errors.New("invalid tournament settings")
+	// ErrTournamentClosed возвращается при регистрации или старте турнира, регистрация в который закрыта.
+	ErrTournamentClosed = errors.New("tournament registration is closed")
+	// ErrTournamentFull возвращается при регистрации в турнир, в котором нет свободных мест.
+	ErrTournamentFull = errors.New("tournament is full")
+	// ErrNotEnoughParticipants возвращается при старте турнира меньше чем с двумя участниками.
+	ErrNotEnoughParticipants = errors.New("tournament needs at least two participants")
+	// ErrNotRegistered возвращается, если пользователь не зарегистрирован в турнире.
+	ErrNotRegistered = errors.New("user is not registered in the tournament")
+	// ErrTournamentNotRunning возвращается при старте круга турнира, который не идёт.
+	ErrTournamentNotRunning = errors.New("tournament is not running")
+	// ErrNoMoreRounds возвращается при старте круга, если начаты все круги турнира.
+	ErrNoMoreRounds = errors.New("all tournament rounds have started")
+	// ErrRoundInProgress возвращается при старте круга, пока не решены все матчи текущего.
+	ErrRoundInProgress = errors.New("current round is still in progress")
+	// ErrTournamentMatchNotFound возвращается, если матч не найден в турнире.
+	ErrTournamentMatchNotFound = errors.New("tournament match not found")
+	// ErrTournamentMatchDecided возвращается при назначении победителя матча, который уже решён
+	// или ещё не может играться.
+	ErrTournamentMatchDecided = errors.New("tournament match is not in play")
+	// ErrInvalidMatchWinner возвращается, если назначенный победитель не играет в матче
+	// или ничья назначается в турнире на выбывание.
+	ErrInvalidMatchWinner = errors.New("winner is not a player of the match")
+)
+
+// TournamentService проводит турниры: регистрирует участников, строит сетку
+// или круги швейцарской и круговой систем и продвигает турнир по мере окончания партий.
+type TournamentService struct {
+	repo   repository.TournamentRepository
+	rooms  *RoomService
+	scores *ScoreService
+	mu     *sync.Mutex
+}
+
+// NewTournamentService создаёт новый экземпляр TournamentService.
+// Турниры и круги по расписанию начинаются после запуска RunScheduler.
+func NewTournamentService(repo repository.TournamentRepository, rooms *RoomService, scores *ScoreService) *TournamentService {
+	return &TournamentService{
+		repo:   repo,
+		rooms:  rooms,
+		scores: scores,
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Create создаёт турнир в состоянии регистрации
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем (администратором)
+//   - form: название, формат и настройки партий турнира
+//
+// Логика:
+//  1. Формат по умолчанию — single_elimination, вариант — classic
+//  2. Вариант должен быть зарегистрирован, размер поля задаётся только
+//     для вариантов с настраиваемым полем
+//  3. Наибольшее число участников по умолчанию TOURNAMENT_DEFAULT_PARTICIPANTS
+//  4. Число кругов задаётся только для швейцарской системы, интервал кругов —
+//     для швейцарской и круговой систем, время старта должно быть в будущем
+//
+// Возвращает:
+//   - *common.Tournament: созданный турнир
+//   - error: ErrInvalidTournament или ошибка запроса
+func (service *TournamentService) Create(ctx context.Context, form common.TournamentRequest) (*common.Tournament, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	if form.Format == "" {
+		form.Format = singleEliminationFormat
+	}
+	if form.Variant == "" {
+		form.Variant = classicVariant
+	}
+	rules, ok := LookupRuleSet(form.Variant)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown variant %s", ErrInvalidTournament, form.Variant)
+	}
+	if form.BoardSize != "" {
+		if rules.Settings().BorderSize > 0 {
+			return nil, fmt.Errorf("%w: variant %s has a fixed board size", ErrInvalidTournament, form.Variant)
+		}
+		if _, _, ok := parseNotationSize(form.BoardSize); !ok {
+			return nil, fmt.Errorf("%w: board size %q must be <width>x<height> from 3 to 15", ErrInvalidTournament, form.BoardSize)
+		}
+	}
+	if form.Rounds != 0 && form.Format != swissFormat {
+		return nil, fmt.Errorf("%w: rounds can only be set for swiss tournaments", ErrInvalidTournament)
+	}
+	if form.RoundInterval != 0 && form.Format == singleEliminationFormat {
+		return nil, fmt.Errorf("%w: knockout rounds start as soon as the previous matches finish", ErrInvalidTournament)
+	}
+	if form.StartsAt != nil && !form.StartsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: start time must be in the future", ErrInvalidTournament)
+	}
+	if form.MaxParticipants == 0 {
+		form.MaxParticipants = TOURNAMENT_DEFAULT_PARTICIPANTS
+	}
+	tournament := &common.Tournament{
+		Name:            form.Name,
+		CreatorID:       user.ID,
+		Format:          form.Format,
+		Status:          registrationTournamentStatus,
+		Variant:         form.Variant,
+		BoardSize:       form.BoardSize,
+		WinLength:       form.WinLength,
+		IsRated:         form.IsRated != nil && *form.IsRated,
+		MaxParticipants: form.MaxParticipants,
+		Rounds:          form.Rounds,
+		StartsAt:        form.StartsAt,
+		RoundInterval:   form.RoundInterval,
+		CreatedAt:       time.Now(),
+	}
+	id, err := service.repo.Create(ctx, tournament)
+	if err != nil {
+		return nil, err
+	}
+	tournament.ID = id
+	return tournament, nil
+}
+
+// GetAll возвращает последние TOURNAMENT_LIST_LIMIT турниров, начиная с новых.
+func (service *TournamentService) GetAll(ctx context.Context) ([]*common.Tournament, error) {
+	return service.repo.FindAll(ctx, TOURNAMENT_LIST_LIMIT)
+}
+
+// GetById возвращает турнир с участниками и живой сеткой
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - id: идентификатор турнира
+//   - ws: WebSocket сервер для получения состояния комнат матчей
+//
+// Возвращает:
+//   - *common.TournamentResponse: турнир, участники, круги сетки и, для швейцарской
+//     и круговой систем, турнирная таблица (см. tournamentStandings)
+//   - error: ErrTournamentNotFound или ошибка запроса
+//
+// Особенности:
+//   - Для матчей, которые играются, в ответе есть число участников в комнате,
+//     состояние партии и число её ходов
+//   - Пароль комнаты видят только участники матча, пока матч играется
+func (service *TournamentService) GetById(ctx context.Context, id uint64, ws *WSServer) (*common.TournamentResponse, error) {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return nil, errors.New("userId is not correct")
+	}
+	tournament, participants, matches, err := service.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := &common.TournamentResponse{
+		Tournament:   tournament,
+		Participants: participants,
+		Bracket:      make([]*common.TournamentRoundResponse, 0, tournament.Rounds),
+	}
+	if tournament.Format != singleEliminationFormat && tournament.Status != registrationTournamentStatus {
+		response.Standings = tournamentStandings(tournament, participants, matches)
+	}
+	players := make(map[uuid.UUID]*common.UserResponse, len(participants))
+	for _, participant := range participants {
+		players[participant.UserID] = &common.UserResponse{
+			ID:   participant.UserID,
+			Name: participant.Name,
+		}
+		if participant.UserID == user.ID {
+			response.IsRegistered = true
+		}
+	}
+	for _, match := range matches {
+		if len(response.Bracket) == 0 || response.Bracket[len(response.Bracket)-1].Round != match.Round {
+			response.Bracket = append(response.Bracket, &common.TournamentRoundResponse{
+				Round:   match.Round,
+				Matches: make([]*common.TournamentMatchResponse, 0),
+			})
+		}
+		matchResponse := &common.TournamentMatchResponse{
+			ID:       match.ID,
+			Position: match.Position,
+			Status:   match.Status,
+			WinnerID: match.WinnerID,
+			RoomID:   match.RoomID,
+			GameID:   match.GameID,
+		}
+		if match.FirstID != nil {
+			matchResponse.First = players[*match.FirstID]
+		}
+		if match.SecondID != nil {
+			matchResponse.Second = players[*match.SecondID]
+		}
+		if match.Status == playingTournamentMatchStatus {
+			if isMatchPlayer(match, user.ID) {
+				matchResponse.Password = match.RoomPassword
+			}
+			if players, status, moves, ok := ws.roomState(*match.RoomID); ok {
+				matchResponse.PlayersIn = players
+				matchResponse.GameStatus = status
+				matchResponse.Moves = moves
+			}
+		}
+		round := response.Bracket[len(response.Bracket)-1]
+		round.Matches = append(round.Matches, matchResponse)
+	}
+	return response, nil
+}
+
+// Register регистрирует текущего пользователя в турнире
+//
+// Параметры:
+//   - ctx: контекст запроса с текущим пользователем
+//   - id: идентификатор турнира
+//
+// Возвращает:
+//   - error: ErrTournamentNotFound, ErrTournamentClosed, если регистрация закрыта,
+//     ErrTournamentFull, если свободных мест нет, или ошибка запроса
+//
+// Особенности:
+//   - Повторная регистрация ничего не меняет
+//   - Вместе с участником сохраняется его текущий рейтинг в пуле турнира
+func (service *TournamentService) Register(ctx context.Context, id uint64) error {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return errors.New("userId is not correct")
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	tournament, participants, _, err := service.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tournament.Status != registrationTournamentStatus {
+		return ErrTournamentClosed
+	}
+	for _, participant := range participants {
+		if participant.UserID == user.ID {
+			return nil
+		}
+	}
+	if len(participants) >= int(tournament.MaxParticipants) {
+		return ErrTournamentFull
+	}
+	variant, boardSize := tournamentPool(tournament)
+	rating, err := service.scores.PlayerRating(ctx, user.ID, variant, boardSize)
+	if err != nil {
+		return err
+	}
+	return service.repo.AddParticipant(ctx, &common.TournamentParticipant{
+		TournamentID: tournament.ID,
+		UserID:       user.ID,
+		Rating:       rating,
+	})
+}
+
+// Withdraw снимает текущего пользователя с турнира.
+// Возвращает ErrTournamentNotFound, ErrTournamentClosed, если турнир уже начался,
+// или ErrNotRegistered, если пользователь не зарегистрирован.
+func (service *TournamentService) Withdraw(ctx context.Context, id uint64) error {
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return errors.New("userId is not correct")
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	tournament, err := service.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tournament.Status != registrationTournamentStatus {
+		return ErrTournamentClosed
+	}
+	if err := service.repo.RemoveParticipant(ctx, tournament.ID, user.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotRegistered
+		}
+		return err
+	}
+	return nil
+}
+
+// Start закрывает регистрацию и начинает турнир (см. start).
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор турнира
+//
+// Возвращает:
+//   - *common.Tournament: начатый турнир
+//   - error: ErrTournamentNotFound, ErrTournamentClosed, если турнир уже начался,
+//     ErrNotEnoughParticipants или ошибка запроса
+func (service *TournamentService) Start(ctx context.Context, id uint64) (*common.Tournament, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	tournament, participants, _, err := service.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.start(ctx, tournament, participants); err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// RecordResult продвигает турнир по итогу партии в комнате турнирного матча
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - roomID: комната, в которой сыграна партия
+//   - scores: результаты игроков партии
+//
+// Логика:
+//  1. Если комната не принадлежит матчу, который играется, ничего не происходит
+//  2. Брошенная партия не решает матч: участники переигрывают в той же комнате
+//  3. В турнире на выбывание ничья тоже переигрывается (см. finishEliminationMatch),
+//     в швейцарской и круговой системах победа и ничья решают матч (см. finishRoundMatch)
+//
+// Возвращает:
+//   - error: ошибка запроса
+func (service *TournamentService) RecordResult(ctx context.Context, roomID uint64, scores []*common.Score) error {
+	var winnerID *uuid.UUID
+	var gameID *uint64
+	isDraw := false
+	players := make([]uuid.UUID, 0, len(scores))
+	for _, score := range scores {
+		userID, err := uuid.Parse(score.UserID)
+		if err != nil {
+			return err
+		}
+		players = append(players, userID)
+		switch score.Result {
+		case winResult:
+			winnerID, gameID = &userID, score.GameID
+		case drawResult:
+			isDraw, gameID = true, score.GameID
+		}
+	}
+	if winnerID == nil && !isDraw {
+		return nil
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	found, err := service.repo.FindMatchByRoom(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if found.Status != playingTournamentMatchStatus {
+		return nil
+	}
+	for _, userID := range players {
+		if !isMatchPlayer(found, userID) {
+			return nil
+		}
+	}
+	tournament, participants, matches, err := service.load(ctx, found.TournamentID)
+	if err != nil {
+		return err
+	}
+	match := bracketMatch(matches, found.Round, found.Position)
+	if match == nil {
+		return nil
+	}
+	if tournament.Format != singleEliminationFormat {
+		return service.finishRoundMatch(ctx, tournament, participants, matches, match, winnerID, gameID)
+	}
+	if winnerID == nil {
+		return nil
+	}
+	return service.finishEliminationMatch(ctx, tournament, participants, matches, match, *winnerID, gameID)
+}
+
+// SetMatchWinner решает матч администратором, например если участник не пришёл
+// или комната матча закрыта
+//
+// Параметры:
+//   - ctx: контекст запроса
+//   - id: идентификатор турнира
+//   - matchID: идентификатор матча
+//   - form: победитель матча (пустой — ничья, только для швейцарской и круговой систем)
+//
+// Логика:
+//  1. Решить можно только матч, в котором известны оба участника и который ещё не решён
+//  2. Матч решается так же, как по итогу партии (см. finishEliminationMatch и finishRoundMatch),
+//     но без ссылки на партию
+//
+// Возвращает:
+//   - *common.Tournament: турнир после решения матча
+//   - error: ErrTournamentNotFound, ErrTournamentMatchNotFound, ErrTournamentNotRunning,
+//     ErrTournamentMatchDecided, ErrInvalidMatchWinner или ошибка запроса
+func (service *TournamentService) SetMatchWinner(ctx context.Context, id, matchID uint64, form common.TournamentMatchWinnerRequest) (*common.Tournament, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	tournament, participants, matches, err := service.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	found, err := service.repo.FindMatchById(ctx, id, matchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTournamentMatchNotFound
+		}
+		return nil, err
+	}
+	if tournament.Status != runningTournamentStatus {
+		return nil, ErrTournamentNotRunning
+	}
+	match := bracketMatch(matches, found.Round, found.Position)
+	if match == nil {
+		return nil, ErrTournamentMatchNotFound
+	}
+	if (match.Status != pendingTournamentMatchStatus && match.Status != playingTournamentMatchStatus) ||
+		match.FirstID == nil || match.SecondID == nil {
+		return nil, ErrTournamentMatchDecided
+	}
+	var winnerID *uuid.UUID
+	if form.WinnerID != "" {
+		userID, err := uuid.Parse(form.WinnerID)
+		if err != nil || !isMatchPlayer(match, userID) {
+			return nil, ErrInvalidMatchWinner
+		}
+		winnerID = &userID
+	}
+	if tournament.Format != singleEliminationFormat {
+		err = service.finishRoundMatch(ctx, tournament, participants, matches, match, winnerID, nil)
+	} else if winnerID == nil {
+		return nil, ErrInvalidMatchWinner
+	} else {
+		err = service.finishEliminationMatch(ctx, tournament, participants, matches, match, *winnerID, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// start посеивает участников и строит матчи турнира
+//
+// Логика:
+//  1. Участники посеиваются по текущему рейтингу в пуле турнира (см. ScoreService.PlayerRating),
+//     при равном рейтинге выше тот, кто зарегистрировался раньше
+//  2. Матчи строятся по формату турнира: сетка на выбывание (см. eliminationBracket),
+//     первый круг швейцарской системы (см. swissRound) или расписание всех кругов
+//     круговой системы (см. roundRobinSchedule)
+//  3. Турнир, посев и матчи сохраняются вместе (см. TournamentRepository.Start)
+//  4. Для матчей, которые можно играть, создаются комнаты (см. openMatches)
+//
+// Возвращает:
+//   - error: ErrTournamentClosed, если турнир уже начался, ErrNotEnoughParticipants
+//     или ошибка запроса
+func (service *TournamentService) start(ctx context.Context, tournament *common.Tournament, participants []*common.TournamentParticipant) error {
+	if tournament.Status != registrationTournamentStatus {
+		return ErrTournamentClosed
+	}
+	if len(participants) < 2 {
+		return ErrNotEnoughParticipants
+	}
+	variant, boardSize := tournamentPool(tournament)
+	for _, participant := range participants {
+		rating, err := service.scores.PlayerRating(ctx, participant.UserID, variant, boardSize)
+		if err != nil {
+			return err
+		}
+		participant.Rating = rating
+	}
+	sort.SliceStable(participants, func(i, j int) bool {
+		return participants[i].Rating > participants[j].Rating
+	})
+	for i, participant := range participants {
+		participant.Seed = uint16(i + 1)
+	}
+	now := time.Now()
+	tournament.Status = runningTournamentStatus
+	tournament.StartedAt = &now
+	tournament.RoundStartedAt = &now
+	var matches []*common.TournamentMatch
+	switch tournament.Format {
+	case swissFormat:
+		tournament.Rounds = swissRounds(tournament.Rounds, len(participants))
+		tournament.CurrentRound = 1
+		matches = swissRound(tournament, participants, nil)
+	case roundRobinFormat:
+		matches, tournament.Rounds = roundRobinSchedule(participants)
+		tournament.CurrentRound = 1
+	default:
+		matches, tournament.Rounds = eliminationBracket(participants)
+		tournament.CurrentRound = currentTournamentRound(tournament, matches)
+	}
+	if err := service.repo.Start(ctx, tournament, participants, matches); err != nil {
+		return err
+	}
+	return service.openMatches(ctx, tournament, participants, matches)
+}
+
+// finishEliminationMatch решает матч турнира на выбывание
+//
+// Логика:
+//  1. Победитель матча проходит в следующий круг, проигравший выбывает
+//  2. Когда в матче следующего круга известны оба участника, для него создаётся комната
+//  3. Победитель финала становится победителем турнира
+//  4. Выбывание, матчи и турнир сохраняются вместе (см. TournamentRepository.FinishEliminationMatch)
+func (service *TournamentService) finishEliminationMatch(
+	ctx context.Context,
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+	match *common.TournamentMatch,
+	winnerID uuid.UUID,
+	gameID *uint64,
+) error {
+	match.Status = finishedTournamentMatchStatus
+	match.GameID = gameID
+	loserID := match.FirstID
+	if *loserID == winnerID {
+		loserID = match.SecondID
+	}
+	changed := []*common.TournamentMatch{match}
+	if next := advanceWinner(tournament, matches, match, winnerID); next != nil {
+		changed = append(changed, next)
+	}
+	tournament.CurrentRound = currentTournamentRound(tournament, matches)
+	if tournament.WinnerID != nil {
+		now := time.Now()
+		tournament.Status = finishedTournamentStatus
+		tournament.FinishedAt = &now
+	}
+	if err := service.repo.FinishEliminationMatch(ctx, tournament, *loserID, match.Round, changed); err != nil {
+		return err
+	}
+	return service.openMatches(ctx, tournament, participants, changed)
+}
+
+// find находит турнир по идентификатору; возвращает ErrTournamentNotFound, если его нет.
+func (service *TournamentService) find(ctx context.Context, id uint64) (*common.Tournament, error) {
+	tournament, err := service.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// load находит турнир вместе с его участниками и матчами.
+func (service *TournamentService) load(ctx context.Context, id uint64) (
+	*common.Tournament,
+	[]*common.TournamentParticipant,
+	[]*common.TournamentMatch,
+	error,
+) {
+	tournament, err := service.find(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	participants, err := service.repo.FindParticipants(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	matches, err := service.repo.FindMatches(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tournament, participants, matches, nil
+}
+
+// openMatches открывает матчи, которые можно играть (см. createMatchRooms), и сохраняет их.
+func (service *TournamentService) openMatches(
+	ctx context.Context,
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+) error {
+	opened, err := service.createMatchRooms(ctx, tournament, participants, matches)
+	if err != nil {
+		return err
+	}
+	for _, match := range opened {
+		if err := service.repo.UpdateMatch(ctx, match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createMatchRooms создаёт комнаты для ожидающих матчей, в которых известны оба участника,
+// и переводит их в состояние "играется", не сохраняя сами матчи. В швейцарской и круговой
+// системах открываются только матчи текущего круга.
+//
+// Возвращает:
+//   - []*common.TournamentMatch: открытые матчи
+//   - error: ошибка создания комнаты
+func (service *TournamentService) createMatchRooms(
+	ctx context.Context,
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+) ([]*common.TournamentMatch, error) {
+	names := make(map[uuid.UUID]string, len(participants))
+	for _, participant := range participants {
+		names[participant.UserID] = participant.Name
+	}
+	opened := make([]*common.TournamentMatch, 0)
+	for _, match := range matches {
+		if match.Status != pendingTournamentMatchStatus || match.FirstID == nil || match.SecondID == nil {
+			continue
+		}
+		if tournament.Format != singleEliminationFormat && match.Round != tournament.CurrentRound {
+			continue
+		}
+		if err := service.createMatchRoom(ctx, tournament, match, names); err != nil {
+			return nil, err
+		}
+		opened = append(opened, match)
+	}
+	return opened, nil
+}
+
+// createMatchRoom создаёт комнату для матча турнира
+//
+// Особенности:
+//   - Комната закрытая, пароль генерируется случайно и сообщается только участникам матча
+//   - Вариант правил, размер поля, длина линии и рейтинговость берутся из турнира
+//   - Создателем комнаты становится первый участник матча; в турнире на выбывание это
+//     выше посеянный, а первый ход при переигровках передаётся по очереди, в швейцарской
+//     и круговой системах первый участник ходит первым (см. colourOrder)
+func (service *TournamentService) createMatchRoom(
+	ctx context.Context,
+	tournament *common.Tournament,
+	match *common.TournamentMatch,
+	names map[uuid.UUID]string,
+) error {
+	isPrivate := true
+	password := uuid.NewString()
+	isRated := tournament.IsRated
+	name := []rune(fmt.Sprintf("%s: %s vs %s", tournament.Name, names[*match.FirstID], names[*match.SecondID]))
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	firstMovePolicy := alternateFirstMovePolicy
+	if tournament.Format != singleEliminationFormat {
+		firstMovePolicy = creatorFirstMovePolicy
+	}
+	form := common.RoomRequest{
+		Name:            string(name),
+		IsPrivate:       &isPrivate,
+		Password:        &password,
+		FirstMovePolicy: firstMovePolicy,
+		Variant:         tournament.Variant,
+		WinLength:       tournament.WinLength,
+		IsRated:         &isRated,
+	}
+	if width, height, ok := parseNotationSize(tournament.BoardSize); ok {
+		form.Width, form.Height = uint8(width), uint8(height)
+	}
+	roomID, err := service.rooms.create(ctx, *match.FirstID, form)
+	if err != nil {
+		return err
+	}
+	match.Status = playingTournamentMatchStatus
+	match.RoomID = &roomID
+	match.RoomPassword = password
+	return nil
+}
+
+// eliminationBracket строит сетку турнира на выбывание
+//
+// Параметры:
+//   - participants: участники по номеру посева
+//
+// Возвращает:
+//   - []*common.TournamentMatch: матчи всех кругов
+//   - uint8: число кругов
+//
+// Особенности:
+//   - Число мест в сетке — ближайшая сверху степень двойки; в первом круге посевы
+//     расставлены так, что сильнейшие могут встретиться только в поздних кругах
+//     (1 и 2 — в финале, 1–4 — в полуфиналах)
+//   - Недостающие места достаются сильнейшим посевам как свободный проход (bye):
+//     такой матч сразу завершён, а его участник уже стоит в матче второго круга
+func eliminationBracket(participants []*common.TournamentParticipant) ([]*common.TournamentMatch, uint8) {
+	size, rounds := 2, uint8(1)
+	for size < len(participants) {
+		size *= 2
+		rounds++
+	}
+	matches := make([]*common.TournamentMatch, 0, size-1)
+	for round := uint8(1); round <= rounds; round++ {
+		for position := range size >> round {
+			matches = append(matches, &common.TournamentMatch{
+				Round:    round,
+				Position: uint16(position),
+				Status:   pendingTournamentMatchStatus,
+			})
+		}
+	}
+	tournament := &common.Tournament{Rounds: rounds}
+	seeds := bracketSeeds(size)
+	for position := range size / 2 {
+		match := matches[position]
+		for slot, seed := range seeds[2*position : 2*position+2] {
+			if seed > len(participants) {
+				continue
+			}
+			userID := participants[seed-1].UserID
+			if slot == 0 {
+				match.FirstID = &userID
+			} else {
+				match.SecondID = &userID
+			}
+		}
+		if match.SecondID == nil {
+			match.Status = byeTournamentMatchStatus
+			advanceWinner(tournament, matches, match, *match.FirstID)
+		}
+	}
+	return matches, rounds
+}
+
+// bracketSeeds возвращает порядок посевов в первом круге сетки на size мест:
+// соседние номера образуют пару. Для 8 мест это 1, 8, 4, 5, 2, 7, 3, 6.
+func bracketSeeds(size int) []int {
+	seeds := []int{1}
+	for len(seeds) < size {
+		next := make([]int, 0, len(seeds)*2)
+		for _, seed := range seeds {
+			next = append(next, seed, len(seeds)*2+1-seed)
+		}
+		seeds = next
+	}
+	return seeds
+}
+
+// advanceWinner отмечает победителя матча и переводит его в матч следующего круга
+//
+// Возвращает:
+//   - *common.TournamentMatch: матч следующего круга (nil для финала,
+//     победитель которого становится победителем турнира)
+func advanceWinner(tournament *common.Tournament, matches []*common.TournamentMatch, match *common.TournamentMatch, winnerID uuid.UUID) *common.TournamentMatch {
+	match.WinnerID = &winnerID
+	if match.Round == tournament.Rounds {
+		tournament.WinnerID = &winnerID
+		return nil
+	}
+	next := bracketMatch(matches, match.Round+1, match.Position/2)
+	if next == nil {
+		return nil
+	}
+	if match.Position%2 == 0 {
+		next.FirstID = &winnerID
+	} else {
+		next.SecondID = &winnerID
+	}
+	return next
+}
+
+// bracketMatch находит матч сетки по кругу и номеру в круге.
+func bracketMatch(matches []*common.TournamentMatch, round uint8, position uint16) *common.TournamentMatch {
+	for _, match := range matches {
+		if match.Round == round && match.Position == position {
+			return match
+		}
+	}
+	return nil
+}
+
+// currentTournamentRound возвращает первый круг, в котором остались нерешённые матчи,
+// или последний круг, если решены все.
+func currentTournamentRound(tournament *common.Tournament, matches []*common.TournamentMatch) uint8 {
+	for _, match := range matches {
+		if match.Status == pendingTournamentMatchStatus || match.Status == playingTournamentMatchStatus {
+			return match.Round
+		}
+	}
+	return tournament.Rounds
+}
+
+// isMatchPlayer проверяет, участвует ли пользователь в матче.
+func isMatchPlayer(match *common.TournamentMatch, userID uuid.UUID) bool {
+	return (match.FirstID != nil && *match.FirstID == userID) ||
+		(match.SecondID != nil && *match.SecondID == userID)
+}
+
+// tournamentPool возвращает пул рейтинга партий турнира: вариант правил и размер поля
+// (размер поля варианта с фиксированным полем или DEFAULT_BORDER_SIZE, если размер не задан).
+func tournamentPool(tournament *common.Tournament) (string, string) {
+	if tournament.BoardSize != "" {
+		return tournament.Variant, tournament.BoardSize
+	}
+	size := uint64(DEFAULT_BORDER_SIZE)
+	if rules, ok := LookupRuleSet(tournament.Variant); ok && rules.Settings().BorderSize > 0 {
+		size = rules.Settings().BorderSize
+	}
+	return tournament.Variant, fmt.Sprintf("%dx%d", size, size)
+}
@@ -0,0 +1,540 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// SWISS_PAIRING_BUDGET ограничивает число перебираемых вариантов при составлении пар
+// швейцарской системы без повторных встреч.
+const SWISS_PAIRING_BUDGET = 100000
+
+// RunScheduler проверяет расписание турниров каждые TOURNAMENT_SCHEDULE_INTERVAL,
+// пока не отменён контекст (см. schedule).
+func (service *TournamentService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(TOURNAMENT_SCHEDULE_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			service.schedule(ctx, now)
+		}
+	}
+}
+
+// StartRound начинает следующий круг швейцарского или кругового турнира
+// (по команде администратора, не дожидаясь интервала кругов; см. startRound).
+//
+// Параметры:
+//   - ctx: контекст выполнения запроса
+//   - id: идентификатор турнира
+//
+// Возвращает:
+//   - *common.Tournament: турнир с начатым кругом
+//   - error: ErrTournamentNotFound, ErrInvalidTournament для турнира на выбывание,
+//     ErrTournamentNotRunning, ErrNoMoreRounds, ErrRoundInProgress или ошибка запроса
+func (service *TournamentService) StartRound(ctx context.Context, id uint64) (*common.Tournament, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	tournament, participants, matches, err := service.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.startRound(ctx, tournament, participants, matches); err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// schedule начинает турниры и круги, время которых наступило
+//
+// Логика:
+//  1. Турнир в регистрации начинается, когда наступает его время старта
+//     (если участников меньше двух, турнир ждёт их или команды администратора)
+//  2. Следующий круг начинается, когда с начала текущего прошёл интервал кругов
+//     и решены все матчи текущего круга
+func (service *TournamentService) schedule(ctx context.Context, now time.Time) {
+	due, err := service.repo.FindDue(ctx, now)
+	if err != nil {
+		slog.Error("[tournament]schedule", slog.String("error", err.Error()))
+		return
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for _, found := range due {
+		tournament, participants, matches, err := service.load(ctx, found.ID)
+		if err != nil {
+			slog.Error("[tournament]schedule", slog.String("error", err.Error()))
+			continue
+		}
+		if tournament.Status == registrationTournamentStatus {
+			err = service.start(ctx, tournament, participants)
+		} else {
+			err = service.startRound(ctx, tournament, participants, matches)
+		}
+		if err != nil &&
+			!errors.Is(err, ErrNotEnoughParticipants) &&
+			!errors.Is(err, ErrRoundInProgress) &&
+			!errors.Is(err, ErrNoMoreRounds) {
+			slog.Error(
+				"[tournament]schedule",
+				slog.Uint64("tournament_id", tournament.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// startRound начинает следующий круг турнира
+//
+// Логика:
+//  1. Круг начинается, только когда решены все матчи текущего
+//  2. В швейцарской системе пары круга составляются по текущей таблице (см. swissRound),
+//     в круговой — матчи круга уже есть в расписании
+//  3. Для матчей круга создаются комнаты (см. createMatchRooms)
+//  4. Новые матчи, открытые матчи и турнир сохраняются в одной транзакции: если комнату
+//     создать не удалось, круг остаётся неначатым и начнётся при следующей попытке
+//
+// Возвращает:
+//   - error: ErrInvalidTournament для турнира на выбывание, ErrTournamentNotRunning,
+//     ErrNoMoreRounds, ErrRoundInProgress или ошибка запроса
+func (service *TournamentService) startRound(
+	ctx context.Context,
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+) error {
+	if tournament.Format == singleEliminationFormat {
+		return fmt.Errorf("%w: knockout rounds start as soon as the previous matches finish", ErrInvalidTournament)
+	}
+	if tournament.Status != runningTournamentStatus {
+		return ErrTournamentNotRunning
+	}
+	if tournament.CurrentRound >= tournament.Rounds {
+		return ErrNoMoreRounds
+	}
+	if !roundFinished(matches, tournament.CurrentRound) {
+		return ErrRoundInProgress
+	}
+	currentRound, roundStartedAt := tournament.CurrentRound, tournament.RoundStartedAt
+	now := time.Now()
+	tournament.CurrentRound++
+	tournament.RoundStartedAt = &now
+	var round []*common.TournamentMatch
+	if tournament.Format == swissFormat {
+		round = swissRound(tournament, participants, matches)
+		matches = append(matches, round...)
+	}
+	opened, err := service.createMatchRooms(ctx, tournament, participants, matches)
+	if err == nil {
+		err = service.repo.StartRound(ctx, tournament, round, opened)
+	}
+	if err != nil {
+		tournament.CurrentRound, tournament.RoundStartedAt = currentRound, roundStartedAt
+		return err
+	}
+	return nil
+}
+
+// finishRoundMatch решает матч швейцарского или кругового турнира
+//
+// Логика:
+//  1. Победа и ничья решают матч (winnerID nil — ничья)
+//  2. Когда решены все матчи последнего круга, турнир заканчивается, а его победителем
+//     становится первый участник таблицы (см. tournamentStandings)
+//  3. Матч и турнир сохраняются в одной транзакции
+//  4. Следующий круг начинается по расписанию или по команде администратора (см. startRound)
+func (service *TournamentService) finishRoundMatch(
+	ctx context.Context,
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+	match *common.TournamentMatch,
+	winnerID *uuid.UUID,
+	gameID *uint64,
+) error {
+	match.Status = finishedTournamentMatchStatus
+	match.WinnerID = winnerID
+	match.GameID = gameID
+	if tournament.CurrentRound == tournament.Rounds && roundFinished(matches, tournament.CurrentRound) {
+		standings := tournamentStandings(tournament, participants, matches)
+		now := time.Now()
+		tournament.Status = finishedTournamentStatus
+		tournament.WinnerID = &standings[0].UserID
+		tournament.FinishedAt = &now
+	}
+	return service.repo.FinishRoundMatch(ctx, tournament, match)
+}
+
+// roundFinished проверяет, решены ли все матчи круга.
+func roundFinished(matches []*common.TournamentMatch, round uint8) bool {
+	for _, match := range matches {
+		if match.Round == round && (match.Status == pendingTournamentMatchStatus || match.Status == playingTournamentMatchStatus) {
+			return false
+		}
+	}
+	return true
+}
+
+// swissRounds возвращает число кругов швейцарского турнира: заданное или, если оно
+// не задано, столько, сколько нужно, чтобы выявить единственного лидера (log2 участников
+// с округлением вверх). Кругов не больше, чем можно сыграть без повторных встреч.
+func swissRounds(requested uint8, participants int) uint8 {
+	rounds := requested
+	if rounds == 0 {
+		rounds = uint8(bits.Len(uint(participants - 1)))
+	}
+	limit := participants - 1
+	if participants%2 == 1 {
+		limit = participants
+	}
+	return uint8(min(int(rounds), limit))
+}
+
+// swissRound составляет пары текущего круга швейцарской системы
+//
+// Параметры:
+//   - tournament: турнир с номером текущего круга
+//   - participants: участники турнира
+//   - matches: матчи прошлых кругов
+//
+// Логика:
+//  1. Участники упорядочиваются по таблице (см. tournamentStandings), в первом круге — по посеву
+//  2. При нечётном числе участников свободный проход (очко без игры) получает последний
+//     в таблице участник, у которого ещё не было свободного прохода
+//  3. Пары составляются без повторных встреч (см. swissPairs)
+//  4. Кто ходит первым, определяется балансом первых ходов (см. colourOrder)
+func swissRound(
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+) []*common.TournamentMatch {
+	standings := tournamentStandings(tournament, participants, matches)
+	ranked := make([]uuid.UUID, 0, len(standings))
+	points := make(map[uuid.UUID]float64, len(standings))
+	for _, standing := range standings {
+		ranked = append(ranked, standing.UserID)
+		points[standing.UserID] = standing.Points
+	}
+	played := make(map[[2]uuid.UUID]bool)
+	hadBye := make(map[uuid.UUID]bool)
+	for _, match := range matches {
+		if match.Status == byeTournamentMatchStatus && match.FirstID != nil {
+			hadBye[*match.FirstID] = true
+		}
+		if match.FirstID != nil && match.SecondID != nil {
+			played[pairKey(*match.FirstID, *match.SecondID)] = true
+		}
+	}
+	var bye *uuid.UUID
+	if len(ranked)%2 == 1 {
+		index := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !hadBye[ranked[i]] {
+				index = i
+				break
+			}
+		}
+		byeID := ranked[index]
+		bye = &byeID
+		ranked = append(ranked[:index:index], ranked[index+1:]...)
+	}
+	budget := SWISS_PAIRING_BUDGET
+	pairs, ok := swissPairs(ranked, points, played, &budget)
+	if !ok {
+		pairs = make([][2]uuid.UUID, 0, len(ranked)/2)
+		for i := 0; i+1 < len(ranked); i += 2 {
+			pairs = append(pairs, [2]uuid.UUID{ranked[i], ranked[i+1]})
+		}
+	}
+	round := make([]*common.TournamentMatch, 0, len(pairs)+1)
+	for position, pair := range pairs {
+		first, second := colourOrder(pair[0], pair[1], position, matches)
+		round = append(round, &common.TournamentMatch{
+			Round:    tournament.CurrentRound,
+			Position: uint16(position),
+			FirstID:  &first,
+			SecondID: &second,
+			Status:   pendingTournamentMatchStatus,
+		})
+	}
+	if bye != nil {
+		round = append(round, &common.TournamentMatch{
+			Round:    tournament.CurrentRound,
+			Position: uint16(len(pairs)),
+			FirstID:  bye,
+			WinnerID: bye,
+			Status:   byeTournamentMatchStatus,
+		})
+	}
+	return round
+}
+
+// swissPairs составляет пары из участников, упорядоченных по таблице, без повторных встреч
+//
+// Особенности:
+//   - Первому участнику соперник ищется сначала в его группе очков: верхняя половина группы
+//     играет с нижней (1-й с первым из нижней половины и т.д.), затем в группах ниже
+//   - Если дальше пары не составить, выбор соперника пересматривается (перебор с возвратом),
+//     но не дольше budget вариантов
+//   - Возвращает false, если пары без повторных встреч не найдены; тогда пары
+//     составляются по порядку таблицы
+func swissPairs(ranked []uuid.UUID, points map[uuid.UUID]float64, played map[[2]uuid.UUID]bool, budget *int) ([][2]uuid.UUID, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+	*budget--
+	if *budget < 0 {
+		return nil, false
+	}
+	group := 1
+	for group < len(ranked) && points[ranked[group]] == points[ranked[0]] {
+		group++
+	}
+	candidates := make([]int, 0, len(ranked)-1)
+	for i := group / 2; i < group; i++ {
+		if i > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	for i := group/2 - 1; i >= 1; i-- {
+		candidates = append(candidates, i)
+	}
+	for i := group; i < len(ranked); i++ {
+		candidates = append(candidates, i)
+	}
+	for _, i := range candidates {
+		if played[pairKey(ranked[0], ranked[i])] {
+			continue
+		}
+		rest := make([]uuid.UUID, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+		pairs, ok := swissPairs(rest, points, played, budget)
+		if ok {
+			return append([][2]uuid.UUID{{ranked[0], ranked[i]}}, pairs...), true
+		}
+		if *budget < 0 {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// roundRobinSchedule строит расписание круговой системы методом вращения
+//
+// Параметры:
+//   - participants: участники по номеру посева
+//
+// Возвращает:
+//   - []*common.TournamentMatch: матчи всех кругов
+//   - uint8: число кругов (участников без одного, при нечётном числе — участников)
+//
+// Особенности:
+//   - Первый посев остаётся на месте, остальные сдвигаются по кругу на каждый круг
+//   - При нечётном числе участников один из них пропускает круг без очков
+//   - Кто ходит первым, определяется балансом первых ходов (см. colourOrder)
+func roundRobinSchedule(participants []*common.TournamentParticipant) ([]*common.TournamentMatch, uint8) {
+	players := make([]*uuid.UUID, 0, len(participants)+1)
+	for _, participant := range participants {
+		players = append(players, &participant.UserID)
+	}
+	if len(players)%2 == 1 {
+		players = append(players, nil)
+	}
+	size := len(players)
+	matches := make([]*common.TournamentMatch, 0, size*(size-1)/2)
+	for round := 1; round < size; round++ {
+		position := 0
+		for i := range size / 2 {
+			home, away := players[i], players[size-1-i]
+			if home == nil || away == nil {
+				continue
+			}
+			first, second := colourOrder(*home, *away, position, matches)
+			matches = append(matches, &common.TournamentMatch{
+				Round:    uint8(round),
+				Position: uint16(position),
+				FirstID:  &first,
+				SecondID: &second,
+				Status:   pendingTournamentMatchStatus,
+			})
+			position++
+		}
+		last := players[size-1]
+		copy(players[2:], players[1:size-1])
+		players[1] = last
+	}
+	return matches, uint8(size - 1)
+}
+
+// colourOrder определяет, кто из пары ходит первым
+//
+// Параметры:
+//   - first, second: участники пары (first — выше в таблице или по посеву)
+//   - position: номер матча в круге
+//   - matches: матчи прошлых кругов
+//
+// Возвращает:
+//   - uuid.UUID: участник, который ходит первым
+//   - uuid.UUID: участник, который ходит вторым
+//
+// Правила:
+//  1. Первым ходит участник, который реже ходил первым, чем вторым
+//  2. При равном балансе первым ходит тот, кто в прошлом матче ходил вторым
+//  3. Иначе первый ход чередуется по столам: на чётных первым ходит участник выше в таблице
+func colourOrder(first, second uuid.UUID, position int, matches []*common.TournamentMatch) (uuid.UUID, uuid.UUID) {
+	balance := make(map[uuid.UUID]int)
+	movedFirst := make(map[uuid.UUID]bool)
+	for _, match := range matches {
+		if match.FirstID == nil || match.SecondID == nil {
+			continue
+		}
+		balance[*match.FirstID]++
+		balance[*match.SecondID]--
+		movedFirst[*match.FirstID] = true
+		movedFirst[*match.SecondID] = false
+	}
+	if balance[first] != balance[second] {
+		if balance[first] < balance[second] {
+			return first, second
+		}
+		return second, first
+	}
+	firstLast, firstPlayed := movedFirst[first]
+	secondLast, secondPlayed := movedFirst[second]
+	if firstPlayed && secondPlayed && firstLast != secondLast {
+		if firstLast {
+			return second, first
+		}
+		return first, second
+	}
+	if position%2 == 0 {
+		return first, second
+	}
+	return second, first
+}
+
+// tournamentStandings вычисляет турнирную таблицу по решённым матчам
+//
+// Особенности:
+//   - Победа и свободный проход дают 1 очко, ничья — 0.5
+//   - Коэффициент Бухгольца — сумма очков сыгранных соперников, коэффициент
+//     Зоннеборна-Бергера — сумма очков побеждённых соперников и половины очков
+//     соперников, сыгранных вничью
+//   - Порядок: очки, затем в швейцарской системе Бухгольц и Зоннеборн-Бергер,
+//     в круговой — Зоннеборн-Бергер и число побед, затем номер посева
+func tournamentStandings(
+	tournament *common.Tournament,
+	participants []*common.TournamentParticipant,
+	matches []*common.TournamentMatch,
+) []*common.TournamentStanding {
+	type result struct {
+		opponentID uuid.UUID
+		score      float64
+	}
+	standings := make([]*common.TournamentStanding, 0, len(participants))
+	rows := make(map[uuid.UUID]*common.TournamentStanding, len(participants))
+	for _, participant := range participants {
+		standing := &common.TournamentStanding{
+			UserID: participant.UserID,
+			Name:   participant.Name,
+			Seed:   participant.Seed,
+		}
+		standings = append(standings, standing)
+		rows[participant.UserID] = standing
+	}
+	results := make(map[uuid.UUID][]result, len(participants))
+	for _, match := range matches {
+		if match.Status == byeTournamentMatchStatus && match.FirstID != nil {
+			if row := rows[*match.FirstID]; row != nil {
+				row.Byes++
+				row.Points++
+			}
+			continue
+		}
+		if match.Status != finishedTournamentMatchStatus || match.FirstID == nil || match.SecondID == nil {
+			continue
+		}
+		first, second := rows[*match.FirstID], rows[*match.SecondID]
+		if first == nil || second == nil {
+			continue
+		}
+		score := 0.5
+		if match.WinnerID != nil {
+			score = 0
+			if *match.WinnerID == first.UserID {
+				score = 1
+			}
+		}
+		first.FirstMoves++
+		for _, side := range []struct {
+			row, opponent *common.TournamentStanding
+			score         float64
+		}{{first, second, score}, {second, first, 1 - score}} {
+			side.row.Played++
+			side.row.Points += side.score
+			switch side.score {
+			case 1:
+				side.row.Wins++
+			case 0:
+				side.row.Losses++
+			default:
+				side.row.Draws++
+			}
+			results[side.row.UserID] = append(results[side.row.UserID], result{opponentID: side.opponent.UserID, score: side.score})
+		}
+	}
+	for userID, userResults := range results {
+		for _, result := range userResults {
+			rows[userID].Buchholz += rows[result.opponentID].Points
+			rows[userID].SonnebornBerger += rows[result.opponentID].Points * result.score
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if tournament.Format == roundRobinFormat {
+			if a.SonnebornBerger != b.SonnebornBerger {
+				return a.SonnebornBerger > b.SonnebornBerger
+			}
+			if a.Wins != b.Wins {
+				return a.Wins > b.Wins
+			}
+		} else {
+			if a.Buchholz != b.Buchholz {
+				return a.Buchholz > b.Buchholz
+			}
+			if a.SonnebornBerger != b.SonnebornBerger {
+				return a.SonnebornBerger > b.SonnebornBerger
+			}
+		}
+		return a.Seed < b.Seed
+	})
+	for i, standing := range standings {
+		standing.Rank = i + 1
+	}
+	return standings
+}
+
+// pairKey возвращает ключ пары участников, не зависящий от их порядка.
+func pairKey(first, second uuid.UUID) [2]uuid.UUID {
+	if first.String() > second.String() {
+		first, second = second, first
+	}
+	return [2]uuid.UUID{first, second}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+func TestSwissPairs(t *testing.T) {
+	players := make([]uuid.UUID, 6)
+	for i := range players {
+		players[i] = uuid.New()
+	}
+	a, b, c, d, e, f := players[0], players[1], players[2], players[3], players[4], players[5]
+	tests := []struct {
+		name   string
+		ranked []uuid.UUID
+		points map[uuid.UUID]float64
+		played [][2]uuid.UUID
+		budget int
+		want   [][2]uuid.UUID
+		ok     bool
+	}{
+		{
+			name:   "top half plays bottom half",
+			ranked: []uuid.UUID{a, b, c, d},
+			budget: SWISS_PAIRING_BUDGET,
+			want:   [][2]uuid.UUID{{a, c}, {b, d}},
+			ok:     true,
+		},
+		{
+			name:   "score groups are paired separately",
+			ranked: []uuid.UUID{a, b, c, d},
+			points: map[uuid.UUID]float64{a: 1, b: 1},
+			budget: SWISS_PAIRING_BUDGET,
+			want:   [][2]uuid.UUID{{a, b}, {c, d}},
+			ok:     true,
+		},
+		{
+			name:   "rematch is avoided inside the group",
+			ranked: []uuid.UUID{a, b, c, d},
+			played: [][2]uuid.UUID{{a, c}},
+			budget: SWISS_PAIRING_BUDGET,
+			want:   [][2]uuid.UUID{{a, d}, {b, c}},
+			ok:     true,
+		},
+		{
+			name:   "leader floats down to the next group",
+			ranked: []uuid.UUID{a, b, c, d},
+			points: map[uuid.UUID]float64{a: 1, b: 1},
+			played: [][2]uuid.UUID{{a, b}},
+			budget: SWISS_PAIRING_BUDGET,
+			want:   [][2]uuid.UUID{{a, c}, {b, d}},
+			ok:     true,
+		},
+		{
+			name:   "backtracking revises an earlier choice",
+			ranked: []uuid.UUID{a, b, c, d, e, f},
+			played: [][2]uuid.UUID{{a, d}, {b, e}, {b, f}, {c, e}, {c, f}},
+			budget: SWISS_PAIRING_BUDGET,
+			want:   [][2]uuid.UUID{{a, e}, {b, c}, {d, f}},
+			ok:     true,
+		},
+		{
+			name:   "everyone already met",
+			ranked: []uuid.UUID{a, b},
+			played: [][2]uuid.UUID{{b, a}},
+			budget: SWISS_PAIRING_BUDGET,
+		},
+		{
+			name:   "budget exhausted",
+			ranked: []uuid.UUID{a, b, c, d},
+			budget: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := tt.points
+			if points == nil {
+				points = map[uuid.UUID]float64{}
+			}
+			played := make(map[[2]uuid.UUID]bool, len(tt.played))
+			for _, pair := range tt.played {
+				played[pairKey(pair[0], pair[1])] = true
+			}
+			budget := tt.budget
+			pairs, ok := swissPairs(tt.ranked, points, played, &budget)
+			if ok != tt.ok {
+				t.Fatalf("swissPairs() ok = %v, want %v", ok, tt.ok)
+			}
+			if !slices.Equal(pairs, tt.want) {
+				t.Errorf("swissPairs() = %v, want %v", pairs, tt.want)
+			}
+		})
+	}
+}
+
+func TestColourOrder(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	match := func(first, second uuid.UUID) *common.TournamentMatch {
+		return &common.TournamentMatch{FirstID: &first, SecondID: &second}
+	}
+	tests := []struct {
+		name     string
+		first    uuid.UUID
+		second   uuid.UUID
+		position int
+		matches  []*common.TournamentMatch
+		want     [2]uuid.UUID
+	}{
+		{
+			name:   "even table starts with the higher player",
+			first:  a,
+			second: b,
+			want:   [2]uuid.UUID{a, b},
+		},
+		{
+			name:     "odd table starts with the lower player",
+			first:    a,
+			second:   b,
+			position: 1,
+			want:     [2]uuid.UUID{b, a},
+		},
+		{
+			name:    "fewer first moves goes first",
+			first:   a,
+			second:  b,
+			matches: []*common.TournamentMatch{match(a, c)},
+			want:    [2]uuid.UUID{b, a},
+		},
+		{
+			name:    "balance beats the table rule",
+			first:   b,
+			second:  a,
+			matches: []*common.TournamentMatch{match(c, a), match(d, a), match(b, c)},
+			want:    [2]uuid.UUID{a, b},
+		},
+		{
+			name:    "equal balance alternates after the last game",
+			first:   b,
+			second:  a,
+			matches: []*common.TournamentMatch{match(a, c), match(d, a), match(c, b), match(b, d)},
+			want:    [2]uuid.UUID{a, b},
+		},
+		{
+			name:    "bye does not count",
+			first:   a,
+			second:  b,
+			matches: []*common.TournamentMatch{{FirstID: &b, Status: byeTournamentMatchStatus}},
+			want:    [2]uuid.UUID{a, b},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := colourOrder(tt.first, tt.second, tt.position, tt.matches)
+			if [2]uuid.UUID{first, second} != tt.want {
+				t.Errorf("colourOrder() = %v, %v, want %v", first, second, tt.want)
+			}
+		})
+	}
+}
+
+func TestTournamentStandings(t *testing.T) {
+	participants := testParticipants(4)
+	a, b, c, d := participants[0].UserID, participants[1].UserID, participants[2].UserID, participants[3].UserID
+	finished := func(first, second uuid.UUID, winner *uuid.UUID) *common.TournamentMatch {
+		return &common.TournamentMatch{FirstID: &first, SecondID: &second, WinnerID: winner, Status: finishedTournamentMatchStatus}
+	}
+	matches := []*common.TournamentMatch{
+		finished(a, b, &a),
+		finished(c, d, nil),
+		finished(a, c, nil),
+		{FirstID: &b, WinnerID: &b, Status: byeTournamentMatchStatus},
+		{FirstID: &d, SecondID: &c, Status: pendingTournamentMatchStatus},
+	}
+	tests := []struct {
+		name   string
+		format string
+		order  []uuid.UUID
+	}{
+		// b и c набрали по очку; Бухгольц c (a и d: 1.5 + 0.5) больше, чем у b (a: 1.5),
+		// свободный проход в Бухгольц не входит
+		{name: "swiss", format: swissFormat, order: []uuid.UUID{a, c, b, d}},
+		// Зоннеборн-Бергер c (половины очков a и d: 0.75 + 0.25) больше, чем у b (0)
+		{name: "round robin", format: roundRobinFormat, order: []uuid.UUID{a, c, b, d}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := tournamentStandings(&common.Tournament{Format: tt.format}, participants, matches)
+			order := make([]uuid.UUID, 0, len(standings))
+			for i, standing := range standings {
+				order = append(order, standing.UserID)
+				if standing.Rank != i+1 {
+					t.Errorf("tournamentStandings() rank of %s = %d, want %d", standing.Name, standing.Rank, i+1)
+				}
+			}
+			if !slices.Equal(order, tt.order) {
+				t.Errorf("tournamentStandings() order = %v, want %v", order, tt.order)
+			}
+			leader := standings[0]
+			if leader.Points != 1.5 || leader.Played != 2 || leader.Wins != 1 || leader.Draws != 1 || leader.FirstMoves != 2 {
+				t.Errorf("tournamentStandings() leader = %+v", leader)
+			}
+			if leader.Buchholz != 2 || leader.SonnebornBerger != 1.5 {
+				t.Errorf("tournamentStandings() leader buchholz, sonneborn-berger = %v, %v, want 2, 1.5", leader.Buchholz, leader.SonnebornBerger)
+			}
+			if standings[2].Byes != 1 || standings[2].Points != 1 || standings[2].Losses != 1 {
+				t.Errorf("tournamentStandings() bye player = %+v", standings[2])
+			}
+		})
+	}
+}
+
+func TestTournamentStandingsSeedTieBreak(t *testing.T) {
+	participants := testParticipants(3)
+	reversed := []*common.TournamentParticipant{participants[2], participants[0], participants[1]}
+	standings := tournamentStandings(&common.Tournament{Format: swissFormat}, reversed, nil)
+	for i, standing := range standings {
+		if standing.Seed != uint16(i+1) {
+			t.Errorf("tournamentStandings() position %d has seed %d", i+1, standing.Seed)
+		}
+	}
+}
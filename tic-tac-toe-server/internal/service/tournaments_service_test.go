@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// testParticipants возвращает count участников с посевами от 1.
+func testParticipants(count int) []*common.TournamentParticipant {
+	participants := make([]*common.TournamentParticipant, 0, count)
+	for seed := 1; seed <= count; seed++ {
+		participants = append(participants, &common.TournamentParticipant{
+			UserID: uuid.New(),
+			Name:   fmt.Sprintf("player%d", seed),
+			Seed:   uint16(seed),
+		})
+	}
+	return participants
+}
+
+func TestBracketSeeds(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 1, want: []int{1}},
+		{size: 2, want: []int{1, 2}},
+		{size: 4, want: []int{1, 4, 2, 3}},
+		{size: 8, want: []int{1, 8, 4, 5, 2, 7, 3, 6}},
+		{size: 16, want: []int{1, 16, 8, 9, 4, 13, 5, 12, 2, 15, 7, 10, 3, 14, 6, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.size), func(t *testing.T) {
+			if got := bracketSeeds(tt.size); !slices.Equal(got, tt.want) {
+				t.Errorf("bracketSeeds(%d) = %v, want %v", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEliminationBracket(t *testing.T) {
+	tests := []struct {
+		participants int
+		rounds       uint8
+		matches      int
+		// byes — посевы, проходящие первый круг без игры
+		byes []int
+	}{
+		{participants: 2, rounds: 1, matches: 1},
+		{participants: 3, rounds: 2, matches: 3, byes: []int{1}},
+		{participants: 5, rounds: 3, matches: 7, byes: []int{1, 2, 3}},
+		{participants: 8, rounds: 3, matches: 7},
+		{participants: 9, rounds: 4, matches: 15, byes: []int{1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.participants), func(t *testing.T) {
+			participants := testParticipants(tt.participants)
+			seeds := make(map[uuid.UUID]int, len(participants))
+			for _, participant := range participants {
+				seeds[participant.UserID] = int(participant.Seed)
+			}
+			matches, rounds := eliminationBracket(participants)
+			if rounds != tt.rounds || len(matches) != tt.matches {
+				t.Fatalf("eliminationBracket() = %d matches in %d rounds, want %d in %d", len(matches), rounds, tt.matches, tt.rounds)
+			}
+			var byes []int
+			placed := make(map[int]bool)
+			for _, match := range matches {
+				if match.Round != 1 {
+					continue
+				}
+				for _, id := range []*uuid.UUID{match.FirstID, match.SecondID} {
+					if id == nil {
+						continue
+					}
+					if placed[seeds[*id]] {
+						t.Errorf("eliminationBracket() seed %d placed twice", seeds[*id])
+					}
+					placed[seeds[*id]] = true
+				}
+				switch match.Status {
+				case byeTournamentMatchStatus:
+					if match.FirstID == nil || match.SecondID != nil || match.WinnerID == nil || *match.WinnerID != *match.FirstID {
+						t.Errorf("eliminationBracket() bye match %d is not won by its only player", match.Position)
+						continue
+					}
+					byes = append(byes, seeds[*match.FirstID])
+					next := bracketMatch(matches, 2, match.Position/2)
+					slot := next.FirstID
+					if match.Position%2 == 1 {
+						slot = next.SecondID
+					}
+					if slot == nil || *slot != *match.FirstID {
+						t.Errorf("eliminationBracket() seed %d is not advanced to round 2", seeds[*match.FirstID])
+					}
+				case pendingTournamentMatchStatus:
+					if match.FirstID == nil || match.SecondID == nil {
+						t.Errorf("eliminationBracket() pending match %d has an empty slot", match.Position)
+					}
+				default:
+					t.Errorf("eliminationBracket() match %d status = %q", match.Position, match.Status)
+				}
+			}
+			if len(placed) != tt.participants {
+				t.Errorf("eliminationBracket() placed %d participants, want %d", len(placed), tt.participants)
+			}
+			slices.Sort(byes)
+			if !slices.Equal(byes, tt.byes) {
+				t.Errorf("eliminationBracket() byes = %v, want %v", byes, tt.byes)
+			}
+		})
+	}
+}
+
+func TestAdvanceWinner(t *testing.T) {
+	participants := testParticipants(4)
+	matches, rounds := eliminationBracket(participants)
+	tournament := &common.Tournament{Rounds: rounds}
+	first, fourth := participants[0].UserID, participants[3].UserID
+	next := advanceWinner(tournament, matches, bracketMatch(matches, 1, 0), fourth)
+	if next == nil || next.Round != 2 || next.FirstID == nil || *next.FirstID != fourth {
+		t.Fatalf("advanceWinner() did not place the winner of match 0 first in the final")
+	}
+	next = advanceWinner(tournament, matches, bracketMatch(matches, 1, 1), first)
+	if next == nil || next.SecondID == nil || *next.SecondID != first {
+		t.Fatalf("advanceWinner() did not place the winner of match 1 second in the final")
+	}
+	if next = advanceWinner(tournament, matches, next, first); next != nil {
+		t.Errorf("advanceWinner() after the final = %v, want nil", next)
+	}
+	if tournament.WinnerID == nil || *tournament.WinnerID != first {
+		t.Errorf("advanceWinner() tournament winner is not set")
+	}
+}
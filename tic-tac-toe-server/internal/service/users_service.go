@@ -11,19 +11,26 @@ import (
 
 // UserService предоставляет методы для получения информации о пользователе.
 type UserService struct {
-	userRepo  repository.UserRepository
-	scoreRepo repository.ScoreRepository
+	userRepo   repository.UserRepository
+	scoreRepo  repository.ScoreRepository
+	ratingRepo repository.RatingRepository
 }
 
 // NewUserService создаёт новый экземпляр UserService.
-func NewUserService(userRepo repository.UserRepository, scoreRepo repository.ScoreRepository) *UserService {
+func NewUserService(
+	userRepo repository.UserRepository,
+	scoreRepo repository.ScoreRepository,
+	ratingRepo repository.RatingRepository,
+) *UserService {
 	return &UserService{
-		userRepo:  userRepo,
-		scoreRepo: scoreRepo,
+		userRepo:   userRepo,
+		scoreRepo:  scoreRepo,
+		ratingRepo: ratingRepo,
 	}
 }
 
-// GetCurrentUser возвращает информацию о текущем пользователе, включая счёт побед.
+// GetCurrentUser возвращает информацию о текущем пользователе, включая счёт побед
+// в целом и по каждому варианту правил, и его рейтинги в рейтинговых партиях.
 func (service *UserService) GetCurrentUser(ctx context.Context) (*common.UserResponse, error) {
 	email, ok := ctx.Value(common.USER_MAIL).(string)
 	if !ok {
@@ -37,12 +44,24 @@ func (service *UserService) GetCurrentUser(ctx context.Context) (*common.UserRes
 	if err != nil {
 		return nil, err
 	}
+	wonScoreByVariant, err := service.scoreRepo.GetWonScoreByVariant(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := service.ratingRepo.FindByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
 	userResponse := &common.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: &user.CreatedAt,
-		WonScore:  &currentWonScore,
+		ID:                user.ID,
+		Name:              user.Name,
+		Email:             user.Email,
+		IsAdmin:           user.IsAdmin,
+		PuzzleRating:      &user.PuzzleRating,
+		Ratings:           ratings,
+		CreatedAt:         &user.CreatedAt,
+		WonScore:          &currentWonScore,
+		WonScoreByVariant: wonScoreByVariant,
 	}
 	return userResponse, nil
 }
@@ -0,0 +1,218 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+)
+
+// VERSUS_RECENT_GAMES задаёт число последних партий в личных встречах.
+const VERSUS_RECENT_GAMES = 10
+
+// Ошибки статистики пользователей.
+var (
+	// ErrUserNotFound возвращается, если пользователь не найден.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidVersus возвращается при запросе личных встреч пользователя с самим собой.
+	ErrInvalidVersus = errors.New("user cannot play against themselves")
+)
+
+// GetStats возвращает статистику пользователя
+//
+// Параметры:
+//   - ctx: контекст запроса
+//   - id: идентификатор пользователя
+//
+// Логика:
+//  1. Результаты пользователя читаются от старых к новым
+//  2. Каждый результат учитывается в общей статистике и в статистике своего
+//     варианта правил и размера поля
+//  3. Серии побед считаются по порядку партий, ничья и поражение прерывают серию
+//  4. Сдача и поражение по времени считаются поражениями, брошенные партии не учитываются
+//
+// Возвращает:
+//   - *common.UserStatsResponse: общая статистика и статистика по вариантам и размерам поля
+//   - error: ErrUserNotFound или ошибка запроса
+//
+// Особенности:
+//   - Результаты, сохранённые до появления размера поля, символа и длины партии,
+//     учитываются в счёте, но не в разбивке по символам и средней длине партии
+func (service *UserService) GetStats(ctx context.Context, id uuid.UUID) (*common.UserStatsResponse, error) {
+	user, err := service.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	scores, err := service.scoreRepo.FindResultsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	overall := newStatsCollector("", "")
+	pools := make([]*statsCollector, 0)
+	poolIndex := make(map[[2]string]*statsCollector)
+	for _, score := range scores {
+		overall.add(score)
+		key := [2]string{score.Variant, score.BoardSize}
+		pool, ok := poolIndex[key]
+		if !ok {
+			pool = newStatsCollector(score.Variant, score.BoardSize)
+			poolIndex[key] = pool
+			pools = append(pools, pool)
+		}
+		pool.add(score)
+	}
+	response := &common.UserStatsResponse{
+		UserID:  user.ID,
+		Name:    user.Name,
+		Overall: overall.result(),
+		Pools:   make([]*common.UserStats, 0, len(pools)),
+	}
+	for _, pool := range pools {
+		response.Pools = append(response.Pools, pool.result())
+	}
+	return response, nil
+}
+
+// GetVersus возвращает личные встречи пользователя с соперником
+//
+// Параметры:
+//   - ctx: контекст запроса
+//   - id: идентификатор пользователя
+//   - opponentID: идентификатор соперника
+//
+// Возвращает:
+//   - *common.VersusResponse: счёт пользователя, изменение его общего рейтинга
+//     и последние VERSUS_RECENT_GAMES партий с соперником
+//   - error: ErrUserNotFound, ErrInvalidVersus или ошибка запроса
+//
+// Особенности:
+//   - Учитываются только результаты, в которых сохранены идентификаторы соперников
+func (service *UserService) GetVersus(ctx context.Context, id, opponentID uuid.UUID) (*common.VersusResponse, error) {
+	if id == opponentID {
+		return nil, ErrInvalidVersus
+	}
+	users := make([]*common.UserResponse, 0, 2)
+	for _, userID := range []uuid.UUID{id, opponentID} {
+		user, err := service.userRepo.FindById(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		users = append(users, &common.UserResponse{
+			ID:   user.ID,
+			Name: user.Name,
+		})
+	}
+	record, err := service.scoreRepo.GetVersusRecord(ctx, id, opponentID)
+	if err != nil {
+		return nil, err
+	}
+	ratedGames, ratingChange, err := service.scoreRepo.GetVersusRatingChange(ctx, id, opponentID)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := service.scoreRepo.FindVersusGames(ctx, id, opponentID, VERSUS_RECENT_GAMES)
+	if err != nil {
+		return nil, err
+	}
+	return &common.VersusResponse{
+		User:         users[0],
+		Opponent:     users[1],
+		Record:       *record,
+		RatedGames:   ratedGames,
+		RatingChange: ratingChange,
+		Recent:       recent,
+	}, nil
+}
+
+// statsCollector накапливает статистику по результатам, переданным по порядку партий.
+type statsCollector struct {
+	stats       *common.UserStats
+	moves       uint
+	knownLength uint
+}
+
+// newStatsCollector создаёт пустую статистику варианта и размера поля
+// (пустые — общая статистика).
+func newStatsCollector(variant, boardSize string) *statsCollector {
+	return &statsCollector{
+		stats: &common.UserStats{
+			Variant:   variant,
+			BoardSize: boardSize,
+			BySymbol:  make(map[string]*common.ResultStats),
+			Daily:     make([]*common.DailyStats, 0),
+		},
+	}
+}
+
+// add учитывает результат партии (брошенные партии пропускаются).
+func (collector *statsCollector) add(score *common.Score) {
+	if score.Result == abandonedResult {
+		return
+	}
+	stats := collector.stats
+	addResult(&stats.ResultStats, score.Result)
+	if score.Result == winResult {
+		stats.CurrentStreak++
+		stats.BestStreak = max(stats.BestStreak, stats.CurrentStreak)
+	} else {
+		stats.CurrentStreak = 0
+	}
+	if score.Moves > 0 {
+		collector.moves += score.Moves
+		collector.knownLength++
+	}
+	if score.Symbol != "" {
+		if stats.BySymbol[score.Symbol] == nil {
+			stats.BySymbol[score.Symbol] = &common.ResultStats{}
+		}
+		addResult(stats.BySymbol[score.Symbol], score.Result)
+	}
+	day := score.CreatedAt.Format(time.DateOnly)
+	if len(stats.Daily) == 0 || stats.Daily[len(stats.Daily)-1].Day != day {
+		stats.Daily = append(stats.Daily, &common.DailyStats{Day: day})
+	}
+	daily := stats.Daily[len(stats.Daily)-1]
+	switch score.Result {
+	case winResult:
+		daily.Wins++
+	case drawResult:
+		daily.Draws++
+	default:
+		daily.Losses++
+	}
+}
+
+// result возвращает накопленную статистику.
+func (collector *statsCollector) result() *common.UserStats {
+	if collector.knownLength > 0 {
+		average := float64(collector.moves) / float64(collector.knownLength)
+		collector.stats.AverageLength = &average
+	}
+	return collector.stats
+}
+
+// addResult добавляет к счёту партий результат (сдача и время — поражения)
+// и пересчитывает долю побед.
+func addResult(stats *common.ResultStats, result string) {
+	stats.Games++
+	switch result {
+	case winResult:
+		stats.Wins++
+	case drawResult:
+		stats.Draws++
+	default:
+		stats.Losses++
+	}
+	stats.WinRate = float64(stats.Wins) / float64(stats.Games)
+}
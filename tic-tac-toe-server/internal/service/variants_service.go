@@ -0,0 +1,100 @@
+// Package service реализует бизнес-логику приложения.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/common"
+	"github.com/margar-melkonyan/tic-tac-toe-game/tic-tac-toe.git/internal/repository"
+)
+
+// ErrInvalidVariantSpec возвращается, если описание пользовательского варианта противоречиво.
+var ErrInvalidVariantSpec = errors.New("invalid variant spec")
+
+// VariantService предоставляет методы для работы с вариантами правил.
+type VariantService struct {
+	repo repository.VariantRepository
+}
+
+// NewVariantService создаёт новый экземпляр VariantService с указанным репозиторием.
+func NewVariantService(repo repository.VariantRepository) *VariantService {
+	return &VariantService{
+		repo: repo,
+	}
+}
+
+// LoadCustomVariants регистрирует наборы правил для сохранённых пользовательских вариантов.
+// Варианты с описанием, которое больше не проходит проверку, пропускаются с записью в лог.
+func (service *VariantService) LoadCustomVariants(ctx context.Context) error {
+	variants, err := service.repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err := validateVariantSpec(variant.Spec); err != nil {
+			slog.Error("skip custom variant", slog.String("name", variant.Name), slog.String("error", err.Error()))
+			continue
+		}
+		RegisterRuleSet(newCustomRuleSet(variant.Spec))
+	}
+	return nil
+}
+
+// GetAll возвращает все зарегистрированные варианты правил в алфавитном порядке.
+func (service *VariantService) GetAll() []*common.VariantResponse {
+	names := RuleSetNames()
+	variants := make([]*common.VariantResponse, 0, len(names))
+	for _, name := range names {
+		rules, ok := LookupRuleSet(name)
+		if !ok {
+			continue
+		}
+		settings := rules.Settings()
+		variant := &common.VariantResponse{
+			Name:        name,
+			Multiplayer: settings.Multiplayer,
+			BorderSize:  settings.BorderSize,
+		}
+		if custom, ok := rules.(*customRuleSet); ok {
+			spec := custom.spec
+			variant.Custom = true
+			variant.Spec = &spec
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// Validate проверяет согласованность описания варианта (теги validate проверяются в обработчике).
+func (service *VariantService) Validate(spec common.VariantSpec) error {
+	if err := validateVariantSpec(spec); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVariantSpec, err)
+	}
+	return nil
+}
+
+// Save проверяет описание варианта, сохраняет его от имени текущего пользователя
+// и сразу регистрирует набор правил, чтобы в варианте можно было создавать комнаты.
+// Повторная загрузка варианта с тем же именем заменяет описание для новых партий.
+func (service *VariantService) Save(ctx context.Context, spec common.VariantSpec) error {
+	if err := service.Validate(spec); err != nil {
+		return err
+	}
+	user, ok := ctx.Value(common.USER).(*common.User)
+	if !ok {
+		return errors.New("userId is not correct")
+	}
+	err := service.repo.Upsert(ctx, &common.CustomVariant{
+		Name:      spec.Name,
+		Spec:      spec,
+		CreatorID: user.ID,
+	})
+	if err != nil {
+		return err
+	}
+	RegisterRuleSet(newCustomRuleSet(spec))
+	return nil
+}